@@ -20,8 +20,9 @@ const (
 	readPythonCodeTemplate = `
 import os
 import sys
+import base64
 
-file_path = '{file_path}'
+file_path = base64.b64decode('{file_path_b64}').decode('utf-8')
 offset = {offset}
 limit = {limit}
 
@@ -50,19 +51,119 @@ for i, line in enumerate(selected_lines):
     # Remove trailing newline for formatting, then add it back
     line_content = line.rstrip('\n')
     print(f'{{line_num:6d}}\t{{line_content}}')
+`
+	readWithInfoPythonCodeTemplate = `
+import os
+import sys
+import json
+import base64
+
+file_path = base64.b64decode('{file_path_b64}').decode('utf-8')
+offset = {offset}
+limit = {limit}
+max_bytes = {max_bytes}
+
+# Check if file exists
+if not os.path.isfile(file_path):
+    print('Error: File not found')
+    sys.exit(-1)
+
+with open(file_path, 'r') as f:
+    lines = f.readlines()
+
+total_lines = len(lines)
+selected_lines = lines[offset:offset + limit]
+
+# Format with line numbers, stopping at a line boundary once max_bytes would be exceeded
+content = []
+size = 0
+capped = False
+for i, line in enumerate(selected_lines):
+    line_num = offset + i + 1
+    line_content = line.rstrip('\n')
+    entry = f'{{line_num:6d}}\t{{line_content}}\n'
+    entry_size = len(entry.encode('utf-8'))
+    if max_bytes > 0 and size + entry_size > max_bytes:
+        capped = True
+        break
+    content.append(entry)
+    size += entry_size
+
+print(json.dumps({{
+    'content': ''.join(content),
+    'total_lines': total_lines,
+    'truncated': capped or offset + len(content) < total_lines
+}}))
+`
+	hashPythonCodeTemplate = `
+import base64
+import hashlib
+import os
+import sys
+
+file_path = base64.b64decode('{file_path_b64}').decode('utf-8')
+
+if not os.path.isfile(file_path):
+    print('Error: File not found')
+    sys.exit(-1)
+
+h = hashlib.sha256()
+with open(file_path, 'rb') as f:
+    for chunk in iter(lambda: f.read(65536), b''):
+        h.update(chunk)
+
+print(h.hexdigest())
+`
+	statPythonCodeTemplate = `
+import base64
+import json
+import os
+import stat
+import sys
+
+path = base64.b64decode('{path_b64}').decode('utf-8')
+
+try:
+    st = os.stat(path)
+except FileNotFoundError:
+    print(json.dumps({{'not_found': True}}))
+    sys.exit(0)
+except OSError as e:
+    print(f"Error: cannot stat '{{path}}': {{e}}", file=sys.stderr)
+    sys.exit(-1)
+
+print(json.dumps({{
+    'path': path,
+    'size': st.st_size,
+    'mtime': st.st_mtime,
+    'is_dir': stat.S_ISDIR(st.st_mode),
+    'mode': stat.S_IMODE(st.st_mode)
+}}))
 `
 	lsInfoPythonCodeTemplate = `
 import os
 import json
+import base64
 
-path = '{path}'
+path = base64.b64decode('{path_b64}').decode('utf-8')
+max_lines = {max_lines}
 
 try:
     with os.scandir(path) as it:
-        for entry in sorted(it, key=lambda e: e.name):
+        for i, entry in enumerate(sorted(it, key=lambda e: e.name)):
+            if max_lines > 0 and i >= max_lines:
+                print(json.dumps({{'truncated': True}}))
+                break
+            try:
+                stat = entry.stat(follow_symlinks=False)
+                size, mtime = stat.st_size, stat.st_mtime
+            except OSError:
+                size, mtime = 0, 0
             result = {{
                 'path': entry.name,
-                'is_dir': entry.is_dir(follow_symlinks=False)
+                'is_dir': entry.is_dir(follow_symlinks=False),
+                'size': size,
+                'mtime': mtime
             }}
             print(json.dumps(result))
 except FileNotFoundError:
@@ -75,10 +176,10 @@ import os
 import sys
 import base64
 
-file_path = '{file_path}'
+file_path = base64.b64decode('{file_path_b64}').decode('utf-8')
 
-# Check if file already exists (atomic with write)
-if os.path.exists(file_path):
+# Check if file already exists (atomic with write), unless forced to overwrite it
+if not {force} and os.path.exists(file_path):
     print(f"Error: File '{{file_path}}' already exists", file=sys.stderr)
     sys.exit(-1)
 
@@ -86,17 +187,94 @@ if os.path.exists(file_path):
 parent_dir = os.path.dirname(file_path) or '.'
 os.makedirs(parent_dir, exist_ok=True)
 
-# Decode and write content
+# Decode and write content, truncating any existing file
 content = base64.b64decode('{content_b64}').decode('utf-8')
 with open(file_path, 'w') as f:
     f.write(content)
+`
+	removePythonCodeTemplate = `
+import os
+import sys
+import base64
+import shutil
+
+path = base64.b64decode('{path_b64}').decode('utf-8')
+recursive = {recursive}
+
+if not os.path.lexists(path):
+    print(f"Error: Path '{{path}}' does not exist", file=sys.stderr)
+    sys.exit(-1)
+
+try:
+    if os.path.isdir(path) and not os.path.islink(path):
+        if recursive:
+            shutil.rmtree(path)
+        else:
+            # Only removes empty directories
+            os.rmdir(path)
+    else:
+        os.remove(path)
+except OSError as e:
+    print(f"Error: Failed to remove '{{path}}': {{e}}", file=sys.stderr)
+    sys.exit(-1)
+`
+	movePythonCodeTemplate = `
+import os
+import sys
+import base64
+import shutil
+import tempfile
+
+src = base64.b64decode('{src_b64}').decode('utf-8')
+dst = base64.b64decode('{dst_b64}').decode('utf-8')
+overwrite = {overwrite}
+
+if not os.path.lexists(src):
+    print(f"Error: Source '{{src}}' does not exist", file=sys.stderr)
+    sys.exit(-1)
+
+aside = None
+if os.path.lexists(dst):
+    if not overwrite:
+        print(f"Error: Destination '{{dst}}' already exists", file=sys.stderr)
+        sys.exit(-1)
+    # shutil.move would move src into an existing directory instead of replacing it,
+    # so keep the old destination aside until the move succeeds
+    try:
+        aside = tempfile.mkdtemp(dir=os.path.dirname(dst), prefix='.' + os.path.basename(dst) + '.move-')
+        os.rename(dst, os.path.join(aside, os.path.basename(dst)))
+    except OSError as e:
+        if aside is not None:
+            os.rmdir(aside)
+        print(f"Error: Failed to set aside '{{dst}}': {{e}}", file=sys.stderr)
+        sys.exit(-1)
+
+try:
+    os.makedirs(os.path.dirname(dst) or '.', exist_ok=True)
+    shutil.move(src, dst)
+except OSError as e:
+    if aside is not None:
+        if os.path.lexists(dst):
+            if os.path.isdir(dst) and not os.path.islink(dst):
+                shutil.rmtree(dst, ignore_errors=True)
+            else:
+                os.remove(dst)
+        os.rename(os.path.join(aside, os.path.basename(dst)), dst)
+        os.rmdir(aside)
+    print(f"Error: Failed to move '{{src}}' to '{{dst}}': {{e}}", file=sys.stderr)
+    sys.exit(-1)
+
+if aside is not None:
+    shutil.rmtree(aside, ignore_errors=True)
 `
 	editPythonCodeTemplate = `
 import sys
 import base64
 
+file_path = base64.b64decode('{file_path_b64}').decode('utf-8')
+
 # Read file content
-with open('{file_path}', 'r') as f:
+with open(file_path, 'r') as f:
     text = f.read()
 
 # Decode base64-encoded strings
@@ -121,20 +299,100 @@ else:
     result = text.replace(old, new, 1)
 
 # Write back to file
-with open('{file_path}', 'w') as f:
+with open(file_path, 'w') as f:
     f.write(result)
 
 print(count)
+`
+	dryRunEditPythonCodeTemplate = `
+import sys
+import json
+import base64
+import difflib
+
+file_path = base64.b64decode('{file_path_b64}').decode('utf-8')
+
+# Read file content
+with open(file_path, 'r') as f:
+    text = f.read()
+
+# Decode base64-encoded strings
+old = base64.b64decode('{old_b64}').decode('utf-8')
+new = base64.b64decode('{new_b64}').decode('utf-8')
+
+# Count occurrences
+count = text.count(old)
+
+# Exit with error codes if issues found
+if count == 0:
+    print(f"Error: String not found in file: '{{old}}'")
+    sys.exit(-1)  # String not found
+elif count > 1 and not {replace_all}:
+    print(f"Error: String '{{old}}' appears multiple times. Use replace_all=True to replace all occurrences.")
+    sys.exit(-1)  # Multiple occurrences without replace_all
+
+# Compute the replacement without writing it back
+if {replace_all}:
+    result = text.replace(old, new)
+else:
+    result = text.replace(old, new, 1)
+
+diff = difflib.unified_diff(text.splitlines(), result.splitlines(), fromfile=file_path, tofile=file_path, lineterm='')
+print(json.dumps({{
+    'new_content': result,
+    'diff': ''.join(line + '\n' for line in diff)
+}}))
+`
+	multiEditPythonCodeTemplate = `
+import sys
+import json
+import base64
+
+file_path = base64.b64decode('{file_path_b64}').decode('utf-8')
+
+# Read file content
+with open(file_path, 'r') as f:
+    text = f.read()
+
+# Decode base64-encoded edit list
+edits = json.loads(base64.b64decode('{edits_b64}').decode('utf-8'))
+
+# Apply every edit in memory first, so that a failing edit leaves the file untouched
+for i, edit in enumerate(edits):
+    old = edit['old_string']
+    new = edit['new_string']
+    replace_all = edit['replace_all']
+
+    count = text.count(old)
+    if count == 0:
+        print(f"Error: edit {{i}}: String not found in file: '{{old}}'")
+        sys.exit(-1)  # String not found
+    elif count > 1 and not replace_all:
+        print(f"Error: edit {{i}}: String '{{old}}' appears multiple times. Use replace_all=True to replace all occurrences.")
+        sys.exit(-1)  # Multiple occurrences without replace_all
+
+    if replace_all:
+        text = text.replace(old, new)
+    else:
+        text = text.replace(old, new, 1)
+
+# Write back to file
+with open(file_path, 'w') as f:
+    f.write(text)
+
+print(len(edits))
 `
 	grepPythonCodeTemplate = `
 import os
 import sys
 import json
+import base64
 import subprocess
 
-pattern = '{pattern}'
-path = '{path}'
-glob_pattern = '{glob_pattern}'
+pattern = base64.b64decode('{pattern_b64}').decode('utf-8')
+path = base64.b64decode('{path_b64}').decode('utf-8')
+glob_pattern = base64.b64decode('{glob_b64}').decode('utf-8')
+max_lines = {max_lines}
 
 search_path = path or '.'
 
@@ -158,6 +416,7 @@ try:
     if not output:
         sys.exit(0)
 
+    count = 0
     for line in output.splitlines():
         # Format is: path:line_number:content
         parts = line.split(':', 2)
@@ -169,13 +428,86 @@ try:
                     'Line': line_num,
                     'Content': parts[2]
                 }}
-                print(json.dumps(match))
             except (ValueError, IndexError):
                 # Ignore malformed lines, e.g., "grep: ...: Is a directory"
                 continue
+            if max_lines > 0 and count >= max_lines:
+                print(json.dumps({{'truncated': True}}))
+                break
+            print(json.dumps(match))
+            count += 1
 except Exception as e:
     print(f"Error executing grep script: {{e}}", file=sys.stderr)
     sys.exit(1)
+`
+	grepPagedPythonCodeTemplate = `
+import sys
+import json
+import base64
+import subprocess
+import tempfile
+
+# Decode base64-encoded parameters
+pattern = base64.b64decode('{pattern_b64}').decode('utf-8')
+path = base64.b64decode('{path_b64}').decode('utf-8')
+glob_pattern = base64.b64decode('{glob_b64}').decode('utf-8')
+offset = {offset}
+max_matches = {max_matches}
+
+search_path = path or '.'
+
+# Build grep command: recursive, with filename, with line number, fixed-strings (literal)
+grep_cmd = ['grep', '-rHnF']
+
+if glob_pattern:
+    grep_cmd.extend(['--include', glob_pattern])
+
+grep_cmd.extend(['-e', pattern, search_path])
+
+matches = []
+has_more = False
+found = 0
+
+try:
+    with tempfile.TemporaryFile() as stderr_file:
+        proc = subprocess.Popen(grep_cmd, stdout=subprocess.PIPE, stderr=stderr_file, text=True)
+
+        # Stream matches and stop grep as soon as one match beyond the page is seen
+        for line in proc.stdout:
+            # Format is: path:line_number:content
+            parts = line.rstrip('\n').split(':', 2)
+            if len(parts) < 3:
+                continue
+            try:
+                line_num = int(parts[1])
+            except ValueError:
+                # Ignore malformed lines, e.g., "grep: ...: Is a directory"
+                continue
+
+            if max_matches > 0 and found >= offset + max_matches:
+                has_more = True
+                proc.kill()
+                break
+            if found >= offset:
+                matches.append({{
+                    'Path': parts[0],
+                    'Line': line_num,
+                    'Content': parts[2]
+                }})
+            found += 1
+
+        proc.wait()
+
+        # grep exits with 1 if no lines were selected. We can ignore this case.
+        if not has_more and proc.returncode > 1:
+            stderr_file.seek(0)
+            print(f"Grep error: {{stderr_file.read().decode('utf-8', 'replace')}}", file=sys.stderr)
+            sys.exit(proc.returncode)
+except Exception as e:
+    print(f"Error executing grep script: {{e}}", file=sys.stderr)
+    sys.exit(1)
+
+print(json.dumps({{'matches': matches, 'has_more': has_more}}))
 `
 	globPythonCodeTemplate = `
 import glob
@@ -187,9 +519,14 @@ import base64
 path = base64.b64decode('{path_b64}').decode('utf-8')
 pattern = base64.b64decode('{pattern_b64}').decode('utf-8')
 
+max_lines = {max_lines}
+
 os.chdir(path)
 matches = sorted(glob.glob(pattern, recursive=True))
-for m in matches:
+for i, m in enumerate(matches):
+    if max_lines > 0 and i >= max_lines:
+        print(json.dumps({{'truncated': True}}))
+        break
     stat = os.stat(m)
     result = {{
         'path': m,
@@ -222,5 +559,84 @@ try:
 except Exception as e:
     print(f"Error executing command script: {{e}}", file=sys.stderr)
     sys.exit(1)
+`
+	executeStartPythonCodeTemplate = `
+import os
+import sys
+import json
+import base64
+import tempfile
+import subprocess
+
+# Decode base64-encoded command
+command = base64.b64decode('{command_b64}').decode('utf-8')
+
+job_dir = tempfile.mkdtemp(prefix='eino-exec-')
+out_path = os.path.join(job_dir, 'stdout')
+err_path = os.path.join(job_dir, 'stderr')
+code_path = os.path.join(job_dir, 'exit_code')
+
+# Run the command detached, the exit code file is renamed into place once the command is done
+wrapper = 'sh -c "$0" > "$1" 2> "$2"; echo $? > "$3.tmp" && mv "$3.tmp" "$3"'
+proc = subprocess.Popen(['/bin/sh', '-c', wrapper, command, out_path, err_path, code_path],
+                        stdin=subprocess.DEVNULL, stdout=subprocess.DEVNULL, stderr=subprocess.DEVNULL,
+                        start_new_session=True)
+
+print(json.dumps({{'job_dir': job_dir, 'pid': proc.pid}}))
+`
+	executePollPythonCodeTemplate = `
+import os
+import json
+import base64
+import shutil
+
+job_dir = base64.b64decode('{job_dir_b64}').decode('utf-8')
+offset = {offset}
+
+out_path = os.path.join(job_dir, 'stdout')
+err_path = os.path.join(job_dir, 'stderr')
+code_path = os.path.join(job_dir, 'exit_code')
+
+# Check completion before reading, so that no output written before exiting is missed
+done = os.path.exists(code_path)
+
+data = b''
+if os.path.exists(out_path):
+    with open(out_path, 'rb') as f:
+        f.seek(offset)
+        data = f.read()
+
+# Only return complete lines while the command is running
+if not done:
+    end = data.rfind(b'\n') + 1
+    data = data[:end]
+
+result = {{
+    'output': data.decode('utf-8', 'replace'),
+    'offset': offset + len(data),
+    'done': done
+}}
+if done:
+    with open(code_path) as f:
+        result['exit_code'] = int(f.read().strip() or '0')
+    with open(err_path, 'rb') as f:
+        result['stderr'] = f.read().decode('utf-8', 'replace')
+    shutil.rmtree(job_dir, ignore_errors=True)
+
+print(json.dumps(result))
+`
+	executeKillPythonCodeTemplate = `
+import os
+import base64
+import shutil
+import signal
+
+job_dir = base64.b64decode('{job_dir_b64}').decode('utf-8')
+
+try:
+    os.killpg({pid}, signal.SIGKILL)
+except (ProcessLookupError, PermissionError):
+    pass
+shutil.rmtree(job_dir, ignore_errors=True)
 `
 )
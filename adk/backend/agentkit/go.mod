@@ -2,9 +2,12 @@ module github.com/cloudwego/eino-ext/adk/backend/agentkit
 
 go 1.18
 
+replace github.com/cloudwego/eino-ext/adk/backend/internal => ../internal
+
 require (
 	github.com/bytedance/sonic v1.14.2
 	github.com/cloudwego/eino v0.7.27
+	github.com/cloudwego/eino-ext/adk/backend/internal v0.0.0-00010101000000-000000000000
 	github.com/slongfield/pyfmt v0.0.0-20220222012616-ea85ff4c361f
 	github.com/stretchr/testify v1.11.1
 )
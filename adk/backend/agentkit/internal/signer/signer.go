@@ -0,0 +1,159 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package signer implements the HMAC-SHA256 request signing (a SigV4-style scheme) used by Volcengine OpenAPI.
+package signer
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	algorithm  = "HMAC-SHA256"
+	dateFormat = "20060102T150405Z"
+)
+
+// signedHeaders are the headers covered by the signature, in canonical order.
+var signedHeaders = []string{"host", "x-date", "x-content-sha256", "content-type"}
+
+// Sign signs req in place for the given credentials, region and service, setting the
+// X-Date, X-Content-Sha256, Content-Type and Authorization headers.
+// The request body is read to compute its hash and restored afterwards.
+func Sign(req *http.Request, accessKey, secretKey, region, service string) error {
+	return sign(req, accessKey, secretKey, region, service, time.Now())
+}
+
+func sign(req *http.Request, accessKey, secretKey, region, service string, now time.Time) error {
+	body, err := readBody(req)
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	date := now.UTC().Format(dateFormat)
+	authDate := date[:8]
+	req.Header.Set("X-Date", date)
+
+	payload := hex.EncodeToString(hashSHA256(body))
+	req.Header.Set("X-Content-Sha256", payload)
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	hashedCanonicalRequest := hex.EncodeToString(hashSHA256([]byte(canonicalRequest(req, payload))))
+
+	scope := credentialScope(authDate, region, service)
+	stringToSign := strings.Join([]string{
+		algorithm,
+		date,
+		scope,
+		hashedCanonicalRequest,
+	}, "\n")
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey(secretKey, authDate, region, service), stringToSign))
+
+	req.Header.Set("Authorization", algorithm+
+		" Credential="+accessKey+"/"+scope+
+		", SignedHeaders="+strings.Join(signedHeaders, ";")+
+		", Signature="+signature)
+
+	return nil
+}
+
+// canonicalRequest builds the canonical request string from the method, path, query, signed headers and payload hash.
+func canonicalRequest(req *http.Request, payload string) string {
+	path := req.URL.Path
+	if path == "" {
+		path = "/"
+	}
+
+	queryString := strings.Replace(req.URL.Query().Encode(), "+", "%20", -1)
+
+	headerList := make([]string, 0, len(signedHeaders))
+	for _, header := range signedHeaders {
+		if header == "host" {
+			host := req.Host
+			if host == "" {
+				host = req.URL.Host
+			}
+			headerList = append(headerList, header+":"+host)
+		} else {
+			headerList = append(headerList, header+":"+strings.TrimSpace(req.Header.Get(header)))
+		}
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		path,
+		queryString,
+		strings.Join(headerList, "\n") + "\n",
+		strings.Join(signedHeaders, ";"),
+		payload,
+	}, "\n")
+}
+
+func credentialScope(authDate, region, service string) string {
+	return authDate + "/" + region + "/" + service + "/request"
+}
+
+func signingKey(secretKey, date, region, service string) []byte {
+	kDate := hmacSHA256([]byte(secretKey), date)
+	kRegion := hmacSHA256(kDate, region)
+	kService := hmacSHA256(kRegion, service)
+	return hmacSHA256(kService, "request")
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func hmacSHA256(key []byte, content string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(content))
+	return mac.Sum(nil)
+}
+
+func hashSHA256(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
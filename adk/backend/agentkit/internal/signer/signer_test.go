@@ -0,0 +1,116 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name              string
+		method            string
+		rawURL            string
+		body              []byte
+		accessKey         string
+		secretKey         string
+		region            string
+		service           string
+		now               time.Time
+		expectedCanonical string
+		expectedSignature string
+	}{
+		{
+			name:      "post with body",
+			method:    http.MethodPost,
+			rawURL:    "https://agentkit.cn-beijing.volces.com/?" + url.Values{"Action": {"InvokeTool"}, "Version": {"2025-10-30"}}.Encode(),
+			body:      []byte(`{"ToolId":"t-1"}`),
+			accessKey: "AK",
+			secretKey: "SK",
+			region:    "cn-beijing",
+			service:   "agentkit",
+			now:       time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+			expectedCanonical: "POST\n/\nAction=InvokeTool&Version=2025-10-30\n" +
+				"host:agentkit.cn-beijing.volces.com\nx-date:20250102T030405Z\n" +
+				"x-content-sha256:bbc2110094e209c0af232206a955843079124fa2c005d6af1b9b07a363d936b9\n" +
+				"content-type:application/json\n\nhost;x-date;x-content-sha256;content-type\n" +
+				"bbc2110094e209c0af232206a955843079124fa2c005d6af1b9b07a363d936b9",
+			expectedSignature: "b11efa1d35d93b088976ad9df9f41cdc9ceae413c9342ead69e03be856609052",
+		},
+		{
+			name:      "get without body and space in query",
+			method:    http.MethodGet,
+			rawURL:    "https://open.volcengineapi.com?Name=a+b&Action=ListItems",
+			accessKey: "AK2",
+			secretKey: "SK2",
+			region:    "cn-shanghai",
+			service:   "ark",
+			now:       time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC),
+			expectedCanonical: "GET\n/\nAction=ListItems&Name=a%20b\n" +
+				"host:open.volcengineapi.com\nx-date:20240229T235959Z\n" +
+				"x-content-sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855\n" +
+				"content-type:application/json\n\nhost;x-date;x-content-sha256;content-type\n" +
+				"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			expectedSignature: "a0f4184f6fba6e4a712c07282e6772718980be4d6e0a415224c7280638ce62bd",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader
+			if tt.body != nil {
+				body = bytes.NewReader(tt.body)
+			}
+			req, err := http.NewRequest(tt.method, tt.rawURL, body)
+			assert.NoError(t, err)
+
+			assert.NoError(t, sign(req, tt.accessKey, tt.secretKey, tt.region, tt.service, tt.now))
+
+			assert.Equal(t, tt.expectedCanonical, canonicalRequest(req, req.Header.Get("X-Content-Sha256")))
+			assert.Equal(t, "HMAC-SHA256 Credential="+tt.accessKey+"/"+tt.now.Format("20060102")+"/"+tt.region+"/"+tt.service+"/request"+
+				", SignedHeaders=host;x-date;x-content-sha256;content-type, Signature="+tt.expectedSignature,
+				req.Header.Get("Authorization"))
+
+			// the body is still readable after signing
+			if tt.body != nil {
+				got, err := io.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.body, got)
+			}
+		})
+	}
+}
+
+func TestSignBodyWithoutGetBody(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/", nil)
+	assert.NoError(t, err)
+	req.Body = io.NopCloser(bytes.NewReader([]byte("payload")))
+
+	assert.NoError(t, Sign(req, "AK", "SK", "cn-beijing", "agentkit"))
+
+	got, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "payload", string(got))
+	assert.NotEmpty(t, req.Header.Get("Authorization"))
+}
@@ -19,23 +19,31 @@ package agentkit
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"encoding/base64"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math"
+	"math/rand"
 	"net/http"
 	"net/url"
-	"path/filepath"
+	"os"
+	"runtime/debug"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bytedance/sonic"
 	"github.com/cloudwego/eino/adk/filesystem"
+	"github.com/cloudwego/eino/schema"
 	"github.com/slongfield/pyfmt"
+
+	"github.com/cloudwego/eino-ext/adk/backend/agentkit/internal/signer"
+	"github.com/cloudwego/eino-ext/adk/backend/internal/filetree"
+	"github.com/cloudwego/eino-ext/adk/backend/internal/pathutil"
 )
 
 type Region string
@@ -44,8 +52,13 @@ const (
 	service                 = "agentkit"
 	regionOfBeijingBaseURL  = "https://agentkit.cn-beijing.volces.com"
 	regionOfShangHaiBaseURL = "https://agentkit.cn-shanghai.volces.com"
-	python3KernelName       = "python3"
+	defaultKernelName       = "python3"
 	runCodeOperationType    = "RunCode"
+	defaultWarmupCode       = "pass"
+	truncatedResultLine     = `{"truncated": true}`
+	defaultPollInterval     = 500 * time.Millisecond
+	defaultRetryBackoff     = 200 * time.Millisecond
+	executeKillTimeout      = 10 * time.Second
 )
 
 const (
@@ -53,6 +66,12 @@ const (
 	RegionOfShangHai Region = "cn-shanghai"
 )
 
+// ErrReadOnly is returned by mutating and execute methods when the backend is read-only.
+var ErrReadOnly = errors.New("backend is read-only")
+
+// ErrNotFound is wrapped by the error Stat returns when the path does not exist.
+var ErrNotFound = errors.New("file not found")
+
 // Config holds the configuration for the Ark Sandbox.
 type Config struct {
 	AccessKeyID string
@@ -78,6 +97,8 @@ type Config struct {
 	// Note: Since the SessionID becomes unavailable when the tool's lifecycle ends,
 	// it is recommended to use UserSessionID for execution requests.
 	// If neither SessionID nor UserSessionID is provided, a new UserSessionID will be created by default.
+	// A session created by the first request is reused by later ones; the backend's SessionID and
+	// UserSessionID methods return its identifiers so they can be persisted.
 	// Optional.
 	SessionID string
 
@@ -102,29 +123,106 @@ type Config struct {
 	// Unit: seconds.
 	// For more details, see: https://www.volcengine.com/docs/86681/2155980
 	ExecutionTimeout int
+
+	// ReadOnly rejects Write, Edit, MultiEdit, Remove, Move, Execute, ExecuteStreaming and RunCode with ErrReadOnly without calling the sandbox,
+	// while LsInfo, Read, ReadWithInfo, GrepRaw, GlobInfo and DryRunEdit keep working.
+	// Paths, patterns and contents reach the sandbox base64-encoded, so a crafted path cannot alter the script it is run by.
+	// Optional. Default false.
+	ReadOnly bool
+
+	// AuditFunc is invoked after every backend method call with the method name (e.g. "Read"),
+	// the request and the resulting error (nil on success).
+	// A panic in AuditFunc is recovered and never fails the operation.
+	// Optional.
+	AuditFunc func(ctx context.Context, op string, req any, err error)
+
+	// MaxReadBytes caps the content returned by ReadWithInfo. When the requested window exceeds it,
+	// the content is cut at a line boundary and ReadResult.Truncated is set.
+	// Optional. Default 0, which means no cap.
+	MaxReadBytes int
+
+	// RootDir confines every path to RootDir and its descendants; paths that escape it,
+	// e.g. through "..", are rejected before any request is sent to the sandbox.
+	// When set, empty paths default to RootDir.
+	// Optional. Default "", which allows any absolute path.
+	RootDir string
+
+	// MaxResultLines caps the number of entries LsInfo, LsInfoWithStat, GlobInfo, GlobSorted and GrepRaw
+	// return. The sandbox stops printing results past the cap. LsInfoWithStat and GlobSorted report the
+	// cut with the Truncated flag of their result; use GrepPaged to know whether more matches exist.
+	// Optional. Default 0, which means no cap.
+	MaxResultLines int
+
+	// PollInterval is how often ExecuteStreaming polls the sandbox for new output of the running command.
+	// Optional. Default 500ms.
+	PollInterval time.Duration
+
+	// MaxRetries is the number of times a read-only request (LsInfo, Read, GrepRaw, GlobInfo, Stat, Hash,
+	// DryRunEdit and the output polling of ExecuteStreaming) is retried after a network error
+	// or a 429/5xx response, with exponential backoff and jitter between attempts. Other 4xx responses
+	// are never retried. Requests that run other code, such as Write, Edit, Execute, RunCode or Warmup, are
+	// never retried either, since a lost response does not tell whether they already ran.
+	// Optional. Default 0, which means no retry.
+	MaxRetries int
+
+	// RetryBackoff is the delay before the first retry; it doubles for every further retry.
+	// Optional. Default 200ms.
+	RetryBackoff time.Duration
+
+	// KernelName is the kernel of the session that runs every request, e.g. a bash or node kernel
+	// the sandbox tool is provisioned with, so that RunCode and Warmup run code in that language.
+	// Note: the filesystem methods (LsInfo, Read, Write, GrepRaw, GlobInfo, Edit, etc.), Execute and
+	// ExecuteStreaming still send python scripts, so they only work with a python kernel.
+	// Optional. Default "python3".
+	KernelName string
+
+	// WarmupCode is the python code run by Warmup to start the session kernel,
+	// e.g. to preload heavy imports such as pandas.
+	// Optional. Default runs a no-op statement.
+	WarmupCode string
 }
 
-type sandboxToolBackend struct {
+var _ filesystem.Backend = (*SandboxToolBackend)(nil)
+
+// SandboxToolBackend is a filesystem.Backend running in a Volcengine sandbox tool instance, see NewSandboxToolBackend.
+type SandboxToolBackend struct {
 	secretAccessKey  string
 	accessKeyID      string
 	baseURL          string
 	region           Region
 	httpClient       *http.Client
 	toolID           string
-	userSessionID    string
-	sessionID        string
 	sessionTTL       int
 	executionTimeout int
+	readOnly         bool
+	auditFunc        func(ctx context.Context, op string, req any, err error)
+	maxReadBytes     int
+	rootDir          string
+	maxResultLines   int
+	warmupCode       string
+	pollInterval     time.Duration
+	kernelName       string
+	maxRetries       int
+	retryBackoff     time.Duration
+
+	warmupMu sync.Mutex
+	warmedUp bool
+
+	// sessionMu guards sessionID and userSessionID, which are filled in from the first response
+	// when the config leaves them empty.
+	sessionMu     sync.RWMutex
+	sessionID     string
+	userSessionID string
 }
 
-// NewSandboxToolBackend creates a new sandboxToolBackend instance.
-// sandboxToolBackend refers to the sandbox running instance created by the sandbox tool in Volcengine.
+// NewSandboxToolBackend creates a new SandboxToolBackend instance.
+// SandboxToolBackend refers to the sandbox running instance created by the sandbox tool in Volcengine.
 // For creating a sandbox tool environment, please refer to: https://www.volcengine.com/docs/86681/1847934?lang=zh;
 // For creating a sandbox tool running instance, please refer to: https://www.volcengine.com/docs/86681/1860266?lang=zh.
 // Note: The execution paths within the sandbox environment may be subject to permission restrictions (read, write, execute, etc.).
 // Improper path selection can result in operation failures or permission errors.
 // It is recommended to perform operations within paths where the sandbox environment has explicit permissions to mitigate permission-related risks.
-func NewSandboxToolBackend(config *Config) (filesystem.Backend, error) {
+func NewSandboxToolBackend(config *Config) (*SandboxToolBackend, error) {
 	if config.AccessKeyID == "" {
 		return nil, fmt.Errorf("AccessKeyID is required")
 	}
@@ -135,10 +233,6 @@ func NewSandboxToolBackend(config *Config) (filesystem.Backend, error) {
 		return nil, fmt.Errorf("ToolID is required")
 	}
 
-	if config.SessionID == "" && config.UserSessionID == "" {
-		return nil, fmt.Errorf("SessionID or UserSessionID is required, at least one must be provided")
-	}
-
 	httpClient := http.DefaultClient
 	if config.HTTPClient != nil {
 		httpClient = config.HTTPClient
@@ -159,7 +253,24 @@ func NewSandboxToolBackend(config *Config) (filesystem.Backend, error) {
 		return nil, fmt.Errorf("invalid region: %s", region)
 	}
 
-	return &sandboxToolBackend{
+	pollInterval := config.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	retryBackoff := config.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultRetryBackoff
+	}
+
+	kernelName := config.KernelName
+	if kernelName == "" {
+		kernelName = defaultKernelName
+	} else if strings.TrimSpace(kernelName) == "" {
+		return nil, fmt.Errorf("KernelName must not be blank")
+	}
+
+	return &SandboxToolBackend{
 		accessKeyID:      config.AccessKeyID,
 		secretAccessKey:  config.SecretAccessKey,
 		httpClient:       httpClient,
@@ -170,54 +281,133 @@ func NewSandboxToolBackend(config *Config) (filesystem.Backend, error) {
 		userSessionID:    config.UserSessionID,
 		sessionTTL:       config.SessionTTL,
 		executionTimeout: config.ExecutionTimeout,
+		readOnly:         config.ReadOnly,
+		auditFunc:        config.AuditFunc,
+		maxReadBytes:     config.MaxReadBytes,
+		rootDir:          config.RootDir,
+		maxResultLines:   config.MaxResultLines,
+		warmupCode:       config.WarmupCode,
+		pollInterval:     pollInterval,
+		kernelName:       kernelName,
+		maxRetries:       config.MaxRetries,
+		retryBackoff:     retryBackoff,
 	}, nil
 }
 
+// Warmup runs Config.WarmupCode (a no-op by default) so that the python kernel of the session is started
+// before the first real operation. Every call of the backend shares the configured session, so the kernel
+// stays warm for later calls. Warmup only contacts the sandbox until it succeeds once; later calls return nil.
+func (s *SandboxToolBackend) Warmup(ctx context.Context) (err error) {
+	defer func() { s.audit(ctx, "Warmup", nil, err) }()
+
+	s.warmupMu.Lock()
+	defer s.warmupMu.Unlock()
+
+	if s.warmedUp {
+		return nil
+	}
+
+	code := s.warmupCode
+	if code == "" {
+		code = defaultWarmupCode
+	}
+
+	output, exitCode, err := s.execute(ctx, code, false)
+	if err != nil {
+		return fmt.Errorf("failed to execute warmup script: %w", err)
+	}
+	if exitCode != nil && *exitCode != 0 {
+		return fmt.Errorf("warmup script exited with non-zero code %d: %s", *exitCode, output)
+	}
+
+	s.warmedUp = true
+	return nil
+}
+
 // LsInfo lists file information under the given path.
-func (s *sandboxToolBackend) LsInfo(ctx context.Context, req *filesystem.LsInfoRequest) ([]filesystem.FileInfo, error) {
-	path, err := formatPath(req.Path, "/", true)
+func (s *SandboxToolBackend) LsInfo(ctx context.Context, req *filesystem.LsInfoRequest) (_ []filesystem.FileInfo, err error) {
+	defer func() { s.audit(ctx, "LsInfo", req, err) }()
+
+	entries, _, err := s.ls(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
+	var files []filesystem.FileInfo
+	for _, e := range entries {
+		files = append(files, e.FileInfo)
+	}
+
+	return files, nil
+}
+
+// LsInfoWithStat lists a directory like LsInfo, together with the size, modification time and type of
+// every entry, e.g. to find the largest or newest file without a separate stat call.
+func (s *SandboxToolBackend) LsInfoWithStat(ctx context.Context, req *filesystem.LsInfoRequest) (_ *LsResult, err error) {
+	defer func() { s.audit(ctx, "LsInfoWithStat", req, err) }()
+
+	entries, truncated, err := s.ls(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LsResult{Entries: entries, Truncated: truncated}, nil
+}
+
+// ls runs the ls script in the sandbox and returns the entries in name order,
+// reporting whether the output was cut at MaxResultLines.
+func (s *SandboxToolBackend) ls(ctx context.Context, req *filesystem.LsInfoRequest) (_ []LsEntry, truncated bool, err error) {
+	path, err := s.validatePath(req.Path, "/", false)
+	if err != nil {
+		return nil, false, err
+	}
+
 	params := map[string]any{
-		"path": path,
+		"path_b64":  base64.StdEncoding.EncodeToString([]byte(path)),
+		"max_lines": s.maxResultLines,
 	}
 
 	script, err := pyfmt.Fmt(lsInfoPythonCodeTemplate, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to render ls template: %w", err)
+		return nil, false, fmt.Errorf("failed to render ls template: %w", err)
 	}
 
-	output, exitCode, err := s.execute(ctx, script)
+	output, exitCode, err := s.execute(ctx, script, true)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute ls script: %w", err)
+		return nil, false, fmt.Errorf("failed to execute ls script: %w", err)
 	}
 	if exitCode != nil && *exitCode != 0 {
-		return nil, fmt.Errorf("ls script exited with non-zero code %d: %s", *exitCode, output)
+		return nil, false, fmt.Errorf("ls script exited with non-zero code %d: %s", *exitCode, output)
 	}
 
-	var files []filesystem.FileInfo
+	var entries []LsEntry
 	if output == "" {
-		return files, nil
+		return entries, false, nil
 	}
 
-	lines := strings.Split(strings.TrimSpace(output), "\n")
+	lines, truncated := s.resultLines(output)
 	for _, line := range lines {
-		var fi filesystem.FileInfo
-		if err := json.Unmarshal([]byte(line), &fi); err != nil {
+		var el entryLine
+		if err := json.Unmarshal([]byte(line), &el); err != nil {
 			// Ignore lines that can't be unmarshalled
 			continue
 		}
-		files = append(files, fi)
+		entries = append(entries, LsEntry{
+			FileInfo: filesystem.FileInfo{Path: el.Path},
+			Size:     el.Size,
+			ModTime:  unixTime(el.Mtime),
+			IsDir:    el.IsDir,
+		})
 	}
 
-	return files, nil
+	return entries, truncated, nil
 }
 
 // Read reads file content with support for line-based offset and limit.
-func (s *sandboxToolBackend) Read(ctx context.Context, req *filesystem.ReadRequest) (string, error) {
-	path, err := formatPath(req.FilePath, "", true)
+func (s *SandboxToolBackend) Read(ctx context.Context, req *filesystem.ReadRequest) (_ string, err error) {
+	defer func() { s.audit(ctx, "Read", req, err) }()
+
+	path, err := s.validatePath(req.FilePath, "", false)
 	if err != nil {
 		return "", err
 	}
@@ -229,9 +419,9 @@ func (s *sandboxToolBackend) Read(ctx context.Context, req *filesystem.ReadReque
 	}
 
 	params := map[string]any{
-		"file_path": path,
-		"offset":    req.Offset,
-		"limit":     req.Limit,
+		"file_path_b64": base64.StdEncoding.EncodeToString([]byte(path)),
+		"offset":        req.Offset,
+		"limit":         req.Limit,
 	}
 
 	script, err := pyfmt.Fmt(readPythonCodeTemplate, params)
@@ -239,7 +429,7 @@ func (s *sandboxToolBackend) Read(ctx context.Context, req *filesystem.ReadReque
 		return "", fmt.Errorf("failed to render read template: %w", err)
 	}
 
-	output, exitCode, err := s.execute(ctx, script)
+	output, exitCode, err := s.execute(ctx, script, true)
 	if err != nil {
 		return "", fmt.Errorf("failed to execute read script: %w", err)
 	}
@@ -250,13 +440,157 @@ func (s *sandboxToolBackend) Read(ctx context.Context, req *filesystem.ReadReque
 	return output, nil
 }
 
+// ReadWithInfo reads a window of lines like Read, and additionally reports the total line count
+// of the file and whether the returned content stops before the end of the file.
+func (s *SandboxToolBackend) ReadWithInfo(ctx context.Context, req *filesystem.ReadRequest) (_ *ReadResult, err error) {
+	defer func() { s.audit(ctx, "ReadWithInfo", req, err) }()
+
+	path, err := s.validatePath(req.FilePath, "", false)
+	if err != nil {
+		return nil, err
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 200
+	}
+
+	params := map[string]any{
+		"file_path_b64": base64.StdEncoding.EncodeToString([]byte(path)),
+		"offset":        offset,
+		"limit":         limit,
+		"max_bytes":     s.maxReadBytes,
+	}
+
+	script, err := pyfmt.Fmt(readWithInfoPythonCodeTemplate, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render read template: %w", err)
+	}
+
+	output, exitCode, err := s.execute(ctx, script, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute read script: %w", err)
+	}
+	if exitCode != nil && *exitCode != 0 {
+		return nil, fmt.Errorf("read script exited with non-zero code %d: %s", *exitCode, output)
+	}
+
+	var ret ReadResult
+	if err := json.Unmarshal([]byte(output), &ret); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal read result: %w", err)
+	}
+
+	return &ret, nil
+}
+
+// Hash returns the hex-encoded SHA-256 of the file contents, computed inside the sandbox.
+func (s *SandboxToolBackend) Hash(ctx context.Context, path string) (_ string, err error) {
+	defer func() { s.audit(ctx, "Hash", path, err) }()
+
+	path, err = s.validatePath(path, "", false)
+	if err != nil {
+		return "", err
+	}
+
+	params := map[string]any{
+		"file_path_b64": base64.StdEncoding.EncodeToString([]byte(path)),
+	}
+
+	script, err := pyfmt.Fmt(hashPythonCodeTemplate, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to render hash template: %w", err)
+	}
+
+	output, exitCode, err := s.execute(ctx, script, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute hash script: %w", err)
+	}
+	if exitCode != nil && *exitCode != 0 {
+		return "", fmt.Errorf("hash script exited with non-zero code %d: %s", *exitCode, output)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// Stat returns the metadata of path in the sandbox, following symlinks. A missing path is reported
+// with an error wrapping ErrNotFound.
+func (s *SandboxToolBackend) Stat(ctx context.Context, path string) (_ FileStat, err error) {
+	defer func() { s.audit(ctx, "Stat", path, err) }()
+
+	path, err = s.validatePath(path, "", false)
+	if err != nil {
+		return FileStat{}, err
+	}
+
+	params := map[string]any{
+		"path_b64": base64.StdEncoding.EncodeToString([]byte(path)),
+	}
+
+	script, err := pyfmt.Fmt(statPythonCodeTemplate, params)
+	if err != nil {
+		return FileStat{}, fmt.Errorf("failed to render stat template: %w", err)
+	}
+
+	output, exitCode, err := s.execute(ctx, script, true)
+	if err != nil {
+		return FileStat{}, fmt.Errorf("failed to execute stat script: %w", err)
+	}
+	if exitCode != nil && *exitCode != 0 {
+		return FileStat{}, fmt.Errorf("stat script exited with non-zero code %d: %s", *exitCode, output)
+	}
+
+	var sl statLine
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &sl); err != nil {
+		return FileStat{}, fmt.Errorf("failed to unmarshal stat result: %w", err)
+	}
+	if sl.NotFound {
+		return FileStat{}, fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+
+	return FileStat{
+		FileInfo: filesystem.FileInfo{Path: sl.Path},
+		Size:     sl.Size,
+		ModTime:  unixTime(sl.Mtime),
+		IsDir:    sl.IsDir,
+		Mode:     fileMode(sl.Mode, sl.IsDir),
+	}, nil
+}
+
+// fileMode converts the python permission bits of a file to an os.FileMode.
+func fileMode(mode uint32, isDir bool) os.FileMode {
+	m := os.FileMode(mode & 0o777)
+	if mode&0o4000 != 0 {
+		m |= os.ModeSetuid
+	}
+	if mode&0o2000 != 0 {
+		m |= os.ModeSetgid
+	}
+	if mode&0o1000 != 0 {
+		m |= os.ModeSticky
+	}
+	if isDir {
+		m |= os.ModeDir
+	}
+	return m
+}
+
 // GrepRaw searches for content matching the specified pattern in files.
-func (s *sandboxToolBackend) GrepRaw(ctx context.Context, req *filesystem.GrepRequest) ([]filesystem.GrepMatch, error) {
-	path, _ := formatPath(req.Path, "", false)
+func (s *SandboxToolBackend) GrepRaw(ctx context.Context, req *filesystem.GrepRequest) (_ []filesystem.GrepMatch, err error) {
+	defer func() { s.audit(ctx, "GrepRaw", req, err) }()
+
+	path, err := s.validatePath(req.Path, "", true)
+	if err != nil {
+		return nil, err
+	}
+
 	params := map[string]any{
-		"pattern":      req.Pattern,
-		"path":         path,
-		"glob_pattern": req.Glob,
+		"pattern_b64": base64.StdEncoding.EncodeToString([]byte(req.Pattern)),
+		"path_b64":    base64.StdEncoding.EncodeToString([]byte(path)),
+		"glob_b64":    base64.StdEncoding.EncodeToString([]byte(req.Glob)),
+		"max_lines":   s.maxResultLines,
 	}
 
 	script, err := pyfmt.Fmt(grepPythonCodeTemplate, params)
@@ -264,7 +598,7 @@ func (s *sandboxToolBackend) GrepRaw(ctx context.Context, req *filesystem.GrepRe
 		return nil, fmt.Errorf("failed to render grep template: %w", err)
 	}
 
-	output, exitCode, err := s.execute(ctx, script)
+	output, exitCode, err := s.execute(ctx, script, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute grep script: %w", err)
 	}
@@ -277,7 +611,7 @@ func (s *sandboxToolBackend) GrepRaw(ctx context.Context, req *filesystem.GrepRe
 		return matches, nil
 	}
 
-	lines := strings.Split(strings.TrimSpace(output), "\n")
+	lines, _ := s.resultLines(output)
 	for _, line := range lines {
 		var match filesystem.GrepMatch
 		if err := json.Unmarshal([]byte(line), &match); err != nil {
@@ -291,54 +625,220 @@ func (s *sandboxToolBackend) GrepRaw(ctx context.Context, req *filesystem.GrepRe
 	return matches, nil
 }
 
+// GrepPaged searches like GrepRaw, but skips the first Offset matches and stops grep in the sandbox
+// once MaxMatches matches have been collected, reporting whether more matches exist.
+func (s *SandboxToolBackend) GrepPaged(ctx context.Context, req *PagedGrepRequest) (_ *GrepPage, err error) {
+	defer func() { s.audit(ctx, "GrepPaged", req, err) }()
+
+	path, err := s.validatePath(req.Path, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	maxMatches := req.MaxMatches
+	if maxMatches < 0 {
+		maxMatches = 0
+	}
+
+	params := map[string]any{
+		"pattern_b64": base64.StdEncoding.EncodeToString([]byte(req.Pattern)),
+		"path_b64":    base64.StdEncoding.EncodeToString([]byte(path)),
+		"glob_b64":    base64.StdEncoding.EncodeToString([]byte(req.Glob)),
+		"offset":      offset,
+		"max_matches": maxMatches,
+	}
+
+	script, err := pyfmt.Fmt(grepPagedPythonCodeTemplate, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render grep template: %w", err)
+	}
+
+	output, exitCode, err := s.execute(ctx, script, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute grep script: %w", err)
+	}
+	if exitCode != nil && *exitCode != 0 {
+		return nil, fmt.Errorf("grep script exited with code %d: %s", *exitCode, output)
+	}
+
+	var page GrepPage
+	if err := json.Unmarshal([]byte(output), &page); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal grep result: %w", err)
+	}
+
+	return &page, nil
+}
+
 // GlobInfo returns file information matching the glob pattern.
-func (s *sandboxToolBackend) GlobInfo(ctx context.Context, req *filesystem.GlobInfoRequest) ([]filesystem.FileInfo, error) {
-	path, _ := formatPath(req.Path, "/", false)
+func (s *SandboxToolBackend) GlobInfo(ctx context.Context, req *filesystem.GlobInfoRequest) (_ []filesystem.FileInfo, err error) {
+	defer func() { s.audit(ctx, "GlobInfo", req, err) }()
+
+	entries, _, err := s.glob(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []filesystem.FileInfo
+	for _, entry := range entries {
+		files = append(files, entry.FileInfo)
+	}
+
+	return files, nil
+}
+
+// GlobSorted matches like GlobInfo and returns the matches with their metadata,
+// ordered by req.SortBy (name, mtime or size) and req.Descending. Ties are broken by name.
+func (s *SandboxToolBackend) GlobSorted(ctx context.Context, req *SortedGlobRequest) (_ *GlobResult, err error) {
+	defer func() { s.audit(ctx, "GlobSorted", req, err) }()
+
+	entries, truncated, err := s.glob(ctx, &req.GlobInfoRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sortGlobEntries(entries, req.SortBy, req.Descending); err != nil {
+		return nil, err
+	}
+
+	return &GlobResult{Entries: entries, Truncated: truncated}, nil
+}
+
+// glob runs the glob script in the sandbox and returns the matches in name order,
+// reporting whether the output was cut at MaxResultLines.
+func (s *SandboxToolBackend) glob(ctx context.Context, req *filesystem.GlobInfoRequest) (_ []GlobEntry, truncated bool, err error) {
+	path, err := s.validatePath(req.Path, "/", true)
+	if err != nil {
+		return nil, false, err
+	}
+
 	params := map[string]any{
 		"path_b64":    base64.StdEncoding.EncodeToString([]byte(path)),
 		"pattern_b64": base64.StdEncoding.EncodeToString([]byte(req.Pattern)),
+		"max_lines":   s.maxResultLines,
 	}
 
 	script, err := pyfmt.Fmt(globPythonCodeTemplate, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to render glob template: %w", err)
+		return nil, false, fmt.Errorf("failed to render glob template: %w", err)
 	}
 
-	output, exitCode, err := s.execute(ctx, script)
+	output, exitCode, err := s.execute(ctx, script, true)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute glob script: %w", err)
+		return nil, false, fmt.Errorf("failed to execute glob script: %w", err)
 	}
 	if exitCode != nil && *exitCode != 0 {
-		return nil, fmt.Errorf("glob script exited with non-zero code %d: %s", *exitCode, output)
+		return nil, false, fmt.Errorf("glob script exited with non-zero code %d: %s", *exitCode, output)
 	}
 
-	var files []filesystem.FileInfo
+	var entries []GlobEntry
 	if output == "" {
-		return files, nil
+		return entries, false, nil
 	}
 
-	lines := strings.Split(strings.TrimSpace(output), "\n")
+	lines, truncated := s.resultLines(output)
 	for _, line := range lines {
-		var fi filesystem.FileInfo
-		if err := json.Unmarshal([]byte(line), &fi); err != nil {
+		var el entryLine
+		if err := json.Unmarshal([]byte(line), &el); err != nil {
 			continue
 		}
-		files = append(files, fi)
+		entries = append(entries, GlobEntry{
+			FileInfo: filesystem.FileInfo{Path: el.Path},
+			Size:     el.Size,
+			ModTime:  unixTime(el.Mtime),
+			IsDir:    el.IsDir,
+		})
 	}
 
-	return files, nil
+	return entries, truncated, nil
 }
 
-// Write creates file content.
-func (s *sandboxToolBackend) Write(ctx context.Context, req *filesystem.WriteRequest) error {
-	path, err := formatPath(req.FilePath, "", true)
+// unixTime converts a python timestamp in fractional seconds to a time.Time. Zero maps to the zero time.
+func unixTime(ts float64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	sec, frac := math.Modf(ts)
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
+
+// sortGlobEntries orders entries by the given key, keeping name order for ties.
+func sortGlobEntries(entries []GlobEntry, by GlobSortBy, descending bool) error {
+	var less func(a, b GlobEntry) bool
+	switch by {
+	case "", GlobSortByName:
+		less = func(a, b GlobEntry) bool { return a.Path < b.Path }
+	case GlobSortByMtime:
+		less = func(a, b GlobEntry) bool { return a.ModTime.Before(b.ModTime) }
+	case GlobSortBySize:
+		less = func(a, b GlobEntry) bool { return a.Size < b.Size }
+	default:
+		return fmt.Errorf("unsupported glob sort key: %s", by)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if descending {
+			return less(entries[j], entries[i])
+		}
+		return less(entries[i], entries[j])
+	})
+	return nil
+}
+
+// RenderTree formats files as an indented directory tree, suitable for returning to a model.
+// FileInfo carries no size, so only the structure is rendered; see RenderGlobTree for sizes.
+func RenderTree(files []filesystem.FileInfo) string {
+	entries := make([]filetree.Entry, 0, len(files))
+	for _, f := range files {
+		entries = append(entries, filetree.Entry{Path: f.Path, Size: -1})
+	}
+	return filetree.Render(entries)
+}
+
+// RenderGlobTree formats GlobSorted results as an indented directory tree with file sizes.
+func RenderGlobTree(files []GlobEntry) string {
+	entries := make([]filetree.Entry, 0, len(files))
+	for _, f := range files {
+		entries = append(entries, filetree.Entry{Path: f.Path, Size: f.Size, IsDir: f.IsDir})
+	}
+	return filetree.Render(entries)
+}
+
+// Write creates file content. It fails if the file already exists, see ForceWrite to replace it.
+func (s *SandboxToolBackend) Write(ctx context.Context, req *filesystem.WriteRequest) (err error) {
+	defer func() { s.audit(ctx, "Write", req, err) }()
+
+	return s.write(ctx, req, false)
+}
+
+// ForceWrite writes file content like Write, and when req.Force is set overwrites an existing file instead of failing.
+func (s *SandboxToolBackend) ForceWrite(ctx context.Context, req *ForceWriteRequest) (err error) {
+	defer func() { s.audit(ctx, "ForceWrite", req, err) }()
+
+	return s.write(ctx, &req.WriteRequest, req.Force)
+}
+
+func (s *SandboxToolBackend) write(ctx context.Context, req *filesystem.WriteRequest, force bool) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	path, err := s.validatePath(req.FilePath, "", false)
 	if err != nil {
 		return err
 	}
 
+	forceFlag := 0
+	if force {
+		forceFlag = 1
+	}
 	params := map[string]any{
-		"file_path":   path,
-		"content_b64": base64.StdEncoding.EncodeToString([]byte(req.Content)),
+		"file_path_b64": base64.StdEncoding.EncodeToString([]byte(path)),
+		"content_b64":   base64.StdEncoding.EncodeToString([]byte(req.Content)),
+		"force":         forceFlag,
 	}
 
 	script, err := pyfmt.Fmt(writePythonCodeTemplate, params)
@@ -346,7 +846,7 @@ func (s *sandboxToolBackend) Write(ctx context.Context, req *filesystem.WriteReq
 		return fmt.Errorf("failed to render write template: %w", err)
 	}
 
-	output, exitCode, err := s.execute(ctx, script)
+	output, exitCode, err := s.execute(ctx, script, false)
 	if err != nil {
 		return fmt.Errorf("failed to execute write script: %w", err)
 	}
@@ -357,19 +857,111 @@ func (s *sandboxToolBackend) Write(ctx context.Context, req *filesystem.WriteReq
 	return nil
 }
 
-// Edit replaces string occurrences in a file.
-func (s *sandboxToolBackend) Edit(ctx context.Context, req *filesystem.EditRequest) error {
-	path, err := formatPath(req.FilePath, "", true)
+// Remove deletes a file, or a directory when req.Recursive is set or the directory is empty.
+// It fails if the path does not exist.
+func (s *SandboxToolBackend) Remove(ctx context.Context, req *RemoveRequest) (err error) {
+	defer func() { s.audit(ctx, "Remove", req, err) }()
+
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	path, err := s.validatePath(req.Path, "", false)
 	if err != nil {
 		return err
 	}
 
-	if req.OldString == "" {
-		return fmt.Errorf("old string is required")
+	recursive := 0
+	if req.Recursive {
+		recursive = 1
+	}
+	params := map[string]any{
+		"path_b64":  base64.StdEncoding.EncodeToString([]byte(path)),
+		"recursive": recursive,
 	}
 
-	if req.OldString == req.NewString {
-		return fmt.Errorf("new string must be different from old string")
+	script, err := pyfmt.Fmt(removePythonCodeTemplate, params)
+	if err != nil {
+		return fmt.Errorf("failed to render remove template: %w", err)
+	}
+
+	output, exitCode, err := s.execute(ctx, script, false)
+	if err != nil {
+		return fmt.Errorf("failed to execute remove script: %w", err)
+	}
+	if exitCode != nil && *exitCode != 0 {
+		return fmt.Errorf("remove script exited with non-zero code %d: %s", *exitCode, output)
+	}
+
+	return nil
+}
+
+// Move moves or renames req.Src to req.Dst with shutil.move, creating the parent directories of Dst as needed.
+// It fails if Src does not exist, if either path is inside the other, or if Dst exists and req.Overwrite is not set.
+// An existing Dst is set aside and restored if the move fails.
+func (s *SandboxToolBackend) Move(ctx context.Context, req *MoveRequest) (err error) {
+	defer func() { s.audit(ctx, "Move", req, err) }()
+
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	src, err := s.validatePath(req.Src, "", false)
+	if err != nil {
+		return err
+	}
+	dst, err := s.validatePath(req.Dst, "", false)
+	if err != nil {
+		return err
+	}
+	if src == dst {
+		return fmt.Errorf("source and destination are the same path '%s'", src)
+	}
+	if pathutil.IsDescendant(src, dst) || pathutil.IsDescendant(dst, src) {
+		return fmt.Errorf("cannot move '%s' to '%s': one path is inside the other", src, dst)
+	}
+
+	overwrite := 0
+	if req.Overwrite {
+		overwrite = 1
+	}
+	params := map[string]any{
+		"src_b64":   base64.StdEncoding.EncodeToString([]byte(src)),
+		"dst_b64":   base64.StdEncoding.EncodeToString([]byte(dst)),
+		"overwrite": overwrite,
+	}
+
+	script, err := pyfmt.Fmt(movePythonCodeTemplate, params)
+	if err != nil {
+		return fmt.Errorf("failed to render move template: %w", err)
+	}
+
+	output, exitCode, err := s.execute(ctx, script, false)
+	if err != nil {
+		return fmt.Errorf("failed to execute move script: %w", err)
+	}
+	if exitCode != nil && *exitCode != 0 {
+		return fmt.Errorf("move script exited with non-zero code %d: %s", *exitCode, output)
+	}
+
+	return nil
+}
+
+// Edit replaces string occurrences in a file.
+func (s *SandboxToolBackend) Edit(ctx context.Context, req *filesystem.EditRequest) (err error) {
+	defer func() { s.audit(ctx, "Edit", req, err) }()
+
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	path, err := s.validatePath(req.FilePath, "", false)
+	if err != nil {
+		return err
+	}
+
+	if err := validateEdit(req.OldString, req.NewString); err != nil {
+		return err
 	}
 
 	replaceAll := 1
@@ -377,10 +969,10 @@ func (s *sandboxToolBackend) Edit(ctx context.Context, req *filesystem.EditReque
 		replaceAll = 0
 	}
 	params := map[string]any{
-		"file_path":   path,
-		"old_b64":     base64.StdEncoding.EncodeToString([]byte(req.OldString)),
-		"new_b64":     base64.StdEncoding.EncodeToString([]byte(req.NewString)),
-		"replace_all": replaceAll,
+		"file_path_b64": base64.StdEncoding.EncodeToString([]byte(path)),
+		"old_b64":       base64.StdEncoding.EncodeToString([]byte(req.OldString)),
+		"new_b64":       base64.StdEncoding.EncodeToString([]byte(req.NewString)),
+		"replace_all":   replaceAll,
 	}
 
 	script, err := pyfmt.Fmt(editPythonCodeTemplate, params)
@@ -388,7 +980,7 @@ func (s *sandboxToolBackend) Edit(ctx context.Context, req *filesystem.EditReque
 		return fmt.Errorf("failed to render edit template: %w", err)
 	}
 
-	output, exitCode, err := s.execute(ctx, script)
+	output, exitCode, err := s.execute(ctx, script, false)
 	if err != nil {
 		return fmt.Errorf("failed to execute edit script: %w", err)
 	}
@@ -400,26 +992,168 @@ func (s *sandboxToolBackend) Edit(ctx context.Context, req *filesystem.EditReque
 	return nil
 }
 
-// execute executes a command in the sandbox.
-func (s *sandboxToolBackend) execute(ctx context.Context, command string) (text string, exitCode *int, err error) {
+// DryRunEdit validates req exactly like Edit and returns the resulting content and a unified diff
+// computed in the sandbox, without writing the file. It is allowed in read-only mode since nothing is modified.
+func (s *SandboxToolBackend) DryRunEdit(ctx context.Context, req *filesystem.EditRequest) (_ *EditPreview, err error) {
+	defer func() { s.audit(ctx, "DryRunEdit", req, err) }()
+
+	path, err := s.validatePath(req.FilePath, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateEdit(req.OldString, req.NewString); err != nil {
+		return nil, err
+	}
+
+	replaceAll := 1
+	if !req.ReplaceAll {
+		replaceAll = 0
+	}
+	params := map[string]any{
+		"file_path_b64": base64.StdEncoding.EncodeToString([]byte(path)),
+		"old_b64":       base64.StdEncoding.EncodeToString([]byte(req.OldString)),
+		"new_b64":       base64.StdEncoding.EncodeToString([]byte(req.NewString)),
+		"replace_all":   replaceAll,
+	}
+
+	script, err := pyfmt.Fmt(dryRunEditPythonCodeTemplate, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render dry run edit template: %w", err)
+	}
+
+	output, exitCode, err := s.execute(ctx, script, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute dry run edit script: %w", err)
+	}
+
+	if exitCode != nil && *exitCode != 0 {
+		return nil, fmt.Errorf("dry run edit script exited with non-zero code %d: %s", *exitCode, output)
+	}
+
+	var preview EditPreview
+	if err := json.Unmarshal([]byte(output), &preview); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dry run edit result: %w", err)
+	}
+
+	return &preview, nil
+}
+
+// MultiEdit applies an ordered list of replacements to a single file atomically:
+// each edit sees the result of the previous ones, and the file is only written if every edit succeeds.
+func (s *SandboxToolBackend) MultiEdit(ctx context.Context, req *MultiEditRequest) (err error) {
+	defer func() { s.audit(ctx, "MultiEdit", req, err) }()
+
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	path, err := s.validatePath(req.FilePath, "", false)
+	if err != nil {
+		return err
+	}
+
+	if len(req.Edits) == 0 {
+		return fmt.Errorf("at least one edit is required")
+	}
+	for i, edit := range req.Edits {
+		if err := validateEdit(edit.OldString, edit.NewString); err != nil {
+			return fmt.Errorf("edit %d: %w", i, err)
+		}
+	}
+
+	edits, err := json.Marshal(req.Edits)
+	if err != nil {
+		return fmt.Errorf("failed to marshal edits: %w", err)
+	}
+
+	params := map[string]any{
+		"file_path_b64": base64.StdEncoding.EncodeToString([]byte(path)),
+		"edits_b64":     base64.StdEncoding.EncodeToString(edits),
+	}
+
+	script, err := pyfmt.Fmt(multiEditPythonCodeTemplate, params)
+	if err != nil {
+		return fmt.Errorf("failed to render multi edit template: %w", err)
+	}
+
+	output, exitCode, err := s.execute(ctx, script, false)
+	if err != nil {
+		return fmt.Errorf("failed to execute multi edit script: %w", err)
+	}
+
+	if exitCode != nil && *exitCode != 0 {
+		return fmt.Errorf("multi edit script exited with non-zero code %d: %s", *exitCode, output)
+	}
+
+	return nil
+}
+
+func validateEdit(oldString, newString string) error {
+	if oldString == "" {
+		return fmt.Errorf("old string is required")
+	}
+
+	if oldString == newString {
+		return fmt.Errorf("new string must be different from old string")
+	}
+
+	return nil
+}
+
+// execute executes a command in the sandbox. Only idempotent commands, which just read sandbox state,
+// are retried after transient failures.
+func (s *SandboxToolBackend) execute(ctx context.Context, command string, idempotent bool) (text string, exitCode *int, err error) {
+	ret, err := s.run(ctx, command, idempotent)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !ret.Success {
+		errorExitCode := -1
+		if len(ret.Data.Outputs) > 0 {
+			firstOutput := ret.Data.Outputs[0]
+			if firstOutput.Text != "" {
+				text = firstOutput.Text
+			} else if firstOutput.EName != "" {
+				text = fmt.Sprintf("%s: %s", firstOutput.EName, firstOutput.EValue)
+			}
+		}
+		return text, &errorExitCode, nil
+	}
+
+	exitCode = new(int) // Success, so exit code is 0
+	var sb strings.Builder
+	for _, o := range ret.Data.Outputs {
+		sb.WriteString(o.Text)
+	}
+
+	return sb.String(), exitCode, nil
+}
+
+// run executes code in the kernel of the session (Config.KernelName) and returns the decoded result.
+// The request is retried after transient failures only if idempotent is set.
+func (s *SandboxToolBackend) run(ctx context.Context, code string, idempotent bool) (*result, error) {
 	var operationPayload string
+	var err error
 	if s.executionTimeout <= 0 {
 		operationPayload, err = sonic.MarshalString(map[string]any{
-			"code":       command,
-			"kernelName": python3KernelName,
+			"code":       code,
+			"kernelName": s.kernelName,
 		})
 	} else {
 		operationPayload, err = sonic.MarshalString(map[string]any{
-			"code":       command,
+			"code":       code,
 			"timeout":    s.executionTimeout,
-			"kernelName": python3KernelName,
+			"kernelName": s.kernelName,
 		})
 	}
 
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to marshal operation payload: %w", err)
+		return nil, fmt.Errorf("failed to marshal operation payload: %w", err)
 	}
 
+	s.sessionMu.RLock()
 	req := &invokeToolRequest{
 		ToolID:           s.toolID,
 		SessionID:        s.sessionID,
@@ -427,6 +1161,7 @@ func (s *sandboxToolBackend) execute(ctx context.Context, command string) (text
 		OperationPayload: operationPayload,
 		OperationType:    runCodeOperationType,
 	}
+	s.sessionMu.RUnlock()
 
 	if s.sessionTTL > 0 {
 		req.Ttl = &s.sessionTTL
@@ -434,46 +1169,97 @@ func (s *sandboxToolBackend) execute(ctx context.Context, command string) (text
 
 	requestBytes, err := sonic.Marshal(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	respBody, err := s.invokeTool(ctx, http.MethodPost, requestBytes)
+	respBody, err := s.invokeTool(ctx, http.MethodPost, requestBytes, idempotent)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to invoke tool: %w", err)
+		return nil, fmt.Errorf("failed to invoke tool: %w", err)
 	}
 
 	var resp response
 	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	s.captureSession(resp.Result.SessionID, resp.Result.UserSessionID)
 
 	var ret result
 	if err := json.Unmarshal([]byte(resp.Result.Result), &ret); err != nil {
-		return "", nil, fmt.Errorf("failed to unmarshal result data: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal result data: %w", err)
 	}
 
-	if !ret.Success {
-		errorExitCode := -1
-		if len(ret.Data.Outputs) > 0 {
-			firstOutput := ret.Data.Outputs[0]
-			if firstOutput.Text != "" {
-				text = firstOutput.Text
-			} else if firstOutput.EName != "" {
-				text = fmt.Sprintf("%s: %s", firstOutput.EName, firstOutput.EValue)
-			}
-		}
-		return text, &errorExitCode, nil
+	return &ret, nil
+}
+
+// captureSession records the session identifiers returned by the API, so that later calls reuse
+// the session created for the first one. Configured identifiers are never replaced.
+func (s *SandboxToolBackend) captureSession(sessionID, userSessionID string) {
+	if sessionID == "" && userSessionID == "" {
+		return
 	}
 
-	exitCode = new(int) // Success, so exit code is 0
-	if len(ret.Data.Outputs) > 0 {
-		text = ret.Data.Outputs[0].Text
+	s.sessionMu.Lock()
+	defer s.sessionMu.Unlock()
+
+	if s.sessionID == "" {
+		s.sessionID = sessionID
+	}
+	if s.userSessionID == "" {
+		s.userSessionID = userSessionID
 	}
+}
+
+// SessionID returns the sandbox session the backend runs in: Config.SessionID, or the session id
+// returned by the API after the first call when none was configured. It is empty until then.
+// Pass it as Config.SessionID to reuse the session, e.g. after a process restart.
+func (s *SandboxToolBackend) SessionID() string {
+	s.sessionMu.RLock()
+	defer s.sessionMu.RUnlock()
 
-	return text, exitCode, nil
+	return s.sessionID
 }
 
-func (s *sandboxToolBackend) invokeTool(ctx context.Context, method string, body []byte) ([]byte, error) {
+// UserSessionID returns Config.UserSessionID, or the user session id returned by the API after
+// the first call when none was configured. It is empty until then.
+func (s *SandboxToolBackend) UserSessionID() string {
+	s.sessionMu.RLock()
+	defer s.sessionMu.RUnlock()
+
+	return s.userSessionID
+}
+
+// invokeTool sends the request. An idempotent request is retried up to maxRetries times after network
+// errors and 429/5xx responses; any other request is sent once, since a lost response does not tell
+// whether its code already ran in the sandbox.
+func (s *SandboxToolBackend) invokeTool(ctx context.Context, method string, body []byte, idempotent bool) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		respBody, retry, err := s.doInvokeTool(ctx, method, body)
+		if err == nil || !retry || !idempotent || attempt >= s.maxRetries {
+			return respBody, err
+		}
+
+		timer := time.NewTimer(s.retryDelay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay returns the backoff before retry attempt+1: retryBackoff doubled per attempt,
+// with the upper half randomized so that concurrent callers do not retry in lockstep.
+func (s *SandboxToolBackend) retryDelay(attempt int) time.Duration {
+	if attempt > 16 {
+		attempt = 16
+	}
+	d := s.retryBackoff << uint(attempt)
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// doInvokeTool sends the request once and reports whether a failure is worth retrying.
+func (s *SandboxToolBackend) doInvokeTool(ctx context.Context, method string, body []byte) ([]byte, bool, error) {
 	queries := make(url.Values)
 	queries.Set("Action", "InvokeTool")
 	queries.Set("Version", "2025-10-30")
@@ -481,14 +1267,16 @@ func (s *sandboxToolBackend) invokeTool(ctx context.Context, method string, body
 
 	request, err := http.NewRequestWithContext(ctx, method, requestAddr, bytes.NewBuffer(body))
 	if err != nil {
-		return nil, fmt.Errorf("bad request: %w", err)
+		return nil, false, fmt.Errorf("bad request: %w", err)
 	}
 
-	s.signRequest(request, queries, body)
+	if err = signer.Sign(request, s.accessKeyID, s.secretAccessKey, string(s.region), service); err != nil {
+		return nil, false, fmt.Errorf("failed to sign request: %w", err)
+	}
 
 	response, err := s.httpClient.Do(request)
 	if err != nil {
-		return nil, fmt.Errorf("do request err: %w", err)
+		return nil, ctx.Err() == nil, fmt.Errorf("do request err: %w", err)
 	}
 	defer func() {
 		_ = response.Body.Close()
@@ -496,69 +1284,61 @@ func (s *sandboxToolBackend) invokeTool(ctx context.Context, method string, body
 
 	responseBody, err := io.ReadAll(response.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, ctx.Err() == nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	if response.StatusCode != 200 {
-		return nil, fmt.Errorf("request failed with status code %d", response.StatusCode)
+		retry := response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError
+		return nil, retry, fmt.Errorf("request failed with status code %d", response.StatusCode)
 	}
 
-	return responseBody, nil
+	return responseBody, false, nil
 }
 
-func (s *sandboxToolBackend) signRequest(request *http.Request, queries url.Values, body []byte) {
-	now := time.Now()
-	date := now.UTC().Format("20060102T150405Z")
-	authDate := date[:8]
-	request.Header.Set("X-Date", date)
+func (s *SandboxToolBackend) Execute(ctx context.Context, input *filesystem.ExecuteRequest) (result *filesystem.ExecuteResponse, err error) {
+	defer func() { s.audit(ctx, "Execute", input, err) }()
 
-	payload := hex.EncodeToString(hashSHA256(body))
-	request.Header.Set("X-Content-Sha256", payload)
-	request.Header.Set("Content-Type", "application/json")
+	if s.readOnly {
+		return nil, ErrReadOnly
+	}
 
-	queryString := strings.Replace(queries.Encode(), "+", "%20", -1)
-	signedHeaders := []string{"host", "x-date", "x-content-sha256", "content-type"}
-	var headerList []string
-	for _, header := range signedHeaders {
-		if header == "host" {
-			headerList = append(headerList, header+":"+request.Host)
-		} else {
-			v := request.Header.Get(header)
-			headerList = append(headerList, header+":"+strings.TrimSpace(v))
-		}
+	if input.Command == "" {
+		return nil, fmt.Errorf("command is required")
 	}
-	headerString := strings.Join(headerList, "\n")
 
-	canonicalString := strings.Join([]string{
-		request.Method,
-		"/",
-		queryString,
-		headerString + "\n",
-		strings.Join(signedHeaders, ";"),
-		payload,
-	}, "\n")
+	params := map[string]any{
+		"command_b64": base64.StdEncoding.EncodeToString([]byte(input.Command)),
+	}
 
-	hashedCanonicalString := hex.EncodeToString(hashSHA256([]byte(canonicalString)))
+	script, err := pyfmt.Fmt(executePythonCodeTemplate, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render execute template: %w", err)
+	}
 
-	credentialScope := authDate + "/" + string(s.region) + "/" + service + "/request"
-	signString := strings.Join([]string{
-		"HMAC-SHA256",
-		date,
-		credentialScope,
-		hashedCanonicalString,
-	}, "\n")
+	output, exitCode, err := s.execute(ctx, script, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute command script: %w", err)
+	}
 
-	signedKey := getSignedKey(s.secretAccessKey, authDate, string(s.region), service)
-	signature := hex.EncodeToString(hmacSHA256(signedKey, signString))
+	if exitCode != nil && *exitCode != 0 {
+		return nil, fmt.Errorf("command exited with non-zero code %d: %s", *exitCode, output)
+	}
 
-	authorization := "HMAC-SHA256" +
-		" Credential=" + s.accessKeyID + "/" + credentialScope +
-		", SignedHeaders=" + strings.Join(signedHeaders, ";") +
-		", Signature=" + signature
-	request.Header.Set("Authorization", authorization)
+	return &filesystem.ExecuteResponse{
+		Output: output,
+	}, nil
 }
 
-func (s *sandboxToolBackend) Execute(ctx context.Context, input *filesystem.ExecuteRequest) (result *filesystem.ExecuteResponse, err error) {
+// ExecuteStreaming starts the command in the background in the sandbox and polls its stdout every PollInterval,
+// emitting each new line as a separate ExecuteResponse. A non-zero exit code is reported as the final error
+// of the stream, along with stderr. Cancelling ctx or closing the reader kills the command.
+func (s *SandboxToolBackend) ExecuteStreaming(ctx context.Context, input *filesystem.ExecuteRequest) (result *schema.StreamReader[*filesystem.ExecuteResponse], err error) {
+	defer func() { s.audit(ctx, "ExecuteStreaming", input, err) }()
+
+	if s.readOnly {
+		return nil, ErrReadOnly
+	}
+
 	if input.Command == "" {
 		return nil, fmt.Errorf("command is required")
 	}
@@ -567,57 +1347,223 @@ func (s *sandboxToolBackend) Execute(ctx context.Context, input *filesystem.Exec
 		"command_b64": base64.StdEncoding.EncodeToString([]byte(input.Command)),
 	}
 
-	script, err := pyfmt.Fmt(executePythonCodeTemplate, params)
+	script, err := pyfmt.Fmt(executeStartPythonCodeTemplate, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to render execute template: %w", err)
 	}
 
-	output, exitCode, err := s.execute(ctx, script)
+	output, exitCode, err := s.execute(ctx, script, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute command script: %w", err)
 	}
-
 	if exitCode != nil && *exitCode != 0 {
-		return nil, fmt.Errorf("command exited with non-zero code %d: %s", *exitCode, output)
+		return nil, fmt.Errorf("command script exited with non-zero code %d: %s", *exitCode, output)
 	}
 
-	return &filesystem.ExecuteResponse{
-		Output: output,
-	}, nil
+	var job executeJob
+	if err := json.Unmarshal([]byte(output), &job); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal execute job: %w", err)
+	}
+
+	sr, sw := schema.Pipe[*filesystem.ExecuteResponse](100)
+	go func() {
+		defer func() {
+			if pe := recover(); pe != nil {
+				sw.Send(nil, fmt.Errorf("panic error: %v, \nstack: %s", pe, string(debug.Stack())))
+			}
+			sw.Close()
+		}()
+
+		if closed, err := s.pollExecution(ctx, &job, sw); err != nil {
+			s.killExecution(&job)
+			if !closed {
+				sw.Send(nil, err)
+			}
+		}
+	}()
+
+	return sr, nil
 }
 
-func hmacSHA256(key []byte, content string) []byte {
-	mac := hmac.New(sha256.New, key)
-	mac.Write([]byte(content))
-	return mac.Sum(nil)
+// pollExecution forwards the output of job to sw until the command is done, ctx is cancelled or the reader is closed.
+func (s *SandboxToolBackend) pollExecution(ctx context.Context, job *executeJob, sw *schema.StreamWriter[*filesystem.ExecuteResponse]) (closed bool, err error) {
+	var offset int64
+	hasOutput := false
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(s.pollInterval):
+		}
+
+		params := map[string]any{
+			"job_dir_b64": base64.StdEncoding.EncodeToString([]byte(job.JobDir)),
+			"offset":      offset,
+		}
+		script, err := pyfmt.Fmt(executePollPythonCodeTemplate, params)
+		if err != nil {
+			return false, fmt.Errorf("failed to render execute poll template: %w", err)
+		}
+
+		output, exitCode, err := s.execute(ctx, script, true)
+		if err != nil {
+			return false, fmt.Errorf("failed to execute poll script: %w", err)
+		}
+		if exitCode != nil && *exitCode != 0 {
+			return false, fmt.Errorf("poll script exited with non-zero code %d: %s", *exitCode, output)
+		}
+
+		var poll executePoll
+		if err := json.Unmarshal([]byte(output), &poll); err != nil {
+			return false, fmt.Errorf("failed to unmarshal poll result: %w", err)
+		}
+		offset = poll.Offset
+
+		if poll.Output != "" {
+			for _, line := range strings.SplitAfter(poll.Output, "\n") {
+				if line == "" {
+					continue
+				}
+				hasOutput = true
+				if closed := sw.Send(&filesystem.ExecuteResponse{Output: strings.TrimSuffix(line, "\n") + "\n"}, nil); closed {
+					return true, fmt.Errorf("stream closed by the reader")
+				}
+			}
+		}
+
+		if !poll.Done {
+			continue
+		}
+		if poll.ExitCode != 0 {
+			if poll.Stderr != "" {
+				sw.Send(nil, fmt.Errorf("command exited with non-zero code %d: %s", poll.ExitCode, poll.Stderr))
+			} else {
+				sw.Send(nil, fmt.Errorf("command exited with non-zero code %d", poll.ExitCode))
+			}
+			return false, nil
+		}
+		if !hasOutput {
+			sw.Send(&filesystem.ExecuteResponse{ExitCode: new(int)}, nil)
+		}
+		return false, nil
+	}
+}
+
+// killExecution stops a command started by ExecuteStreaming, on a fresh context since the caller's may be cancelled.
+func (s *SandboxToolBackend) killExecution(job *executeJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), executeKillTimeout)
+	defer cancel()
+
+	params := map[string]any{
+		"job_dir_b64": base64.StdEncoding.EncodeToString([]byte(job.JobDir)),
+		"pid":         job.Pid,
+	}
+	script, err := pyfmt.Fmt(executeKillPythonCodeTemplate, params)
+	if err != nil {
+		log.Printf("failed to render execute kill template: %v", err)
+		return
+	}
+	if _, _, err := s.execute(ctx, script, false); err != nil {
+		log.Printf("failed to kill command in sandbox: %v", err)
+	}
 }
 
-func getSignedKey(secretKey, date, region, service string) []byte {
-	kDate := hmacSHA256([]byte(secretKey), date)
-	kRegion := hmacSHA256(kDate, region)
-	kService := hmacSHA256(kRegion, service)
-	kSigning := hmacSHA256(kService, "request")
-	return kSigning
+// RunCode runs python code in the session kernel and returns every output it produced, including rich
+// outputs such as plots (e.g. CodeResult.Data("image/png")), HTML or dataframes. An exception raised by
+// the code is reported through CodeResult.Success and an "error" output rather than as an error.
+func (s *SandboxToolBackend) RunCode(ctx context.Context, code string) (_ *CodeResult, err error) {
+	defer func() { s.audit(ctx, "RunCode", code, err) }()
+
+	if s.readOnly {
+		return nil, ErrReadOnly
+	}
+
+	if code == "" {
+		return nil, fmt.Errorf("code is required")
+	}
+
+	ret, err := s.run(ctx, code, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute code: %w", err)
+	}
+
+	res := &CodeResult{Success: ret.Success}
+	for _, o := range ret.Data.Outputs {
+		out := CodeOutput{
+			OutputType: o.OutputType,
+			Text:       o.Text,
+			EName:      o.EName,
+			EValue:     o.EValue,
+		}
+		if len(o.Data) > 0 {
+			out.Data = make(map[string]string, len(o.Data))
+			for mimeType, v := range o.Data {
+				out.Data[mimeType] = mimeData(v)
+			}
+		}
+		res.Outputs = append(res.Outputs, out)
+	}
+
+	return res, nil
 }
 
-func hashSHA256(data []byte) []byte {
-	hash := sha256.New()
-	if _, err := hash.Write(data); err != nil {
-		log.Printf("input hash err:%s", err.Error())
+// mimeData flattens a MIME bundle value. Kernels may split text into a list of lines,
+// and JSON types such as application/json carry objects, which are re-encoded.
+func mimeData(v any) string {
+	switch d := v.(type) {
+	case string:
+		return d
+	case []any:
+		var sb strings.Builder
+		for _, line := range d {
+			if str, ok := line.(string); ok {
+				sb.WriteString(str)
+			}
+		}
+		return sb.String()
+	default:
+		b, err := json.Marshal(d)
+		if err != nil {
+			return fmt.Sprint(d)
+		}
+		return string(b)
 	}
-	return hash.Sum(nil)
 }
 
-// formatPath normalizes a file path with optional default value and absolute path validation.
-// If defaultPath is non-empty and path is empty, defaultPath will be used.
-// If requireAbs is true, returns an error if the cleaned path is not absolute.
-func formatPath(path string, defaultPath string, requireAbs bool) (string, error) {
-	if path == "" && defaultPath != "" {
-		path = defaultPath
+// resultLines splits the line-per-entry output of the ls, glob and grep scripts, stopping at the
+// truncation line the scripts print past MaxResultLines. The cap is enforced here as well,
+// so a misbehaving script still cannot produce an unbounded result.
+func (s *SandboxToolBackend) resultLines(output string) (lines []string, truncated bool) {
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == truncatedResultLine {
+			return lines, true
+		}
+		if s.maxResultLines > 0 && len(lines) >= s.maxResultLines {
+			return lines, true
+		}
+		lines = append(lines, line)
 	}
-	path = filepath.Clean(path)
-	if requireAbs && !filepath.IsAbs(path) {
-		return "", fmt.Errorf("path must be an absolute path: %s", path)
+	return lines, false
+}
+
+// audit reports an operation to the configured AuditFunc, recovering from any panic it raises.
+func (s *SandboxToolBackend) audit(ctx context.Context, op string, req any, err error) {
+	if s.auditFunc == nil {
+		return
 	}
-	return path, nil
+	defer func() {
+		if pe := recover(); pe != nil {
+			log.Printf("audit func panicked on %s: %v", op, pe)
+		}
+	}()
+	s.auditFunc(ctx, op, req, err)
+}
+
+// validatePath cleans and checks a request path, jailing it to the configured root directory if any.
+func (s *SandboxToolBackend) validatePath(path, defaultPath string, allowRelative bool) (string, error) {
+	return pathutil.ValidatePath(path, pathutil.Options{
+		DefaultPath:   defaultPath,
+		AllowRelative: allowRelative,
+		Root:          s.rootDir,
+	})
 }
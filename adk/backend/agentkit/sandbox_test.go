@@ -18,10 +18,18 @@ package agentkit
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino/adk/filesystem"
 	"github.com/stretchr/testify/assert"
@@ -40,8 +48,7 @@ func TestNewArkSandbox(t *testing.T) {
 			SessionTTL:       3600,
 			ExecutionTimeout: 60,
 		}
-		ss, err := NewSandboxToolBackend(config)
-		s := ss.(*sandboxToolBackend)
+		s, err := NewSandboxToolBackend(config)
 		require.NoError(t, err)
 		require.NotNil(t, s)
 		assert.Equal(t, "test-ak", s.accessKeyID)
@@ -61,14 +68,58 @@ func TestNewArkSandbox(t *testing.T) {
 			ToolID:          "test-tool",
 			UserSessionID:   "test-session",
 		}
-		ss, err := NewSandboxToolBackend(config)
-		s := ss.(*sandboxToolBackend)
+		s, err := NewSandboxToolBackend(config)
 		require.NoError(t, err)
 		require.NotNil(t, s)
 		assert.Equal(t, RegionOfBeijing, s.region)
 		assert.Equal(t, regionOfBeijingBaseURL, s.baseURL)
 		assert.Equal(t, 0, s.sessionTTL)
 		assert.Equal(t, 0, s.executionTimeout)
+		assert.Equal(t, "python3", s.kernelName)
+		assert.Equal(t, 0, s.maxRetries)
+		assert.Equal(t, defaultRetryBackoff, s.retryBackoff)
+	})
+
+	t.Run("Success: KernelName", func(t *testing.T) {
+		var kernelName string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			kernelName, _ = payload["kernelName"].(string)
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, "", "", ""))
+		}))
+		defer server.Close()
+
+		s, err := NewSandboxToolBackend(&Config{
+			AccessKeyID:     "test-ak",
+			SecretAccessKey: "test-sk",
+			ToolID:          "test-tool",
+			UserSessionID:   "test-session",
+			HTTPClient:      server.Client(),
+			KernelName:      "bash",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "bash", s.kernelName)
+		s.baseURL = server.URL
+
+		_, err = s.RunCode(context.Background(), "echo hello")
+		require.NoError(t, err)
+		assert.Equal(t, "bash", kernelName)
+	})
+
+	t.Run("Failure: BlankKernelName", func(t *testing.T) {
+		_, err := NewSandboxToolBackend(&Config{
+			AccessKeyID:     "test-ak",
+			SecretAccessKey: "test-sk",
+			ToolID:          "test-tool",
+			UserSessionID:   "test-session",
+			KernelName:      "  ",
+		})
+		require.Error(t, err)
+		assert.Equal(t, "KernelName must not be blank", err.Error())
 	})
 
 	t.Run("Failure: MissingRequiredFields", func(t *testing.T) {
@@ -97,6 +148,17 @@ func TestNewArkSandbox(t *testing.T) {
 		}
 	})
 
+	t.Run("Success: NoSession", func(t *testing.T) {
+		s, err := NewSandboxToolBackend(&Config{
+			AccessKeyID:     "test-ak",
+			SecretAccessKey: "test-sk",
+			ToolID:          "test-tool",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, s.SessionID())
+		assert.Empty(t, s.UserSessionID())
+	})
+
 	t.Run("Failure: InvalidRegion", func(t *testing.T) {
 		config := &Config{
 			AccessKeyID:     "test-ak",
@@ -115,7 +177,7 @@ func TestNewArkSandbox(t *testing.T) {
 var mockAPIHandler http.HandlerFunc
 
 // setupTest creates a mock server and an ArkSandbox client configured to use it.
-func setupTest(t *testing.T) (*sandboxToolBackend, *httptest.Server) {
+func setupTest(t *testing.T) (*SandboxToolBackend, *httptest.Server) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if mockAPIHandler != nil {
 			mockAPIHandler(w, r)
@@ -131,8 +193,7 @@ func setupTest(t *testing.T) (*sandboxToolBackend, *httptest.Server) {
 		UserSessionID:   "test-session",
 		HTTPClient:      server.Client(),
 	}
-	ss, err := NewSandboxToolBackend(config)
-	sandbox := ss.(*sandboxToolBackend)
+	sandbox, err := NewSandboxToolBackend(config)
 	require.NoError(t, err)
 	sandbox.baseURL = server.URL // Override to point to the mock server
 
@@ -165,11 +226,8 @@ func createMockResponse(t *testing.T, success bool, outputText, eName, eValue st
 	resDataBytes, err := json.Marshal(resData)
 	require.NoError(t, err)
 
-	finalRes := response{
-		Result: struct {
-			Result string `json:"result"`
-		}{Result: string(resDataBytes)},
-	}
+	finalRes := response{}
+	finalRes.Result.Result = string(resDataBytes)
 	finalResBytes, err := json.Marshal(finalRes)
 	require.NoError(t, err)
 
@@ -320,3 +378,1262 @@ func TestArkSandbox_FileSystemMethods(t *testing.T) {
 		assert.Contains(t, err.Error(), "command exited with non-zero code -1: command failed")
 	})
 }
+
+func TestArkSandbox_ReadOnly(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+	s.readOnly = true
+
+	var calls int
+	mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		w.Write(createMockResponse(t, true, "hello world", "", ""))
+	}
+
+	t.Run("Read: Success", func(t *testing.T) {
+		res, err := s.Read(context.Background(), &filesystem.ReadRequest{FilePath: "/data/file.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", res)
+	})
+
+	t.Run("Read: Crafted Path Stays Data", func(t *testing.T) {
+		var codes []string
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			code, _ := payload["code"].(string)
+			codes = append(codes, code)
+
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, "", "", ""))
+		}
+		defer func() {
+			mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(http.StatusOK)
+				w.Write(createMockResponse(t, true, "hello world", "", ""))
+			}
+		}()
+
+		path := "/data/x'; open('/tmp/pwned', 'w'); '"
+		_, err := s.Read(context.Background(), &filesystem.ReadRequest{FilePath: path})
+		require.NoError(t, err)
+		_, err = s.LsInfo(context.Background(), &filesystem.LsInfoRequest{Path: path})
+		require.NoError(t, err)
+		_, err = s.GrepRaw(context.Background(), &filesystem.GrepRequest{Pattern: "a'b", Path: path, Glob: "*'"})
+		require.NoError(t, err)
+
+		require.Len(t, codes, 3)
+		for _, code := range codes {
+			assert.NotContains(t, code, "pwned")
+			assert.Contains(t, code, base64.StdEncoding.EncodeToString([]byte(path)))
+		}
+	})
+
+	calls = 0
+
+	t.Run("Write: Rejected", func(t *testing.T) {
+		err := s.Write(context.Background(), &filesystem.WriteRequest{FilePath: "/data/new.txt", Content: "new content"})
+		assert.ErrorIs(t, err, ErrReadOnly)
+	})
+
+	t.Run("Edit: Rejected", func(t *testing.T) {
+		err := s.Edit(context.Background(), &filesystem.EditRequest{FilePath: "/data/file.txt", OldString: "old", NewString: "new"})
+		assert.ErrorIs(t, err, ErrReadOnly)
+	})
+
+	t.Run("Execute: Rejected", func(t *testing.T) {
+		_, err := s.Execute(context.Background(), &filesystem.ExecuteRequest{Command: "echo hello"})
+		assert.ErrorIs(t, err, ErrReadOnly)
+	})
+
+	assert.Equal(t, 0, calls, "read-only rejections must not reach the sandbox")
+}
+
+func TestArkSandbox_AuditFunc(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	var ops []string
+	var errs []error
+	s.auditFunc = func(ctx context.Context, op string, req any, err error) {
+		ops = append(ops, op)
+		errs = append(errs, err)
+	}
+
+	mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write(createMockResponse(t, true, "output", "", ""))
+	}
+
+	_, err := s.Read(context.Background(), &filesystem.ReadRequest{FilePath: "/data/file.txt"})
+	require.NoError(t, err)
+	err = s.Write(context.Background(), &filesystem.WriteRequest{FilePath: "/data/new.txt", Content: "new content"})
+	require.NoError(t, err)
+	_, err = s.Execute(context.Background(), &filesystem.ExecuteRequest{Command: "echo hello"})
+	require.NoError(t, err)
+	_, err = s.Execute(context.Background(), &filesystem.ExecuteRequest{Command: ""})
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"Read", "Write", "Execute", "Execute"}, ops)
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.NoError(t, errs[2])
+	assert.Error(t, errs[3])
+
+	t.Run("panic in audit func does not fail the operation", func(t *testing.T) {
+		s.auditFunc = func(ctx context.Context, op string, req any, err error) {
+			panic("audit failure")
+		}
+		res, err := s.Read(context.Background(), &filesystem.ReadRequest{FilePath: "/data/file.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, "output", res)
+	})
+}
+
+func TestArkSandbox_ReadWithInfo(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			out := `{"content": "   501\tline 500\n", "total_lines": 1000, "truncated": true}`
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, out, "", ""))
+		}
+		res, err := s.ReadWithInfo(context.Background(), &filesystem.ReadRequest{FilePath: "/data/large.txt", Offset: 500, Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "   501\tline 500\n", res.Content)
+		assert.Equal(t, 1000, res.TotalLines)
+		assert.True(t, res.Truncated)
+	})
+
+	t.Run("Success - Quoted Path", func(t *testing.T) {
+		var code string
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			code, _ = payload["code"].(string)
+
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, `{"content": "", "total_lines": 0, "truncated": false}`, "", ""))
+		}
+		path := "/data/it's.txt"
+		_, err := s.ReadWithInfo(context.Background(), &filesystem.ReadRequest{FilePath: path})
+		require.NoError(t, err)
+		assert.Contains(t, code, base64.StdEncoding.EncodeToString([]byte(path)))
+		assert.NotContains(t, code, path)
+	})
+
+	t.Run("Failure - File Not Found", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, false, "Error: File not found", "", ""))
+		}
+		_, err := s.ReadWithInfo(context.Background(), &filesystem.ReadRequest{FilePath: "/data/missing.txt"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "File not found")
+	})
+
+	t.Run("Failure - Invalid Output", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, "not json", "", ""))
+		}
+		_, err := s.ReadWithInfo(context.Background(), &filesystem.ReadRequest{FilePath: "/data/file.txt"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to unmarshal read result")
+	})
+}
+
+func TestArkSandbox_Hash(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		files := map[string]string{"/data/a.txt": "same", "/data/b.txt": "same"}
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			code, _ := payload["code"].(string)
+
+			var out string
+			for p, content := range files {
+				if strings.Contains(code, base64.StdEncoding.EncodeToString([]byte(p))) {
+					sum := sha256.Sum256([]byte(content))
+					out = hex.EncodeToString(sum[:]) + "\n"
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, out, "", ""))
+		}
+
+		hashA, err := s.Hash(context.Background(), "/data/a.txt")
+		require.NoError(t, err)
+		hashB, err := s.Hash(context.Background(), "/data/b.txt")
+		require.NoError(t, err)
+		assert.Len(t, hashA, 64)
+		assert.Equal(t, hashA, hashB)
+
+		files["/data/b.txt"] = "edited"
+		hashB, err = s.Hash(context.Background(), "/data/b.txt")
+		require.NoError(t, err)
+		assert.NotEqual(t, hashA, hashB)
+	})
+
+	t.Run("Failure - File Not Found", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, false, "Error: File not found", "", ""))
+		}
+		_, err := s.Hash(context.Background(), "/data/missing.txt")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "File not found")
+	})
+
+	t.Run("Failure - Relative Path", func(t *testing.T) {
+		_, err := s.Hash(context.Background(), "data/a.txt")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "path must be an absolute path")
+	})
+}
+
+func TestArkSandbox_ForceWrite(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	var code string
+	mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+		var req invokeToolRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+		code, _ = payload["code"].(string)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(createMockResponse(t, true, "", "", ""))
+	}
+
+	t.Run("Write keeps failing on existing files", func(t *testing.T) {
+		err := s.Write(context.Background(), &filesystem.WriteRequest{FilePath: "/data/file.txt", Content: "content"})
+		require.NoError(t, err)
+		assert.Contains(t, code, "if not 0 and os.path.exists(file_path):")
+		assert.Contains(t, code, "file=sys.stderr")
+	})
+
+	t.Run("Force skips the existence check", func(t *testing.T) {
+		err := s.ForceWrite(context.Background(), &ForceWriteRequest{
+			WriteRequest: filesystem.WriteRequest{FilePath: "/data/file.txt", Content: "content"},
+			Force:        true,
+		})
+		require.NoError(t, err)
+		assert.Contains(t, code, "if not 1 and os.path.exists(file_path):")
+		assert.Contains(t, code, base64.StdEncoding.EncodeToString([]byte("content")))
+	})
+
+	t.Run("Without Force an existing file fails", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, false, "Error: File '/data/file.txt' already exists", "", ""))
+		}
+		err := s.ForceWrite(context.Background(), &ForceWriteRequest{
+			WriteRequest: filesystem.WriteRequest{FilePath: "/data/file.txt", Content: "content"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "write script exited with non-zero code -1: Error: File '/data/file.txt' already exists")
+	})
+
+	t.Run("Rejected when read-only", func(t *testing.T) {
+		s.readOnly = true
+		defer func() { s.readOnly = false }()
+		err := s.ForceWrite(context.Background(), &ForceWriteRequest{
+			WriteRequest: filesystem.WriteRequest{FilePath: "/data/file.txt", Content: "content"},
+			Force:        true,
+		})
+		assert.ErrorIs(t, err, ErrReadOnly)
+	})
+}
+
+func TestArkSandbox_Remove(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		var code string
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			code, _ = payload["code"].(string)
+
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, "", "", ""))
+		}
+
+		err := s.Remove(context.Background(), &RemoveRequest{Path: "/data/file.txt"})
+		require.NoError(t, err)
+		assert.Contains(t, code, base64.StdEncoding.EncodeToString([]byte("/data/file.txt")))
+		assert.Contains(t, code, "recursive = 0")
+
+		err = s.Remove(context.Background(), &RemoveRequest{Path: "/data/dir", Recursive: true})
+		require.NoError(t, err)
+		assert.Contains(t, code, "recursive = 1")
+	})
+
+	t.Run("Failure - Path Not Found", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, false, "Error: Path '/data/missing' does not exist", "", ""))
+		}
+		err := s.Remove(context.Background(), &RemoveRequest{Path: "/data/missing"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "remove script exited with non-zero code -1: Error: Path '/data/missing' does not exist")
+	})
+
+	t.Run("Failure - Relative Path", func(t *testing.T) {
+		err := s.Remove(context.Background(), &RemoveRequest{Path: "data/file.txt"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "path must be an absolute path")
+	})
+
+	t.Run("Failure - Read Only", func(t *testing.T) {
+		s.readOnly = true
+		defer func() { s.readOnly = false }()
+		err := s.Remove(context.Background(), &RemoveRequest{Path: "/data/file.txt"})
+		assert.ErrorIs(t, err, ErrReadOnly)
+	})
+}
+
+func TestArkSandbox_Move(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	var code string
+	mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+		var req invokeToolRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+		code, _ = payload["code"].(string)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(createMockResponse(t, true, "", "", ""))
+	}
+
+	t.Run("Success - Same Directory Rename", func(t *testing.T) {
+		err := s.Move(context.Background(), &MoveRequest{Src: "/data/old.txt", Dst: "/data/new.txt"})
+		require.NoError(t, err)
+		assert.Contains(t, code, base64.StdEncoding.EncodeToString([]byte("/data/old.txt")))
+		assert.Contains(t, code, base64.StdEncoding.EncodeToString([]byte("/data/new.txt")))
+		assert.Contains(t, code, "overwrite = 0")
+	})
+
+	t.Run("Success - Cross Directory Move", func(t *testing.T) {
+		err := s.Move(context.Background(), &MoveRequest{Src: "/data/a/file.txt", Dst: "/data/b/../c/file.txt", Overwrite: true})
+		require.NoError(t, err)
+		assert.Contains(t, code, base64.StdEncoding.EncodeToString([]byte("/data/c/file.txt")))
+		assert.Contains(t, code, "overwrite = 1")
+	})
+
+	t.Run("Failure - Missing Source", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, false, "Error: Source '/data/missing.txt' does not exist", "", ""))
+		}
+		err := s.Move(context.Background(), &MoveRequest{Src: "/data/missing.txt", Dst: "/data/new.txt"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "move script exited with non-zero code -1: Error: Source '/data/missing.txt' does not exist")
+	})
+
+	t.Run("Failure - Nested Paths", func(t *testing.T) {
+		code = ""
+		err := s.Move(context.Background(), &MoveRequest{Src: "/data/a/b", Dst: "/data/a", Overwrite: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "inside the other")
+		err = s.Move(context.Background(), &MoveRequest{Src: "/data/a", Dst: "/data/a/b", Overwrite: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "inside the other")
+		assert.Empty(t, code)
+	})
+
+	t.Run("Failure - Relative Path", func(t *testing.T) {
+		err := s.Move(context.Background(), &MoveRequest{Src: "/data/old.txt", Dst: "new.txt"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "path must be an absolute path")
+	})
+}
+
+func TestArkSandbox_MultiEdit(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		var code string
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			code, _ = payload["code"].(string)
+
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, "2", "", ""))
+		}
+		edits := []EditOperation{
+			{OldString: "a", NewString: "b"},
+			{OldString: "c", NewString: "d", ReplaceAll: true},
+		}
+		err := s.MultiEdit(context.Background(), &MultiEditRequest{FilePath: "/data/file.txt", Edits: edits})
+		require.NoError(t, err)
+
+		editsJSON, err := json.Marshal(edits)
+		require.NoError(t, err)
+		assert.Contains(t, code, base64.StdEncoding.EncodeToString(editsJSON))
+	})
+
+	t.Run("Success - Quoted Path", func(t *testing.T) {
+		var code string
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			code, _ = payload["code"].(string)
+
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, "1", "", ""))
+		}
+		path := "/data/it's.txt"
+		err := s.MultiEdit(context.Background(), &MultiEditRequest{FilePath: path, Edits: []EditOperation{{OldString: "a", NewString: "b"}}})
+		require.NoError(t, err)
+		assert.Contains(t, code, base64.StdEncoding.EncodeToString([]byte(path)))
+		assert.NotContains(t, code, path)
+	})
+
+	t.Run("Failure - Script Error", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, false, "Error: edit 1: String not found in file: 'c'", "", ""))
+		}
+		err := s.MultiEdit(context.Background(), &MultiEditRequest{
+			FilePath: "/data/file.txt",
+			Edits: []EditOperation{
+				{OldString: "a", NewString: "b"},
+				{OldString: "c", NewString: "d"},
+			},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "multi edit script exited with non-zero code -1: Error: edit 1")
+	})
+
+	t.Run("Failure - Validation", func(t *testing.T) {
+		err := s.MultiEdit(context.Background(), &MultiEditRequest{
+			FilePath: "/data/file.txt",
+			Edits:    []EditOperation{{OldString: "", NewString: "b"}},
+		})
+		require.Error(t, err)
+		assert.Equal(t, "edit 0: old string is required", err.Error())
+	})
+}
+
+func TestArkSandbox_DryRunEdit(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			out := `{"new_content": "hello go", "diff": "--- /data/file.txt\n+++ /data/file.txt\n@@ -1 +1 @@\n-hello world\n+hello go\n"}`
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, out, "", ""))
+		}
+		preview, err := s.DryRunEdit(context.Background(), &filesystem.EditRequest{FilePath: "/data/file.txt", OldString: "world", NewString: "go"})
+		require.NoError(t, err)
+		assert.Equal(t, "hello go", preview.NewContent)
+		assert.Equal(t, "--- /data/file.txt\n+++ /data/file.txt\n@@ -1 +1 @@\n-hello world\n+hello go\n", preview.Diff)
+	})
+
+	t.Run("Success - Read Only", func(t *testing.T) {
+		s.readOnly = true
+		defer func() { s.readOnly = false }()
+
+		_, err := s.DryRunEdit(context.Background(), &filesystem.EditRequest{FilePath: "/data/file.txt", OldString: "world", NewString: "go"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Success - Quoted Path", func(t *testing.T) {
+		var code string
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			code, _ = payload["code"].(string)
+
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, `{"new_content": "", "diff": ""}`, "", ""))
+		}
+		path := "/data/it's.txt"
+		_, err := s.DryRunEdit(context.Background(), &filesystem.EditRequest{FilePath: path, OldString: "world", NewString: "go"})
+		require.NoError(t, err)
+		assert.Contains(t, code, base64.StdEncoding.EncodeToString([]byte(path)))
+		assert.NotContains(t, code, path)
+	})
+
+	t.Run("Failure - Script Error", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, false, "Error: String not found in file: 'world'", "", ""))
+		}
+		_, err := s.DryRunEdit(context.Background(), &filesystem.EditRequest{FilePath: "/data/file.txt", OldString: "world", NewString: "go"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dry run edit script exited with non-zero code -1")
+	})
+}
+
+func TestArkSandbox_RootDir(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+	s.rootDir = "/data"
+
+	var calls int
+	mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		w.Write(createMockResponse(t, true, "hello world", "", ""))
+	}
+
+	_, err := s.Read(context.Background(), &filesystem.ReadRequest{FilePath: "/data/sub/../file.txt"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	_, err = s.Read(context.Background(), &filesystem.ReadRequest{FilePath: "/data/../etc/passwd"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "path escapes root directory /data: /etc/passwd")
+
+	err = s.Write(context.Background(), &filesystem.WriteRequest{FilePath: "/tmp/new.txt", Content: "x"})
+	require.Error(t, err)
+
+	_, err = s.GrepRaw(context.Background(), &filesystem.GrepRequest{Path: "/", Pattern: "x"})
+	require.Error(t, err)
+
+	assert.Equal(t, 1, calls, "paths outside the root must not reach the sandbox")
+}
+
+func TestArkSandbox_GrepPaged(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		var code string
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			code, _ = payload["code"].(string)
+
+			out := `{"matches": [{"Path": "/data/a.txt", "Line": 3, "Content": "go"}], "has_more": true}`
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, out, "", ""))
+		}
+		page, err := s.GrepPaged(context.Background(), &PagedGrepRequest{
+			GrepRequest: filesystem.GrepRequest{Path: "/data", Pattern: "go"},
+			Offset:      1,
+			MaxMatches:  1,
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Matches, 1)
+		assert.Equal(t, "/data/a.txt", page.Matches[0].Path)
+		assert.Equal(t, 3, page.Matches[0].Line)
+		assert.True(t, page.HasMore)
+		assert.Contains(t, code, "offset = 1")
+		assert.Contains(t, code, "max_matches = 1")
+	})
+
+	t.Run("Failure - Script Error", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, false, "Grep error", "", ""))
+		}
+		_, err := s.GrepPaged(context.Background(), &PagedGrepRequest{GrepRequest: filesystem.GrepRequest{Pattern: "go"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "grep script exited with code -1")
+	})
+}
+
+func TestArkSandbox_Stat(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	var script string
+	respond := func(out string) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			script, _ = payload["code"].(string)
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, out, "", ""))
+		}
+	}
+
+	t.Run("Success - File", func(t *testing.T) {
+		respond(`{"path": "/data/a.txt", "size": 5, "mtime": 1700000000.5, "is_dir": false, "mode": 420}`)
+
+		st, err := s.Stat(context.Background(), "/data/a.txt")
+		require.NoError(t, err)
+		assert.Contains(t, script, base64.StdEncoding.EncodeToString([]byte("/data/a.txt")))
+		assert.Equal(t, FileStat{
+			FileInfo: filesystem.FileInfo{Path: "/data/a.txt"},
+			Size:     5,
+			ModTime:  time.Unix(1700000000, 500000000),
+			Mode:     0o644,
+		}, st)
+	})
+
+	t.Run("Success - Directory", func(t *testing.T) {
+		respond(`{"path": "/data", "size": 4096, "mtime": 1700003600, "is_dir": true, "mode": 1023}`)
+
+		st, err := s.Stat(context.Background(), "/data")
+		require.NoError(t, err)
+		assert.True(t, st.IsDir)
+		assert.Equal(t, os.ModeDir|os.ModeSticky|0o777, st.Mode)
+	})
+
+	t.Run("Failure - Not Found", func(t *testing.T) {
+		respond(`{"not_found": true}`)
+
+		_, err := s.Stat(context.Background(), "/data/missing.txt")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.EqualError(t, err, "file not found: /data/missing.txt")
+	})
+
+	t.Run("Failure - Script Error", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, false, "", "PermissionError", "cannot stat"))
+		}
+
+		_, err := s.Stat(context.Background(), "/root/secret")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "stat script exited with non-zero code")
+	})
+}
+
+func TestArkSandbox_LsInfoWithStat(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	var code string
+	mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+		var req invokeToolRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+		code, _ = payload["code"].(string)
+
+		// gone.txt could not be stat'ed, so the sandbox reports zero values for it.
+		out := `{"path": "a.txt", "is_dir": false, "size": 5, "mtime": 1700000000.5}
+{"path": "gone.txt", "is_dir": false, "size": 0, "mtime": 0}
+{"path": "sub", "is_dir": true, "size": 4096, "mtime": 1700003600}`
+		w.WriteHeader(http.StatusOK)
+		w.Write(createMockResponse(t, true, out, "", ""))
+	}
+
+	res, err := s.LsInfoWithStat(context.Background(), &filesystem.LsInfoRequest{Path: "/data"})
+	require.NoError(t, err)
+	assert.Contains(t, code, "entry.stat(follow_symlinks=False)")
+	assert.False(t, res.Truncated)
+	assert.Equal(t, []LsEntry{
+		{FileInfo: filesystem.FileInfo{Path: "a.txt"}, Size: 5, ModTime: time.Unix(1700000000, 500000000)},
+		{FileInfo: filesystem.FileInfo{Path: "gone.txt"}},
+		{FileInfo: filesystem.FileInfo{Path: "sub"}, Size: 4096, ModTime: time.Unix(1700003600, 0), IsDir: true},
+	}, res.Entries)
+
+	// LsInfo reads the same output and keeps returning paths only.
+	files, err := s.LsInfo(context.Background(), &filesystem.LsInfoRequest{Path: "/data"})
+	require.NoError(t, err)
+	assert.Equal(t, []filesystem.FileInfo{{Path: "a.txt"}, {Path: "gone.txt"}, {Path: "sub"}}, files)
+}
+
+func TestArkSandbox_GlobSorted(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+		out := `{"path": "a.txt", "size": 5, "mtime": 1700000000.5, "is_dir": false}
+{"path": "b.txt", "size": 1, "mtime": 1700007200.0, "is_dir": false}
+{"path": "c.txt", "size": 3, "mtime": 1700003600.25, "is_dir": false}`
+		w.WriteHeader(http.StatusOK)
+		w.Write(createMockResponse(t, true, out, "", ""))
+	}
+
+	paths := func(res *GlobResult) []string {
+		var result []string
+		for _, e := range res.Entries {
+			result = append(result, e.Path)
+		}
+		return result
+	}
+
+	t.Run("Success - Mtime Descending", func(t *testing.T) {
+		res, err := s.GlobSorted(context.Background(), &SortedGlobRequest{
+			GlobInfoRequest: filesystem.GlobInfoRequest{Path: "/data", Pattern: "*.txt"},
+			SortBy:          GlobSortByMtime,
+			Descending:      true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b.txt", "c.txt", "a.txt"}, paths(res))
+		assert.Equal(t, time.Unix(1700003600, 250000000), res.Entries[1].ModTime)
+		assert.False(t, res.Truncated)
+	})
+
+	t.Run("Success - Size", func(t *testing.T) {
+		res, err := s.GlobSorted(context.Background(), &SortedGlobRequest{
+			GlobInfoRequest: filesystem.GlobInfoRequest{Path: "/data", Pattern: "*.txt"},
+			SortBy:          GlobSortBySize,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b.txt", "c.txt", "a.txt"}, paths(res))
+	})
+
+	t.Run("Failure - Unsupported Sort Key", func(t *testing.T) {
+		_, err := s.GlobSorted(context.Background(), &SortedGlobRequest{
+			GlobInfoRequest: filesystem.GlobInfoRequest{Path: "/data", Pattern: "*.txt"},
+			SortBy:          "owner",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported glob sort key")
+	})
+}
+
+func TestRenderGlobTree(t *testing.T) {
+	entries := []GlobEntry{
+		{FileInfo: filesystem.FileInfo{Path: "src/main.go"}, Size: 2048},
+		{FileInfo: filesystem.FileInfo{Path: "src"}, IsDir: true},
+		{FileInfo: filesystem.FileInfo{Path: "go.mod"}, Size: 42},
+	}
+	assert.Equal(t, "src/\n  main.go (2.0 KB)\ngo.mod (42 B)\n", RenderGlobTree(entries))
+
+	files := []filesystem.FileInfo{{Path: "/data/a/b.txt"}, {Path: "/data/c.txt"}}
+	assert.Equal(t, "/\n  data/\n    a/\n      b.txt\n    c.txt\n", RenderTree(files))
+}
+
+func TestArkSandbox_Retry(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+	s.retryBackoff = time.Millisecond
+
+	// failN answers the first len(statuses) requests with the given statuses, where 0 drops the
+	// connection, and succeeds afterwards.
+	failN := func(t *testing.T, calls *int, statuses ...int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			*calls++
+			if *calls <= len(statuses) {
+				status := statuses[*calls-1]
+				if status == 0 {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					require.NoError(t, err)
+					conn.Close()
+					return
+				}
+				w.WriteHeader(status)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, "ok", "", ""))
+		}
+	}
+
+	t.Run("Success After Transient Failures", func(t *testing.T) {
+		s.maxRetries = 4
+		var calls int
+		mockAPIHandler = failN(t, &calls, http.StatusBadGateway, 0, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+
+		content, err := s.Read(context.Background(), &filesystem.ReadRequest{FilePath: "/data/file.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, "ok", content)
+		assert.Equal(t, 5, calls)
+	})
+
+	t.Run("Retries Exhausted", func(t *testing.T) {
+		s.maxRetries = 2
+		var calls int
+		mockAPIHandler = failN(t, &calls, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+		_, err := s.Read(context.Background(), &filesystem.ReadRequest{FilePath: "/data/file.txt"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "request failed with status code 503")
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("No Retry On Client Error", func(t *testing.T) {
+		s.maxRetries = 2
+		var calls int
+		mockAPIHandler = failN(t, &calls, http.StatusForbidden)
+
+		_, err := s.Read(context.Background(), &filesystem.ReadRequest{FilePath: "/data/file.txt"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "request failed with status code 403")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("No Retry For Non-Idempotent Calls", func(t *testing.T) {
+		s.maxRetries = 2
+		var calls int
+		mockAPIHandler = failN(t, &calls, 0)
+		err := s.Write(context.Background(), &filesystem.WriteRequest{FilePath: "/data/file.txt", Content: "ok"})
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+
+		calls = 0
+		mockAPIHandler = failN(t, &calls, http.StatusServiceUnavailable)
+		_, err = s.Execute(context.Background(), &filesystem.ExecuteRequest{Command: "echo ok"})
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+
+		calls = 0
+		mockAPIHandler = failN(t, &calls, http.StatusServiceUnavailable)
+		_, err = s.RunCode(context.Background(), "print('ok')")
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("No Retry By Default", func(t *testing.T) {
+		s.maxRetries = 0
+		var calls int
+		mockAPIHandler = failN(t, &calls, http.StatusServiceUnavailable)
+
+		_, err := s.Read(context.Background(), &filesystem.ReadRequest{FilePath: "/data/file.txt"})
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Context Cancelled Between Attempts", func(t *testing.T) {
+		s.maxRetries = 2
+		s.retryBackoff = time.Hour
+		defer func() { s.retryBackoff = time.Millisecond }()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var calls int
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			cancel()
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: "/data/file.txt"})
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			require.Error(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("retry did not stop on context cancellation")
+		}
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestArkSandbox_SessionID(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	// respond wraps a successful result in an envelope carrying the session identifiers.
+	respond := func(t *testing.T, w http.ResponseWriter, sessionID, userSessionID string) {
+		var env map[string]any
+		require.NoError(t, json.Unmarshal(createMockResponse(t, true, "ok", "", ""), &env))
+		res := env["result"].(map[string]any)
+		res["SessionId"] = sessionID
+		res["UserSessionId"] = userSessionID
+		b, err := json.Marshal(env)
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		w.Write(b)
+	}
+
+	t.Run("Captured From First Response", func(t *testing.T) {
+		s.sessionID, s.userSessionID = "", ""
+		var reqs []invokeToolRequest
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			reqs = append(reqs, req)
+			respond(t, w, "sess-123", "user-456")
+		}
+
+		assert.Empty(t, s.SessionID())
+		_, err := s.Execute(context.Background(), &filesystem.ExecuteRequest{Command: "echo ok"})
+		require.NoError(t, err)
+		assert.Equal(t, "sess-123", s.SessionID())
+		assert.Equal(t, "user-456", s.UserSessionID())
+
+		// Later calls reuse the session created for the first one.
+		_, err = s.Execute(context.Background(), &filesystem.ExecuteRequest{Command: "echo ok"})
+		require.NoError(t, err)
+		require.Len(t, reqs, 2)
+		assert.Empty(t, reqs[0].SessionID)
+		assert.Empty(t, reqs[0].UserSessionID)
+		assert.Equal(t, "sess-123", reqs[1].SessionID)
+		assert.Equal(t, "user-456", reqs[1].UserSessionID)
+	})
+
+	t.Run("Configured Session Kept", func(t *testing.T) {
+		s.sessionID, s.userSessionID = "", "test-session"
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			respond(t, w, "sess-789", "other-session")
+		}
+
+		_, err := s.Execute(context.Background(), &filesystem.ExecuteRequest{Command: "echo ok"})
+		require.NoError(t, err)
+		assert.Equal(t, "sess-789", s.SessionID())
+		assert.Equal(t, "test-session", s.UserSessionID())
+	})
+
+	t.Run("No Session In Response", func(t *testing.T) {
+		s.sessionID, s.userSessionID = "", "test-session"
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, "ok", "", ""))
+		}
+
+		_, err := s.Execute(context.Background(), &filesystem.ExecuteRequest{Command: "echo ok"})
+		require.NoError(t, err)
+		assert.Empty(t, s.SessionID())
+		assert.Equal(t, "test-session", s.UserSessionID())
+	})
+}
+
+func TestArkSandbox_Warmup(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	var calls int
+	var code string
+	mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var req invokeToolRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "test-session", req.UserSessionID)
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+		code, _ = payload["code"].(string)
+		w.WriteHeader(http.StatusOK)
+		w.Write(createMockResponse(t, true, "", "", ""))
+	}
+
+	require.NoError(t, s.Warmup(context.Background()))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "pass", code)
+
+	// The session is already warm, so no further request is sent.
+	require.NoError(t, s.Warmup(context.Background()))
+	assert.Equal(t, 1, calls)
+
+	t.Run("Custom Code", func(t *testing.T) {
+		s.warmedUp = false
+		s.warmupCode = "import pandas"
+		require.NoError(t, s.Warmup(context.Background()))
+		assert.Equal(t, "import pandas", code)
+	})
+
+	t.Run("Failure - Script Error", func(t *testing.T) {
+		s.warmedUp = false
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, false, "", "ModuleNotFoundError", "No module named 'pandas'"))
+		}
+		err := s.Warmup(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ModuleNotFoundError")
+		assert.False(t, s.warmedUp)
+	})
+}
+
+// streamingHandler answers the start, poll and kill scripts of ExecuteStreaming, returning polls in order
+// and repeating the last one once exhausted.
+func streamingHandler(t *testing.T, polls []string, killed *bool) http.HandlerFunc {
+	var n int
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req invokeToolRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+		code, _ := payload["code"].(string)
+
+		var out string
+		switch {
+		case strings.Contains(code, "start_new_session"):
+			out = `{"job_dir": "/tmp/eino-exec-1", "pid": 42}`
+		case strings.Contains(code, "killpg"):
+			*killed = true
+		default:
+			out = polls[n]
+			if n < len(polls)-1 {
+				n++
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(createMockResponse(t, true, out, "", ""))
+	}
+}
+
+func TestArkSandbox_ExecuteStreaming(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+	s.pollInterval = time.Millisecond
+
+	recvAll := func(sr interface {
+		Recv() (*filesystem.ExecuteResponse, error)
+	}) (lines []string, err error) {
+		for {
+			resp, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				return lines, nil
+			}
+			if err != nil {
+				return lines, err
+			}
+			lines = append(lines, resp.Output)
+		}
+	}
+
+	t.Run("Success - Lines Across Polls", func(t *testing.T) {
+		var killed bool
+		mockAPIHandler = streamingHandler(t, []string{
+			`{"output": "line 1\n", "offset": 7, "done": false}`,
+			`{"output": "", "offset": 7, "done": false}`,
+			`{"output": "line 2\nline 3\n", "offset": 21, "done": false}`,
+			`{"output": "tail", "offset": 25, "done": true, "exit_code": 0, "stderr": ""}`,
+		}, &killed)
+
+		sr, err := s.ExecuteStreaming(context.Background(), &filesystem.ExecuteRequest{Command: "make test"})
+		require.NoError(t, err)
+		defer sr.Close()
+
+		lines, err := recvAll(sr)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"line 1\n", "line 2\n", "line 3\n", "tail\n"}, lines)
+		assert.False(t, killed)
+	})
+
+	t.Run("Failure - Non-Zero Exit Code", func(t *testing.T) {
+		var killed bool
+		mockAPIHandler = streamingHandler(t, []string{
+			`{"output": "building\n", "offset": 9, "done": true, "exit_code": 2, "stderr": "compile error"}`,
+		}, &killed)
+
+		sr, err := s.ExecuteStreaming(context.Background(), &filesystem.ExecuteRequest{Command: "make"})
+		require.NoError(t, err)
+		defer sr.Close()
+
+		lines, err := recvAll(sr)
+		assert.Equal(t, []string{"building\n"}, lines)
+		require.Error(t, err)
+		assert.Equal(t, "command exited with non-zero code 2: compile error", err.Error())
+	})
+
+	t.Run("Success - No Output", func(t *testing.T) {
+		var killed bool
+		mockAPIHandler = streamingHandler(t, []string{
+			`{"output": "", "offset": 0, "done": true, "exit_code": 0, "stderr": ""}`,
+		}, &killed)
+
+		sr, err := s.ExecuteStreaming(context.Background(), &filesystem.ExecuteRequest{Command: "true"})
+		require.NoError(t, err)
+		defer sr.Close()
+
+		resp, err := sr.Recv()
+		require.NoError(t, err)
+		require.NotNil(t, resp.ExitCode)
+		assert.Equal(t, 0, *resp.ExitCode)
+	})
+
+	t.Run("Cancel - Kills The Command", func(t *testing.T) {
+		var killed bool
+		mockAPIHandler = streamingHandler(t, []string{
+			`{"output": "still running\n", "offset": 14, "done": false}`,
+			`{"output": "", "offset": 14, "done": false}`,
+		}, &killed)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		sr, err := s.ExecuteStreaming(ctx, &filesystem.ExecuteRequest{Command: "sleep 1000"})
+		require.NoError(t, err)
+		defer sr.Close()
+
+		resp, err := sr.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "still running\n", resp.Output)
+
+		cancel()
+		_, err = recvAll(sr)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.True(t, killed)
+	})
+
+	t.Run("Failure - Validation", func(t *testing.T) {
+		_, err := s.ExecuteStreaming(context.Background(), &filesystem.ExecuteRequest{})
+		assert.EqualError(t, err, "command is required")
+
+		s.readOnly = true
+		defer func() { s.readOnly = false }()
+		_, err = s.ExecuteStreaming(context.Background(), &filesystem.ExecuteRequest{Command: "ls"})
+		assert.ErrorIs(t, err, ErrReadOnly)
+	})
+}
+
+func TestArkSandbox_RunCode(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+
+	writeResult := func(t *testing.T, w http.ResponseWriter, res string) {
+		finalRes := response{}
+		finalRes.Result.Result = res
+		b, err := json.Marshal(finalRes)
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		w.Write(b)
+	}
+
+	t.Run("Success - Multiple Outputs", func(t *testing.T) {
+		var code string
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			code, _ = payload["code"].(string)
+
+			writeResult(t, w, `{"success": true, "data": {"outputs": [
+				{"output_type": "stream", "text": "plotting\n"},
+				{"output_type": "display_data", "data": {"image/png": "iVBORw0KGgo=", "text/plain": ["<Figure ", "640x480>"]}},
+				{"output_type": "execute_result", "data": {"text/html": "<table></table>", "application/json": {"rows": 2}}}
+			]}}`)
+		}
+
+		res, err := s.RunCode(context.Background(), "df.plot()")
+		require.NoError(t, err)
+		assert.Equal(t, "df.plot()", code)
+		assert.True(t, res.Success)
+		require.Len(t, res.Outputs, 3)
+		assert.Equal(t, "stream", res.Outputs[0].OutputType)
+		assert.Equal(t, "plotting\n", res.Outputs[0].Text)
+		assert.Equal(t, "display_data", res.Outputs[1].OutputType)
+		assert.Equal(t, "<Figure 640x480>", res.Outputs[1].Data["text/plain"])
+		assert.Equal(t, []string{"iVBORw0KGgo="}, res.Data("image/png"))
+		assert.Equal(t, []string{"<table></table>"}, res.Data("text/html"))
+		assert.Equal(t, []string{`{"rows":2}`}, res.Data("application/json"))
+	})
+
+	t.Run("Success - Code Raised", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			writeResult(t, w, `{"success": false, "data": {"outputs": [{"output_type": "error", "ename": "ZeroDivisionError", "evalue": "division by zero"}]}}`)
+		}
+		res, err := s.RunCode(context.Background(), "1/0")
+		require.NoError(t, err)
+		assert.False(t, res.Success)
+		require.Len(t, res.Outputs, 1)
+		assert.Equal(t, "ZeroDivisionError", res.Outputs[0].EName)
+	})
+
+	t.Run("Failure - Empty Code", func(t *testing.T) {
+		_, err := s.RunCode(context.Background(), "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "code is required")
+	})
+
+	t.Run("Execute Joins Stream Outputs", func(t *testing.T) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			writeResult(t, w, `{"success": true, "data": {"outputs": [{"output_type": "stream", "text": "a\n"}, {"output_type": "stream", "text": "b\n"}]}}`)
+		}
+		resp, err := s.Execute(context.Background(), &filesystem.ExecuteRequest{Command: "echo a; echo b"})
+		require.NoError(t, err)
+		assert.Equal(t, "a\nb\n", resp.Output)
+	})
+}
+
+func TestArkSandbox_MaxResultLines(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+	s.maxResultLines = 2
+
+	var code string
+	respond := func(out string) {
+		mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+			var req invokeToolRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(req.OperationPayload), &payload))
+			code, _ = payload["code"].(string)
+			w.WriteHeader(http.StatusOK)
+			w.Write(createMockResponse(t, true, out, "", ""))
+		}
+	}
+
+	t.Run("LsInfo", func(t *testing.T) {
+		respond("{\"path\": \"a\"}\n{\"path\": \"b\"}\n{\"truncated\": true}\n")
+		files, err := s.LsInfo(context.Background(), &filesystem.LsInfoRequest{Path: "/data"})
+		require.NoError(t, err)
+		assert.Equal(t, []filesystem.FileInfo{{Path: "a"}, {Path: "b"}}, files)
+		assert.Contains(t, code, "max_lines = 2")
+
+		res, err := s.LsInfoWithStat(context.Background(), &filesystem.LsInfoRequest{Path: "/data"})
+		require.NoError(t, err)
+		require.Len(t, res.Entries, 2)
+		assert.True(t, res.Truncated)
+	})
+
+	t.Run("GlobInfo", func(t *testing.T) {
+		respond("{\"path\": \"a.go\"}\n{\"path\": \"b.go\"}\n{\"truncated\": true}\n")
+		files, err := s.GlobInfo(context.Background(), &filesystem.GlobInfoRequest{Path: "/data", Pattern: "*.go"})
+		require.NoError(t, err)
+		assert.Equal(t, []filesystem.FileInfo{{Path: "a.go"}, {Path: "b.go"}}, files)
+		assert.Contains(t, code, "max_lines = 2")
+
+		res, err := s.GlobSorted(context.Background(), &SortedGlobRequest{
+			GlobInfoRequest: filesystem.GlobInfoRequest{Path: "/data", Pattern: "*.go"},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Entries, 2)
+		assert.True(t, res.Truncated)
+	})
+
+	t.Run("GrepRaw", func(t *testing.T) {
+		respond("{\"Path\": \"/a\", \"Line\": 1, \"Content\": \"go\"}\n{\"Path\": \"/a\", \"Line\": 2, \"Content\": \"go\"}\n{\"truncated\": true}\n")
+		matches, err := s.GrepRaw(context.Background(), &filesystem.GrepRequest{Path: "/data", Pattern: "go"})
+		require.NoError(t, err)
+		require.Len(t, matches, 2)
+		assert.Equal(t, 2, matches[1].Line)
+		assert.Contains(t, code, "max_lines = 2")
+	})
+
+	t.Run("Capped Without Truncation Line", func(t *testing.T) {
+		respond("{\"path\": \"a\"}\n{\"path\": \"b\"}\n{\"path\": \"c\"}\n")
+		res, err := s.LsInfoWithStat(context.Background(), &filesystem.LsInfoRequest{Path: "/data"})
+		require.NoError(t, err)
+		require.Len(t, res.Entries, 2)
+		assert.True(t, res.Truncated)
+	})
+
+	t.Run("Not Truncated", func(t *testing.T) {
+		respond("{\"path\": \"a\"}\n{\"path\": \"b\"}\n")
+		res, err := s.LsInfoWithStat(context.Background(), &filesystem.LsInfoRequest{Path: "/data"})
+		require.NoError(t, err)
+		require.Len(t, res.Entries, 2)
+		assert.Equal(t, "b", res.Entries[1].Path)
+		assert.False(t, res.Truncated)
+	})
+}
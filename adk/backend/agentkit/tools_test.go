@@ -0,0 +1,54 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package agentkit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTools(t *testing.T) {
+	s, server := setupTest(t)
+	defer server.Close()
+	ctx := context.Background()
+
+	tools, err := Tools(ctx, s)
+	require.NoError(t, err)
+
+	byName := map[string]tool.InvokableTool{}
+	for _, bt := range tools {
+		info, err := bt.Info(ctx)
+		require.NoError(t, err)
+		doc, err := info.ParamsOneOf.ToJSONSchema()
+		require.NoError(t, err)
+		assert.NotZero(t, doc.Properties.Len(), info.Name)
+		byName[info.Name] = bt.(tool.InvokableTool)
+	}
+	assert.Len(t, byName, 7)
+
+	mockAPIHandler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write(createMockResponse(t, true, "hi\n", "", ""))
+	}
+	out, err := byName["execute"].InvokableRun(ctx, `{"command": "echo hi"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "hi\n", out)
+}
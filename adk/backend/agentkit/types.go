@@ -16,6 +16,13 @@
 
 package agentkit
 
+import (
+	"os"
+	"time"
+
+	"github.com/cloudwego/eino/adk/filesystem"
+)
+
 type invokeToolRequest struct {
 	ToolID           string `json:"ToolId"`
 	SessionID        string `json:"SessionId"`
@@ -28,6 +35,10 @@ type invokeToolRequest struct {
 type response struct {
 	Result struct {
 		Result string `json:"result"`
+		// SessionID and UserSessionID identify the session that ran the request,
+		// including a session the API created because the request named none.
+		SessionID     string `json:"SessionId"`
+		UserSessionID string `json:"UserSessionId"`
 	} `json:"result"`
 }
 
@@ -39,8 +50,213 @@ type result struct {
 }
 
 type output struct {
-	OutputType string `json:"output_type"`
-	Text       string `json:"text"`
-	EName      string `json:"ename"`
-	EValue     string `json:"evalue"`
+	OutputType string         `json:"output_type"`
+	Text       string         `json:"text"`
+	EName      string         `json:"ename"`
+	EValue     string         `json:"evalue"`
+	Data       map[string]any `json:"data"`
+}
+
+// executeJob is the output of the execute start script, identifying a command running in the background.
+type executeJob struct {
+	JobDir string `json:"job_dir"`
+	Pid    int    `json:"pid"`
+}
+
+// executePoll is the output of the execute poll script.
+type executePoll struct {
+	// Output holds the complete lines written to stdout since the previous poll,
+	// and everything left once Done.
+	Output   string `json:"output"`
+	Offset   int64  `json:"offset"`
+	Done     bool   `json:"done"`
+	ExitCode int    `json:"exit_code"`
+	Stderr   string `json:"stderr"`
+}
+
+// ReadResult is the structured result of ReadWithInfo.
+type ReadResult struct {
+	// Content is the requested window of lines, numbered like Read.
+	Content string `json:"content"`
+	// TotalLines is the total number of lines in the file.
+	TotalLines int `json:"total_lines"`
+	// Truncated reports whether the file continues past the end of Content,
+	// either because of the line limit or because of MaxReadBytes.
+	Truncated bool `json:"truncated"`
+}
+
+// ForceWriteRequest is a WriteRequest that may replace an existing file.
+type ForceWriteRequest struct {
+	filesystem.WriteRequest
+
+	// Force overwrites the file, truncating it, when it already exists.
+	// When false, writing to an existing file fails like Write.
+	Force bool
+}
+
+// RemoveRequest removes the file or directory at Path.
+type RemoveRequest struct {
+	// Path is the absolute path to remove.
+	Path string
+	// Recursive removes a directory together with its contents.
+	// When false, only files and empty directories can be removed.
+	Recursive bool
+}
+
+// MoveRequest moves or renames the file or directory at Src to Dst.
+type MoveRequest struct {
+	Src string
+	Dst string
+	// Overwrite replaces an existing Dst instead of failing.
+	Overwrite bool
+}
+
+// EditOperation is a single replacement within a MultiEditRequest.
+type EditOperation struct {
+	OldString  string `json:"old_string"`
+	NewString  string `json:"new_string"`
+	ReplaceAll bool   `json:"replace_all"`
+}
+
+// MultiEditRequest applies Edits, in order, to the file at FilePath.
+type MultiEditRequest struct {
+	FilePath string
+	Edits    []EditOperation
+}
+
+// EditPreview is the result of DryRunEdit.
+type EditPreview struct {
+	// NewContent is the full file content after the edit.
+	NewContent string `json:"new_content"`
+	// Diff is a unified diff from the current content to NewContent.
+	Diff string `json:"diff"`
+}
+
+// PagedGrepRequest is a GrepRequest with result pagination.
+type PagedGrepRequest struct {
+	filesystem.GrepRequest
+
+	// Offset is the number of leading matches to skip.
+	Offset int
+	// MaxMatches caps the number of returned matches. Zero or negative means no cap.
+	MaxMatches int
+}
+
+// GrepPage is the result of GrepPaged.
+type GrepPage struct {
+	Matches []filesystem.GrepMatch `json:"matches"`
+	// HasMore reports whether further matches exist beyond this page.
+	HasMore bool `json:"has_more"`
+}
+
+// GlobSortBy selects the key used by GlobSorted.
+type GlobSortBy string
+
+const (
+	GlobSortByName  GlobSortBy = "name"
+	GlobSortByMtime GlobSortBy = "mtime"
+	GlobSortBySize  GlobSortBy = "size"
+)
+
+// SortedGlobRequest is a GlobInfoRequest with result ordering.
+type SortedGlobRequest struct {
+	filesystem.GlobInfoRequest
+
+	// SortBy is the sort key. Optional. Default GlobSortByName.
+	SortBy GlobSortBy
+	// Descending reverses the order, e.g. newest-first for GlobSortByMtime.
+	Descending bool
+}
+
+// GlobEntry is a glob match together with the metadata GlobSorted orders by.
+type GlobEntry struct {
+	filesystem.FileInfo
+
+	Size    int64
+	ModTime time.Time
+	IsDir   bool
+}
+
+// GlobResult is the result of GlobSorted.
+type GlobResult struct {
+	Entries []GlobEntry
+	// Truncated reports whether matches past Config.MaxResultLines were omitted.
+	Truncated bool
+}
+
+// LsEntry is a directory entry returned by LsInfoWithStat.
+// Size and ModTime are zero when the entry could not be stat'ed.
+type LsEntry struct {
+	filesystem.FileInfo
+
+	Size    int64
+	ModTime time.Time
+	IsDir   bool
+}
+
+// LsResult is the result of LsInfoWithStat.
+type LsResult struct {
+	Entries []LsEntry
+	// Truncated reports whether entries past Config.MaxResultLines were omitted.
+	Truncated bool
+}
+
+// FileStat is the metadata of a single path returned by Stat.
+type FileStat struct {
+	filesystem.FileInfo
+
+	Size    int64
+	ModTime time.Time
+	IsDir   bool
+	Mode    os.FileMode
+}
+
+// statLine is the output of the stat script.
+type statLine struct {
+	entryLine
+
+	// Mode holds the permission bits, including setuid, setgid and sticky.
+	Mode     uint32 `json:"mode"`
+	NotFound bool   `json:"not_found"`
+}
+
+// entryLine is one line of output from the ls and glob scripts.
+type entryLine struct {
+	Path  string  `json:"path"`
+	Size  int64   `json:"size"`
+	Mtime float64 `json:"mtime"`
+	IsDir bool    `json:"is_dir"`
+}
+
+// CodeOutput is one output produced by code run with RunCode, following the Jupyter output model.
+type CodeOutput struct {
+	// OutputType is the kernel output type, e.g. "stream", "execute_result", "display_data" or "error".
+	OutputType string
+	// Text is the text of a stream output.
+	Text string
+	// Data maps MIME types such as "text/plain", "text/html" or "image/png" to the content of a rich output.
+	// Binary content such as images is base64-encoded.
+	Data map[string]string
+	// EName and EValue describe an error output.
+	EName  string
+	EValue string
+}
+
+// CodeResult is the result of RunCode.
+type CodeResult struct {
+	// Success is false when the code raised an error; the error is then one of Outputs.
+	Success bool
+	// Outputs holds every output in the order the kernel produced them.
+	Outputs []CodeOutput
+}
+
+// Data returns the content of every output carrying the given MIME type, e.g. "image/png" for plots.
+func (r *CodeResult) Data(mimeType string) []string {
+	var ret []string
+	for _, o := range r.Outputs {
+		if v, ok := o.Data[mimeType]; ok {
+			ret = append(ret, v)
+		}
+	}
+	return ret
 }
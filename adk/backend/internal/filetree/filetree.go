@@ -0,0 +1,129 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package filetree renders lists of paths as indented trees for the filesystem backends.
+package filetree
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Entry is one path rendered by Render.
+type Entry struct {
+	// Path is slash-separated, either absolute or relative.
+	Path string
+	// Size is the file size in bytes. A negative size is not rendered.
+	Size int64
+	// IsDir marks Path as a directory, even if no other entry lies below it.
+	IsDir bool
+}
+
+type treeNode struct {
+	name     string
+	isDir    bool
+	size     int64
+	children map[string]*treeNode
+}
+
+// Render formats entries as an indented tree, two spaces per level.
+// Directories are suffixed with "/" and listed before files; siblings are sorted by name.
+// Parent directories missing from entries are created implicitly, and absolute paths hang off a "/" node.
+func Render(entries []Entry) string {
+	root := &treeNode{isDir: true, size: -1, children: map[string]*treeNode{}}
+	for _, e := range entries {
+		p := strings.TrimSuffix(e.Path, "/")
+		if p == "" && strings.HasPrefix(e.Path, "/") {
+			p = "/"
+		}
+		if p == "" || p == "." {
+			continue
+		}
+
+		var parts []string
+		if strings.HasPrefix(p, "/") {
+			parts = append(parts, "/")
+		}
+		for _, part := range strings.Split(p, "/") {
+			if part != "" && part != "." {
+				parts = append(parts, part)
+			}
+		}
+
+		n := root
+		for i, part := range parts {
+			child, ok := n.children[part]
+			if !ok {
+				child = &treeNode{name: part, size: -1, children: map[string]*treeNode{}}
+				n.children[part] = child
+			}
+			if i < len(parts)-1 {
+				child.isDir = true
+			} else {
+				child.isDir = e.IsDir || len(child.children) > 0
+				child.size = e.Size
+			}
+			n = child
+		}
+	}
+
+	var sb strings.Builder
+	writeTree(&sb, root, 0)
+	return sb.String()
+}
+
+func writeTree(sb *strings.Builder, n *treeNode, depth int) {
+	children := make([]*treeNode, 0, len(n.children))
+	for _, child := range n.children {
+		children = append(children, child)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].isDir != children[j].isDir {
+			return children[i].isDir
+		}
+		return children[i].name < children[j].name
+	})
+
+	for _, child := range children {
+		sb.WriteString(strings.Repeat("  ", depth))
+		sb.WriteString(child.name)
+		if child.isDir && child.name != "/" {
+			sb.WriteByte('/')
+		}
+		if !child.isDir && child.size >= 0 {
+			sb.WriteString(" (" + FormatSize(child.size) + ")")
+		}
+		sb.WriteByte('\n')
+		writeTree(sb, child, depth+1)
+	}
+}
+
+// FormatSize formats a byte count for humans, e.g. "512 B" or "1.5 KB".
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size)
+	suffixes := []string{"KB", "MB", "GB", "TB", "PB", "EB"}
+	i := -1
+	for value >= unit && i < len(suffixes)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", value, suffixes[i])
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filetree
+
+import "testing"
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []Entry
+		want    string
+	}{
+		{name: "empty", entries: nil, want: ""},
+		{
+			name: "nested relative paths",
+			entries: []Entry{
+				{Path: "src/util/strings.go", Size: 2048},
+				{Path: "README.md", Size: 512},
+				{Path: "src/main.go", Size: 1536},
+				{Path: "docs", IsDir: true, Size: -1},
+				{Path: "src/util/math.go", Size: 3 << 20},
+			},
+			want: "docs/\n" +
+				"src/\n" +
+				"  util/\n" +
+				"    math.go (3.0 MB)\n" +
+				"    strings.go (2.0 KB)\n" +
+				"  main.go (1.5 KB)\n" +
+				"README.md (512 B)\n",
+		},
+		{
+			name: "absolute paths",
+			entries: []Entry{
+				{Path: "/tmp/b.txt", Size: 1},
+				{Path: "/tmp/a/c.txt", Size: -1},
+			},
+			want: "/\n" +
+				"  tmp/\n" +
+				"    a/\n" +
+				"      c.txt\n" +
+				"    b.txt (1 B)\n",
+		},
+		{
+			name: "file listed before its children",
+			entries: []Entry{
+				{Path: "pkg", Size: 4096},
+				{Path: "pkg/a.go", Size: 0},
+			},
+			want: "pkg/\n" +
+				"  a.go (0 B)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.entries); got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{5 << 30, "5.0 GB"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatSize(tt.size); got != tt.want {
+			t.Errorf("FormatSize(%d) = %q, want %q", tt.size, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fstool exposes the operations of a filesystem backend as individual agent tools,
+// so that every backend offers the same tool names, descriptions and parameter schemas.
+package fstool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudwego/eino/adk/filesystem"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	ToolNameLs      = "ls"
+	ToolNameRead    = "read_file"
+	ToolNameWrite   = "write_file"
+	ToolNameEdit    = "edit_file"
+	ToolNameGlob    = "glob"
+	ToolNameGrep    = "grep"
+	ToolNameExecute = "execute"
+)
+
+type lsInput struct {
+	Path string `json:"path,omitempty" jsonschema_description:"The absolute path of the directory to list, defaults to the root directory"`
+}
+
+type readInput struct {
+	FilePath string `json:"file_path" jsonschema_description:"The absolute path of the file to read"`
+	Offset   int    `json:"offset,omitempty" jsonschema_description:"The 0-based line number to start reading from"`
+	Limit    int    `json:"limit,omitempty" jsonschema_description:"The maximum number of lines to read, defaults to 200"`
+}
+
+type writeInput struct {
+	FilePath string `json:"file_path" jsonschema_description:"The absolute path of the file to create, it must not exist yet"`
+	Content  string `json:"content" jsonschema_description:"The content to write to the file"`
+}
+
+type editInput struct {
+	FilePath   string `json:"file_path" jsonschema_description:"The absolute path of the file to edit"`
+	OldString  string `json:"old_string" jsonschema_description:"The exact text to replace, it must be unique in the file unless replace_all is set"`
+	NewString  string `json:"new_string" jsonschema_description:"The text to replace old_string with"`
+	ReplaceAll bool   `json:"replace_all,omitempty" jsonschema_description:"Replace every occurrence of old_string instead of requiring a unique match"`
+}
+
+type globInput struct {
+	Pattern string `json:"pattern" jsonschema_description:"The glob pattern to match, ** matches any number of directories"`
+	Path    string `json:"path,omitempty" jsonschema_description:"The absolute path of the directory to search in, defaults to the root directory"`
+}
+
+type grepInput struct {
+	Pattern string `json:"pattern" jsonschema_description:"The literal text to search for"`
+	Path    string `json:"path,omitempty" jsonschema_description:"The absolute path of the file or directory to search in, defaults to the root directory"`
+	Glob    string `json:"glob,omitempty" jsonschema_description:"Only search files whose name matches this glob pattern, e.g. *.go"`
+}
+
+type executeInput struct {
+	Command string `json:"command" jsonschema_description:"The shell command to execute"`
+}
+
+// New returns one tool per operation of b. The execute tool is only included when b implements
+// filesystem.ShellBackend or filesystem.StreamingShellBackend; streamed output is collected before returning. Results are rendered as plain text, one entry per line.
+func New(_ context.Context, b filesystem.Backend) ([]tool.BaseTool, error) {
+	if b == nil {
+		return nil, fmt.Errorf("backend is required")
+	}
+
+	ls, err := utils.InferTool(ToolNameLs, "Lists the entries of a directory.",
+		func(ctx context.Context, in *lsInput) (string, error) {
+			files, err := b.LsInfo(ctx, &filesystem.LsInfoRequest{Path: in.Path})
+			if err != nil {
+				return "", err
+			}
+			return joinPaths(files), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	read, err := utils.InferTool(ToolNameRead, "Reads a file, returning its lines prefixed with their line numbers.",
+		func(ctx context.Context, in *readInput) (string, error) {
+			return b.Read(ctx, &filesystem.ReadRequest{FilePath: in.FilePath, Offset: in.Offset, Limit: in.Limit})
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	write, err := utils.InferTool(ToolNameWrite, "Creates a new file with the given content.",
+		func(ctx context.Context, in *writeInput) (string, error) {
+			if err := b.Write(ctx, &filesystem.WriteRequest{FilePath: in.FilePath, Content: in.Content}); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Wrote %s", in.FilePath), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	edit, err := utils.InferTool(ToolNameEdit, "Replaces text in an existing file.",
+		func(ctx context.Context, in *editInput) (string, error) {
+			err := b.Edit(ctx, &filesystem.EditRequest{
+				FilePath:   in.FilePath,
+				OldString:  in.OldString,
+				NewString:  in.NewString,
+				ReplaceAll: in.ReplaceAll,
+			})
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Edited %s", in.FilePath), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	glob, err := utils.InferTool(ToolNameGlob, "Finds files whose path matches a glob pattern.",
+		func(ctx context.Context, in *globInput) (string, error) {
+			files, err := b.GlobInfo(ctx, &filesystem.GlobInfoRequest{Pattern: in.Pattern, Path: in.Path})
+			if err != nil {
+				return "", err
+			}
+			return joinPaths(files), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	grep, err := utils.InferTool(ToolNameGrep, "Searches file contents for a literal text, returning path:line:content for each match.",
+		func(ctx context.Context, in *grepInput) (string, error) {
+			matches, err := b.GrepRaw(ctx, &filesystem.GrepRequest{Pattern: in.Pattern, Path: in.Path, Glob: in.Glob})
+			if err != nil {
+				return "", err
+			}
+			var sb strings.Builder
+			for _, m := range matches {
+				sb.WriteString(fmt.Sprintf("%s:%d:%s\n", m.Path, m.Line, m.Content))
+			}
+			return sb.String(), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	tools := []tool.BaseTool{ls, read, write, edit, glob, grep}
+
+	var execute func(ctx context.Context, req *filesystem.ExecuteRequest) (*filesystem.ExecuteResponse, error)
+	if sb, ok := b.(filesystem.ShellBackend); ok {
+		execute = sb.Execute
+	} else if ssb, ok := b.(filesystem.StreamingShellBackend); ok {
+		execute = func(ctx context.Context, req *filesystem.ExecuteRequest) (*filesystem.ExecuteResponse, error) {
+			sr, err := ssb.ExecuteStreaming(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			return collectExecuteResponse(sr)
+		}
+	}
+
+	if execute != nil {
+		t, err := utils.InferTool(ToolNameExecute, "Executes a shell command and returns its output.",
+			func(ctx context.Context, in *executeInput) (string, error) {
+				resp, err := execute(ctx, &filesystem.ExecuteRequest{Command: in.Command})
+				if err != nil {
+					return "", err
+				}
+				return formatExecuteResponse(resp), nil
+			})
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, t)
+	}
+
+	return tools, nil
+}
+
+func joinPaths(files []filesystem.FileInfo) string {
+	var sb strings.Builder
+	for _, f := range files {
+		sb.WriteString(f.Path)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// collectExecuteResponse drains a streamed execution into a single response.
+func collectExecuteResponse(sr *schema.StreamReader[*filesystem.ExecuteResponse]) (*filesystem.ExecuteResponse, error) {
+	defer sr.Close()
+
+	var sb strings.Builder
+	result := &filesystem.ExecuteResponse{}
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if chunk == nil {
+			continue
+		}
+		sb.WriteString(chunk.Output)
+		if chunk.ExitCode != nil {
+			result.ExitCode = chunk.ExitCode
+		}
+		result.Truncated = result.Truncated || chunk.Truncated
+	}
+	result.Output = sb.String()
+	return result, nil
+}
+
+func formatExecuteResponse(resp *filesystem.ExecuteResponse) string {
+	output := resp.Output
+	if resp.Truncated {
+		output += "\n[output truncated]"
+	}
+	if resp.ExitCode != nil && *resp.ExitCode != 0 {
+		output += fmt.Sprintf("\n[exit code %d]", *resp.ExitCode)
+	}
+	return output
+}
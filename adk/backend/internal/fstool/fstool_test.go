@@ -0,0 +1,185 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fstool
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/adk/filesystem"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBackend struct {
+	lastEdit *filesystem.EditRequest
+}
+
+func (f *fakeBackend) LsInfo(_ context.Context, _ *filesystem.LsInfoRequest) ([]filesystem.FileInfo, error) {
+	return []filesystem.FileInfo{{Path: "a.txt"}, {Path: "b"}}, nil
+}
+
+func (f *fakeBackend) Read(_ context.Context, req *filesystem.ReadRequest) (string, error) {
+	return "     1\thello " + req.FilePath + "\n", nil
+}
+
+func (f *fakeBackend) GrepRaw(_ context.Context, _ *filesystem.GrepRequest) ([]filesystem.GrepMatch, error) {
+	return []filesystem.GrepMatch{{Path: "/a.txt", Line: 3, Content: "hello"}}, nil
+}
+
+func (f *fakeBackend) GlobInfo(_ context.Context, _ *filesystem.GlobInfoRequest) ([]filesystem.FileInfo, error) {
+	return []filesystem.FileInfo{{Path: "src/main.go"}}, nil
+}
+
+func (f *fakeBackend) Write(_ context.Context, _ *filesystem.WriteRequest) error {
+	return errors.New("file already exists")
+}
+
+func (f *fakeBackend) Edit(_ context.Context, req *filesystem.EditRequest) error {
+	f.lastEdit = req
+	return nil
+}
+
+type fakeShellBackend struct {
+	fakeBackend
+}
+
+func (f *fakeShellBackend) Execute(_ context.Context, req *filesystem.ExecuteRequest) (*filesystem.ExecuteResponse, error) {
+	code := 2
+	return &filesystem.ExecuteResponse{Output: "ran " + req.Command, ExitCode: &code}, nil
+}
+
+type fakeStreamingBackend struct {
+	fakeBackend
+}
+
+func (f *fakeStreamingBackend) ExecuteStreaming(_ context.Context, req *filesystem.ExecuteRequest) (*schema.StreamReader[*filesystem.ExecuteResponse], error) {
+	code := 0
+	return schema.StreamReaderFromArray([]*filesystem.ExecuteResponse{
+		{Output: "line 1\n"},
+		{Output: "line 2\n"},
+		{ExitCode: &code},
+	}), nil
+}
+
+func toolsByName(t *testing.T, tools []tool.BaseTool) map[string]tool.InvokableTool {
+	result := make(map[string]tool.InvokableTool, len(tools))
+	for _, bt := range tools {
+		info, err := bt.Info(context.Background())
+		require.NoError(t, err)
+		it, ok := bt.(tool.InvokableTool)
+		require.True(t, ok, "tool "+info.Name+" is not invokable")
+		result[info.Name] = it
+	}
+	return result
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("schemas", func(t *testing.T) {
+		tools, err := New(ctx, &fakeShellBackend{})
+		require.NoError(t, err)
+
+		wantParams := map[string][]string{
+			ToolNameLs:      {"Path"},
+			ToolNameRead:    {"FilePath", "Offset", "Limit"},
+			ToolNameWrite:   {"FilePath", "Content"},
+			ToolNameEdit:    {"FilePath", "OldString", "NewString", "ReplaceAll"},
+			ToolNameGlob:    {"Pattern", "Path"},
+			ToolNameGrep:    {"Pattern", "Path", "Glob"},
+			ToolNameExecute: {"Command"},
+		}
+		require.Len(t, tools, len(wantParams))
+
+		for _, bt := range tools {
+			info, err := bt.Info(ctx)
+			require.NoError(t, err)
+			assert.NotEmpty(t, info.Desc)
+
+			doc, err := info.ParamsOneOf.ToJSONSchema()
+			require.NoError(t, err)
+			require.NotNil(t, doc)
+			assert.Equal(t, len(wantParams[info.Name]), doc.Properties.Len(), info.Name)
+			for pair := doc.Properties.Oldest(); pair != nil; pair = pair.Next() {
+				assert.NotEmpty(t, pair.Value.Description, info.Name+"."+pair.Key)
+			}
+		}
+	})
+
+	t.Run("execute only for shell backends", func(t *testing.T) {
+		tools, err := New(ctx, &fakeBackend{})
+		require.NoError(t, err)
+		byName := toolsByName(t, tools)
+		assert.Len(t, byName, 6)
+		assert.NotContains(t, byName, ToolNameExecute)
+	})
+
+	t.Run("execute for streaming shell backends", func(t *testing.T) {
+		tools, err := New(ctx, &fakeStreamingBackend{})
+		require.NoError(t, err)
+		byName := toolsByName(t, tools)
+		require.Contains(t, byName, ToolNameExecute)
+
+		out, err := byName[ToolNameExecute].InvokableRun(ctx, `{"command": "seq 2"}`)
+		require.NoError(t, err)
+		assert.Equal(t, "line 1\nline 2\n", out)
+	})
+
+	t.Run("nil backend", func(t *testing.T) {
+		_, err := New(ctx, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("invoke", func(t *testing.T) {
+		b := &fakeShellBackend{}
+		tools, err := New(ctx, b)
+		require.NoError(t, err)
+		byName := toolsByName(t, tools)
+
+		out, err := byName[ToolNameLs].InvokableRun(ctx, `{}`)
+		require.NoError(t, err)
+		assert.Equal(t, "a.txt\nb\n", out)
+
+		out, err = byName[ToolNameRead].InvokableRun(ctx, `{"file_path": "/a.txt"}`)
+		require.NoError(t, err)
+		assert.Equal(t, "     1\thello /a.txt\n", out)
+
+		_, err = byName[ToolNameWrite].InvokableRun(ctx, `{"file_path": "/a.txt", "content": "x"}`)
+		assert.ErrorContains(t, err, "file already exists")
+
+		out, err = byName[ToolNameEdit].InvokableRun(ctx, `{"file_path": "/a.txt", "old_string": "a", "new_string": "b", "replace_all": true}`)
+		require.NoError(t, err)
+		assert.Equal(t, "Edited /a.txt", out)
+		assert.Equal(t, &filesystem.EditRequest{FilePath: "/a.txt", OldString: "a", NewString: "b", ReplaceAll: true}, b.lastEdit)
+
+		out, err = byName[ToolNameGlob].InvokableRun(ctx, `{"pattern": "**/*.go"}`)
+		require.NoError(t, err)
+		assert.Equal(t, "src/main.go\n", out)
+
+		out, err = byName[ToolNameGrep].InvokableRun(ctx, `{"pattern": "hello"}`)
+		require.NoError(t, err)
+		assert.Equal(t, "/a.txt:3:hello\n", out)
+
+		out, err = byName[ToolNameExecute].InvokableRun(ctx, `{"command": "false"}`)
+		require.NoError(t, err)
+		assert.Equal(t, "ran false\n[exit code 2]", out)
+	})
+}
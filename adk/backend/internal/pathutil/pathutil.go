@@ -0,0 +1,98 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package pathutil holds the path handling shared by the filesystem backends,
+// so that every backend accepts, rejects and renders paths exactly the same way.
+package pathutil
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ErrOutsideRoot is returned when a path escapes the configured root directory.
+var ErrOutsideRoot = errors.New("path escapes root directory")
+
+// Options controls ValidatePath.
+type Options struct {
+	// DefaultPath replaces an empty path. When Root is set, an empty path defaults to Root instead.
+	DefaultPath string
+
+	// AllowRelative skips the absolute path requirement, e.g. for searches relative to the working directory.
+	// It has no effect when Root is set, since a relative path cannot be checked against the jail.
+	AllowRelative bool
+
+	// Root confines the path to Root and its descendants.
+	// The check is lexical only: symlinks are not resolved, since the path may not live on the
+	// local filesystem, so a backend that can follow links must resolve them itself.
+	// Optional. An empty Root disables the jail.
+	Root string
+}
+
+// ValidatePath cleans path and checks it against opts, returning the cleaned path.
+// Repeated separators and "." / ".." elements are resolved lexically, so "/a//b/../c" becomes "/a/c";
+// the result is rejected if it is relative (unless allowed) or lies outside opts.Root.
+func ValidatePath(path string, opts Options) (string, error) {
+	if strings.ContainsRune(path, 0) {
+		return "", fmt.Errorf("path must not contain NUL bytes: %q", path)
+	}
+
+	root := ""
+	if opts.Root != "" {
+		root = filepath.Clean(opts.Root)
+		if !filepath.IsAbs(root) {
+			return "", fmt.Errorf("root must be an absolute path: %s", root)
+		}
+	}
+
+	if path == "" {
+		if root != "" {
+			path = root
+		} else {
+			path = opts.DefaultPath
+		}
+	}
+
+	path = filepath.Clean(path)
+	if !filepath.IsAbs(path) {
+		if root != "" || !opts.AllowRelative {
+			return "", fmt.Errorf("path must be an absolute path: %s", path)
+		}
+		return path, nil
+	}
+
+	if root != "" && !isWithin(root, path) {
+		return "", fmt.Errorf("%w %s: %s", ErrOutsideRoot, root, path)
+	}
+
+	return path, nil
+}
+
+// isWithin reports whether the cleaned absolute path is root or one of its descendants.
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// IsDescendant reports whether the cleaned absolute path is strictly below dir.
+func IsDescendant(dir, path string) bool {
+	return path != dir && isWithin(dir, path)
+}
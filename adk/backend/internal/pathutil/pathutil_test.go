@@ -0,0 +1,99 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pathutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidatePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		opts    Options
+		want    string
+		wantErr string
+	}{
+		{name: "clean absolute path", path: "/a/b.txt", want: "/a/b.txt"},
+		{name: "parent element", path: "/a/../b/c.txt", want: "/b/c.txt"},
+		{name: "parent element above root", path: "/../../etc/passwd", want: "/etc/passwd"},
+		{name: "double slashes", path: "//a//b///c.txt", want: "/a/b/c.txt"},
+		{name: "dot elements", path: "/a/./b/.", want: "/a/b"},
+		{name: "trailing slash", path: "/a/b/", want: "/a/b"},
+		{name: "relative path", path: "a/b.txt", wantErr: "path must be an absolute path: a/b.txt"},
+		{name: "relative parent path", path: "../a", wantErr: "path must be an absolute path: ../a"},
+		{name: "relative path allowed", path: "a/../b", opts: Options{AllowRelative: true}, want: "b"},
+		{name: "empty path", path: "", wantErr: "path must be an absolute path: ."},
+		{name: "empty path allowed relative", path: "", opts: Options{AllowRelative: true}, want: "."},
+		{name: "empty path with default", path: "", opts: Options{DefaultPath: "/"}, want: "/"},
+		{name: "NUL byte", path: "/a\x00b", wantErr: "must not contain NUL bytes"},
+		{name: "inside root", path: "/work/a/b.txt", opts: Options{Root: "/work"}, want: "/work/a/b.txt"},
+		{name: "root itself", path: "/work/", opts: Options{Root: "/work"}, want: "/work"},
+		{name: "empty path defaults to root", path: "", opts: Options{DefaultPath: "/", Root: "/work"}, want: "/work"},
+		{name: "traversal out of root", path: "/work/../etc/passwd", opts: Options{Root: "/work"}, wantErr: "path escapes root directory /work: /etc/passwd"},
+		{name: "sibling with root prefix", path: "/workspace/a", opts: Options{Root: "/work"}, wantErr: "path escapes root directory"},
+		{name: "dirty root", path: "/work/a", opts: Options{Root: "/work//x/.."}, want: "/work/a"},
+		{name: "relative path with root", path: "a", opts: Options{Root: "/work", AllowRelative: true}, wantErr: "path must be an absolute path: a"},
+		{name: "relative root", path: "/a", opts: Options{Root: "work"}, wantErr: "root must be an absolute path"},
+		{name: "file name starting with dots", path: "/work/..a", opts: Options{Root: "/work"}, want: "/work/..a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidatePath(tt.path, tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ValidatePath(%q) error = %v, want containing %q", tt.path, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidatePath(%q) unexpected error: %v", tt.path, err)
+			}
+			if got != tt.want {
+				t.Fatalf("ValidatePath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePath_ErrOutsideRoot(t *testing.T) {
+	_, err := ValidatePath("/etc/passwd", Options{Root: "/work"})
+	if !errors.Is(err, ErrOutsideRoot) {
+		t.Fatalf("expected ErrOutsideRoot, got %v", err)
+	}
+}
+
+func TestIsDescendant(t *testing.T) {
+	tests := []struct {
+		dir, path string
+		want      bool
+	}{
+		{"/work", "/work/a", true},
+		{"/work", "/work/a/b", true},
+		{"/work", "/work", false},
+		{"/work", "/workspace", false},
+		{"/work/a", "/work", false},
+	}
+	for _, tt := range tests {
+		if got := IsDescendant(tt.dir, tt.path); got != tt.want {
+			t.Errorf("IsDescendant(%q, %q) = %v, want %v", tt.dir, tt.path, got, tt.want)
+		}
+	}
+}
@@ -2,8 +2,11 @@ module github.com/cloudwego/eino-ext/adk/backend/local
 
 go 1.18
 
+replace github.com/cloudwego/eino-ext/adk/backend/internal => ../internal
+
 require (
 	github.com/cloudwego/eino v0.7.27
+	github.com/cloudwego/eino-ext/adk/backend/internal v0.0.0-00010101000000-000000000000
 	github.com/stretchr/testify v1.11.1
 )
 
@@ -19,9 +19,12 @@ package local
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -29,19 +32,161 @@ import (
 	"runtime/debug"
 	"sort"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/cloudwego/eino/adk/filesystem"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/adk/backend/internal/filetree"
+	"github.com/cloudwego/eino-ext/adk/backend/internal/pathutil"
+)
+
+const (
+	defaultRootPath      = "/"
+	defaultWatchInterval = time.Second
 )
 
-const defaultRootPath = "/"
+// ErrReadOnly is returned by mutating and execute methods when the backend is read-only.
+var ErrReadOnly = errors.New("backend is read-only")
+
+// ErrNotFound is wrapped by the error Stat returns when the path does not exist.
+var ErrNotFound = errors.New("file not found")
 
 type Config struct {
 	ValidateCommand func(string) error
+
+	// ReadOnly rejects Write, Edit, MultiEdit, Move and ExecuteStreaming with ErrReadOnly,
+	// while LsInfo, Read, GrepRaw and GlobInfo keep working.
+	// Optional. Default false.
+	ReadOnly bool
+
+	// AuditFunc is invoked after every backend method call with the method name (e.g. "Read"),
+	// the request and the resulting error (nil on success).
+	// For ExecuteStreaming, the error reflects starting the command, not its exit status.
+	// A panic in AuditFunc is recovered and never fails the operation.
+	// Optional.
+	AuditFunc func(ctx context.Context, op string, req any, err error)
+
+	// MaxReadBytes caps the content returned by ReadWithInfo. When the requested window exceeds it,
+	// the content is cut at a line boundary and ReadResult.Truncated is set.
+	// Optional. Default 0, which means no cap.
+	MaxReadBytes int
+
+	// RootDir confines every path to RootDir and its descendants; paths that escape it,
+	// e.g. through ".." or through a symlink pointing outside of RootDir, are rejected.
+	// Symlinks are resolved when the path is checked, so a link swapped in between the check
+	// and the operation is not caught. When set, empty paths default to RootDir.
+	// Optional. Default "", which allows any absolute path.
+	RootDir string
+}
+
+// ReadResult is the structured result of ReadWithInfo.
+type ReadResult struct {
+	// Content is the requested window of lines, numbered like Read.
+	Content string
+	// TotalLines is the total number of lines in the file.
+	TotalLines int
+	// Truncated reports whether the file continues past the end of Content,
+	// either because of the line limit or because of MaxReadBytes.
+	Truncated bool
+}
+
+// EditOperation is a single replacement within a MultiEditRequest.
+type EditOperation struct {
+	OldString  string
+	NewString  string
+	ReplaceAll bool
+}
+
+// MultiEditRequest applies Edits, in order, to the file at FilePath.
+type MultiEditRequest struct {
+	FilePath string
+	Edits    []EditOperation
+}
+
+// MoveRequest moves or renames the file or directory at Src to Dst.
+type MoveRequest struct {
+	Src string
+	Dst string
+	// Overwrite replaces an existing Dst instead of failing.
+	Overwrite bool
+}
+
+// EditPreview is the result of DryRunEdit.
+type EditPreview struct {
+	// NewContent is the full file content after the edit.
+	NewContent string
+	// Diff is a unified diff from the current content to NewContent.
+	Diff string
+}
+
+// PagedGrepRequest is a GrepRequest with result pagination.
+type PagedGrepRequest struct {
+	filesystem.GrepRequest
+
+	// Offset is the number of leading matches to skip.
+	Offset int
+	// MaxMatches caps the number of returned matches. Zero or negative means no cap.
+	MaxMatches int
 }
 
-type backend struct {
+// GrepPage is the result of GrepPaged.
+type GrepPage struct {
+	Matches []filesystem.GrepMatch
+	// HasMore reports whether further matches exist beyond this page.
+	HasMore bool
+}
+
+// GlobSortBy selects the key used by GlobSorted.
+type GlobSortBy string
+
+const (
+	GlobSortByName  GlobSortBy = "name"
+	GlobSortByMtime GlobSortBy = "mtime"
+	GlobSortBySize  GlobSortBy = "size"
+)
+
+// SortedGlobRequest is a GlobInfoRequest with result ordering.
+type SortedGlobRequest struct {
+	filesystem.GlobInfoRequest
+
+	// SortBy is the sort key. Optional. Default GlobSortByName.
+	SortBy GlobSortBy
+	// Descending reverses the order, e.g. newest-first for GlobSortByMtime.
+	Descending bool
+}
+
+// GlobEntry is a glob match together with the metadata GlobSorted orders by.
+type GlobEntry struct {
+	filesystem.FileInfo
+
+	Size    int64
+	ModTime time.Time
+	IsDir   bool
+}
+
+// FileStat is the metadata of a single path returned by Stat.
+type FileStat struct {
+	filesystem.FileInfo
+
+	Size    int64
+	ModTime time.Time
+	IsDir   bool
+	Mode    os.FileMode
+}
+
+var _ filesystem.Backend = (*Backend)(nil)
+
+// Backend is a filesystem.Backend on the local disk. Besides the interface, it offers operations
+// such as Move, Stat, MultiEdit and ExecuteStreaming.
+type Backend struct {
 	validateCommand func(string) error
+	readOnly        bool
+	auditFunc       func(ctx context.Context, op string, req any, err error)
+	maxReadBytes    int
+	rootDir         string
 }
 
 var defaultValidateCommand = func(string) error {
@@ -55,7 +200,7 @@ var defaultValidateCommand = func(string) error {
 //   - NOT Supported: Windows (requires custom implementation of Backend)
 //   - Command Execution: Uses /bin/sh by default for Execute method
 //   - If /bin/sh does not meet your requirements, please implement your own Backend
-func NewBackend(_ context.Context, cfg *Config) (filesystem.Backend, error) {
+func NewBackend(_ context.Context, cfg *Config) (*Backend, error) {
 	if cfg == nil {
 		return nil, errors.New("config is required")
 	}
@@ -65,19 +210,21 @@ func NewBackend(_ context.Context, cfg *Config) (filesystem.Backend, error) {
 		validateCommand = cfg.ValidateCommand
 	}
 
-	return &backend{
+	return &Backend{
 		validateCommand: validateCommand,
+		readOnly:        cfg.ReadOnly,
+		auditFunc:       cfg.AuditFunc,
+		maxReadBytes:    cfg.MaxReadBytes,
+		rootDir:         cfg.RootDir,
 	}, nil
 }
 
-func (s *backend) LsInfo(ctx context.Context, req *filesystem.LsInfoRequest) ([]filesystem.FileInfo, error) {
-	if req.Path == "" {
-		req.Path = defaultRootPath
-	}
+func (s *Backend) LsInfo(ctx context.Context, req *filesystem.LsInfoRequest) (_ []filesystem.FileInfo, err error) {
+	defer func() { s.audit(ctx, "LsInfo", req, err) }()
 
-	path := filepath.Clean(req.Path)
-	if !filepath.IsAbs(path) {
-		return nil, fmt.Errorf("path must be an absolute path: %s", path)
+	path, err := s.validatePath(req.Path, defaultRootPath, false)
+	if err != nil {
+		return nil, err
 	}
 
 	entries, err := os.ReadDir(path)
@@ -105,10 +252,12 @@ func (s *backend) LsInfo(ctx context.Context, req *filesystem.LsInfoRequest) ([]
 	return files, nil
 }
 
-func (s *backend) Read(ctx context.Context, req *filesystem.ReadRequest) (string, error) {
-	path := filepath.Clean(req.FilePath)
-	if !filepath.IsAbs(path) {
-		return "", fmt.Errorf("path must be an absolute path: %s", path)
+func (s *Backend) Read(ctx context.Context, req *filesystem.ReadRequest) (_ string, err error) {
+	defer func() { s.audit(ctx, "Read", req, err) }()
+
+	path, err := s.validatePath(req.FilePath, "", false)
+	if err != nil {
+		return "", err
 	}
 
 	file, err := os.Open(path)
@@ -160,12 +309,160 @@ func (s *backend) Read(ctx context.Context, req *filesystem.ReadRequest) (string
 	return result.String(), nil
 }
 
-func (s *backend) GrepRaw(ctx context.Context, req *filesystem.GrepRequest) ([]filesystem.GrepMatch, error) {
-	path := filepath.Clean(req.Path)
+// ReadWithInfo reads a window of lines like Read, and additionally reports the total line count
+// of the file and whether the returned content stops before the end of the file.
+func (s *Backend) ReadWithInfo(ctx context.Context, req *filesystem.ReadRequest) (_ *ReadResult, err error) {
+	defer func() { s.audit(ctx, "ReadWithInfo", req, err) }()
+
+	path, err := s.validatePath(req.FilePath, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("file not found: %s", path)
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 200
+	}
+
+	scanner := bufio.NewScanner(file)
+	var content strings.Builder
+	lineIdx := 0
+	linesRead := 0
+	capped := false
+
+	for scanner.Scan() {
+		if lineIdx >= offset && linesRead < limit && !capped {
+			line := fmt.Sprintf("%6d\t%s\n", lineIdx+1, scanner.Text())
+			if s.maxReadBytes > 0 && content.Len()+len(line) > s.maxReadBytes {
+				capped = true
+			} else {
+				content.WriteString(line)
+				linesRead++
+			}
+		}
+		lineIdx++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	return &ReadResult{
+		Content:    content.String(),
+		TotalLines: lineIdx,
+		Truncated:  capped || offset+linesRead < lineIdx,
+	}, nil
+}
+
+// Hash returns the hex-encoded SHA-256 of the file contents, so callers can skip re-reading unchanged files.
+func (s *Backend) Hash(ctx context.Context, path string) (_ string, err error) {
+	defer func() { s.audit(ctx, "Hash", path, err) }()
+
+	path, err = s.validatePath(path, "", false)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("file not found: %s", path)
+		}
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("failed to hash file: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Stat returns the metadata of path, following symlinks. A missing path is reported
+// with an error wrapping ErrNotFound.
+func (s *Backend) Stat(ctx context.Context, path string) (_ FileStat, err error) {
+	defer func() { s.audit(ctx, "Stat", path, err) }()
+
+	path, err = s.validatePath(path, "", false)
+	if err != nil {
+		return FileStat{}, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return FileStat{}, fmt.Errorf("%w: %s", ErrNotFound, path)
+		}
+		return FileStat{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return FileStat{
+		FileInfo: filesystem.FileInfo{Path: path},
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		IsDir:    info.IsDir(),
+		Mode:     info.Mode(),
+	}, nil
+}
+
+func (s *Backend) GrepRaw(ctx context.Context, req *filesystem.GrepRequest) (_ []filesystem.GrepMatch, err error) {
+	defer func() { s.audit(ctx, "GrepRaw", req, err) }()
+
+	matches, _, err := s.grep(ctx, req, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// GrepPaged searches like GrepRaw, but skips the first Offset matches and stops walking
+// once MaxMatches matches have been collected, reporting whether more matches exist.
+func (s *Backend) GrepPaged(ctx context.Context, req *PagedGrepRequest) (_ *GrepPage, err error) {
+	defer func() { s.audit(ctx, "GrepPaged", req, err) }()
+
+	matches, hasMore, err := s.grep(ctx, &req.GrepRequest, req.Offset, req.MaxMatches)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GrepPage{
+		Matches: matches,
+		HasMore: hasMore,
+	}, nil
+}
+
+// errGrepLimitReached stops the walk once a grep page is full.
+var errGrepLimitReached = errors.New("grep limit reached")
+
+// grep walks req.Path and collects matches after skipping offset of them.
+// A positive maxMatches stops the walk as soon as one match beyond the page is found.
+func (s *Backend) grep(ctx context.Context, req *filesystem.GrepRequest, offset, maxMatches int) ([]filesystem.GrepMatch, bool, error) {
+	path, err := s.validatePath(req.Path, "", true)
+	if err != nil {
+		return nil, false, err
+	}
 
 	var matches []filesystem.GrepMatch
+	found := 0
+	hasMore := false
 
-	err := filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+	err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -211,11 +508,18 @@ func (s *backend) GrepRaw(ctx context.Context, req *filesystem.GrepRequest) ([]f
 			}
 
 			if strings.Contains(scanner.Text(), req.Pattern) {
-				matches = append(matches, filesystem.GrepMatch{
-					Path:    p,
-					Line:    lineNumber,
-					Content: scanner.Text(),
-				})
+				if maxMatches > 0 && found >= offset+maxMatches {
+					hasMore = true
+					return errGrepLimitReached
+				}
+				if found >= offset {
+					matches = append(matches, filesystem.GrepMatch{
+						Path:    p,
+						Line:    lineNumber,
+						Content: scanner.Text(),
+					})
+				}
+				found++
 			}
 			lineNumber++
 		}
@@ -225,25 +529,59 @@ func (s *backend) GrepRaw(ctx context.Context, req *filesystem.GrepRequest) ([]f
 		return nil
 	})
 
+	if err != nil && !errors.Is(err, errGrepLimitReached) {
+		return nil, false, fmt.Errorf("error during grep operation: %w", err)
+	}
+
+	return matches, hasMore, nil
+}
+
+func (s *Backend) GlobInfo(ctx context.Context, req *filesystem.GlobInfoRequest) (_ []filesystem.FileInfo, err error) {
+	defer func() { s.audit(ctx, "GlobInfo", req, err) }()
+
+	entries, err := s.glob(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("error during grep operation: %w", err)
+		return nil, err
 	}
 
-	return matches, nil
+	var files []filesystem.FileInfo
+	for _, entry := range entries {
+		files = append(files, entry.FileInfo)
+	}
+
+	return files, nil
 }
 
-func (s *backend) GlobInfo(ctx context.Context, req *filesystem.GlobInfoRequest) ([]filesystem.FileInfo, error) {
-	if req.Path == "" {
-		req.Path = defaultRootPath
+// GlobSorted matches like GlobInfo and returns the matches with their metadata,
+// ordered by req.SortBy (name, mtime or size) and req.Descending. Ties are broken by name.
+func (s *Backend) GlobSorted(ctx context.Context, req *SortedGlobRequest) (_ []GlobEntry, err error) {
+	defer func() { s.audit(ctx, "GlobSorted", req, err) }()
+
+	entries, err := s.glob(ctx, &req.GlobInfoRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sortGlobEntries(entries, req.SortBy, req.Descending); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// glob walks req.Path and returns the entries whose relative path matches req.Pattern, sorted by name.
+func (s *Backend) glob(ctx context.Context, req *filesystem.GlobInfoRequest) ([]GlobEntry, error) {
+	path, err := s.validatePath(req.Path, defaultRootPath, true)
+	if err != nil {
+		return nil, err
 	}
-	path := filepath.Clean(req.Path)
 
 	regex, err := globToRegex(req.Pattern)
 	if err != nil {
 		return nil, fmt.Errorf("invalid glob pattern: %w", err)
 	}
 
-	var matches []string
+	var entries []GlobEntry
 	err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
 		select {
 		case <-ctx.Done():
@@ -270,7 +608,19 @@ func (s *backend) GlobInfo(ctx context.Context, req *filesystem.GlobInfoRequest)
 		}
 
 		if regex.MatchString(relPath) {
-			matches = append(matches, relPath)
+			info, err := d.Info()
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return fmt.Errorf("failed to stat %s: %w", p, err)
+			}
+			entries = append(entries, GlobEntry{
+				FileInfo: filesystem.FileInfo{Path: relPath},
+				Size:     info.Size(),
+				ModTime:  info.ModTime(),
+				IsDir:    d.IsDir(),
+			})
 		}
 
 		return nil
@@ -280,16 +630,53 @@ func (s *backend) GlobInfo(ctx context.Context, req *filesystem.GlobInfoRequest)
 		return nil, fmt.Errorf("failed to walk directory: %w", err)
 	}
 
-	sort.Strings(matches)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
 
-	var files []filesystem.FileInfo
-	for _, match := range matches {
-		files = append(files, filesystem.FileInfo{
-			Path: match,
-		})
+	return entries, nil
+}
+
+// sortGlobEntries orders entries by the given key, keeping name order for ties.
+func sortGlobEntries(entries []GlobEntry, by GlobSortBy, descending bool) error {
+	var less func(a, b GlobEntry) bool
+	switch by {
+	case "", GlobSortByName:
+		less = func(a, b GlobEntry) bool { return a.Path < b.Path }
+	case GlobSortByMtime:
+		less = func(a, b GlobEntry) bool { return a.ModTime.Before(b.ModTime) }
+	case GlobSortBySize:
+		less = func(a, b GlobEntry) bool { return a.Size < b.Size }
+	default:
+		return fmt.Errorf("unsupported glob sort key: %s", by)
 	}
 
-	return files, nil
+	sort.SliceStable(entries, func(i, j int) bool {
+		if descending {
+			return less(entries[j], entries[i])
+		}
+		return less(entries[i], entries[j])
+	})
+	return nil
+}
+
+// RenderTree formats files as an indented directory tree, suitable for returning to a model.
+// FileInfo carries no size, so only the structure is rendered; see RenderGlobTree for sizes.
+func RenderTree(files []filesystem.FileInfo) string {
+	entries := make([]filetree.Entry, 0, len(files))
+	for _, f := range files {
+		entries = append(entries, filetree.Entry{Path: f.Path, Size: -1})
+	}
+	return filetree.Render(entries)
+}
+
+// RenderGlobTree formats GlobSorted results as an indented directory tree with file sizes.
+func RenderGlobTree(files []GlobEntry) string {
+	entries := make([]filetree.Entry, 0, len(files))
+	for _, f := range files {
+		entries = append(entries, filetree.Entry{Path: f.Path, Size: f.Size, IsDir: f.IsDir})
+	}
+	return filetree.Render(entries)
 }
 
 func globToRegex(pattern string) (*regexp.Regexp, error) {
@@ -337,20 +724,27 @@ func globToRegex(pattern string) (*regexp.Regexp, error) {
 	return regexp.Compile(pattern)
 }
 
-func (s *backend) Write(ctx context.Context, req *filesystem.WriteRequest) error {
-	if !filepath.IsAbs(req.FilePath) {
-		return fmt.Errorf("path must be an absolute path: %s", req.FilePath)
+func (s *Backend) Write(ctx context.Context, req *filesystem.WriteRequest) (err error) {
+	defer func() { s.audit(ctx, "Write", req, err) }()
+
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	path, err := s.validatePath(req.FilePath, "", false)
+	if err != nil {
+		return err
 	}
 
-	parentDir := filepath.Dir(req.FilePath)
+	parentDir := filepath.Dir(path)
 	if err := os.MkdirAll(parentDir, 0755); err != nil {
 		return fmt.Errorf("failed to create parent directory: %w", err)
 	}
 
-	file, err := os.OpenFile(req.FilePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
 	if err != nil {
 		if os.IsExist(err) {
-			return fmt.Errorf("file '%s' already exists", req.FilePath)
+			return fmt.Errorf("file '%s' already exists", path)
 		}
 		return fmt.Errorf("failed to open file for writing: %w", err)
 	}
@@ -364,18 +758,225 @@ func (s *backend) Write(ctx context.Context, req *filesystem.WriteRequest) error
 	return nil
 }
 
-func (s *backend) Edit(ctx context.Context, req *filesystem.EditRequest) error {
-	path := filepath.Clean(req.FilePath)
-	if !filepath.IsAbs(path) {
-		return fmt.Errorf("path must be an absolute path: %s", path)
+// Move renames req.Src to req.Dst, creating the parent directories of Dst as needed.
+// When the two paths are on different devices the entry is copied and the source removed.
+// It fails if Src does not exist, if either path is inside the other, or if Dst exists and
+// req.Overwrite is not set. An existing Dst is set aside and restored if the move fails.
+func (s *Backend) Move(ctx context.Context, req *MoveRequest) (err error) {
+	defer func() { s.audit(ctx, "Move", req, err) }()
+
+	if s.readOnly {
+		return ErrReadOnly
 	}
 
-	if req.OldString == "" {
-		return fmt.Errorf("old string is required")
+	src, err := s.validatePath(req.Src, "", false)
+	if err != nil {
+		return err
+	}
+	dst, err := s.validatePath(req.Dst, "", false)
+	if err != nil {
+		return err
+	}
+	if src == dst {
+		return fmt.Errorf("source and destination are the same path '%s'", src)
+	}
+	if pathutil.IsDescendant(src, dst) || pathutil.IsDescendant(dst, src) {
+		return fmt.Errorf("cannot move '%s' to '%s': one path is inside the other", src, dst)
 	}
 
-	if req.OldString == req.NewString {
-		return fmt.Errorf("new string must be different from old string")
+	if _, err := os.Lstat(src); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("source '%s' does not exist", src)
+		}
+		return fmt.Errorf("failed to stat source: %w", err)
+	}
+
+	restore, discard := func() {}, func() {}
+	_, err = os.Lstat(dst)
+	switch {
+	case err == nil && !req.Overwrite:
+		return fmt.Errorf("destination '%s' already exists", dst)
+	case err == nil:
+		// rename cannot replace a non-empty directory, and a copy across devices would truncate a file
+		// before it succeeds, so keep the old destination aside until the move succeeds
+		aside, err := os.MkdirTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".move-")
+		if err != nil {
+			return fmt.Errorf("failed to set aside existing destination: %w", err)
+		}
+		old := filepath.Join(aside, filepath.Base(dst))
+		if err := os.Rename(dst, old); err != nil {
+			_ = os.Remove(aside)
+			return fmt.Errorf("failed to set aside existing destination: %w", err)
+		}
+		restore = func() {
+			_ = os.Rename(old, dst)
+			_ = os.RemoveAll(aside)
+		}
+		discard = func() { _ = os.RemoveAll(aside) }
+	case err != nil && !os.IsNotExist(err):
+		return fmt.Errorf("failed to stat destination: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		restore()
+		return fmt.Errorf("failed to create parent directory: %w", err)
+	}
+
+	err = rename(src, dst)
+	if errors.Is(err, syscall.EXDEV) {
+		if err := copyAcrossDevices(src, dst); err != nil {
+			_ = os.RemoveAll(dst)
+			restore()
+			return fmt.Errorf("failed to copy across devices: %w", err)
+		}
+		discard()
+		if err := os.RemoveAll(src); err != nil {
+			return fmt.Errorf("failed to remove source after copy: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		restore()
+		return fmt.Errorf("failed to move: %w", err)
+	}
+	discard()
+
+	return nil
+}
+
+// rename and copyAcrossDevices are replaced in tests to simulate moves across devices.
+var (
+	rename            = os.Rename
+	copyAcrossDevices = copyPath
+)
+
+// copyPath recursively copies src to dst, preserving permissions and symbolic links.
+func copyPath(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, dst)
+	case info.IsDir():
+		if err := os.MkdirAll(dst, info.Mode().Perm()); err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(src)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := copyPath(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		in, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	}
+}
+
+func (s *Backend) Edit(ctx context.Context, req *filesystem.EditRequest) (err error) {
+	defer func() { s.audit(ctx, "Edit", req, err) }()
+
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	path, err := s.validatePath(req.FilePath, "", false)
+	if err != nil {
+		return err
+	}
+
+	if err := validateEdit(req.OldString, req.NewString); err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	newText, err := replaceString(string(content), req.OldString, req.NewString, req.ReplaceAll)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(newText), 0644)
+}
+
+// DryRunEdit validates req exactly like Edit and returns the resulting content and a unified diff,
+// without writing the file. It is allowed in read-only mode since nothing is modified.
+func (s *Backend) DryRunEdit(ctx context.Context, req *filesystem.EditRequest) (_ *EditPreview, err error) {
+	defer func() { s.audit(ctx, "DryRunEdit", req, err) }()
+
+	path, err := s.validatePath(req.FilePath, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateEdit(req.OldString, req.NewString); err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	newText, err := replaceString(string(content), req.OldString, req.NewString, req.ReplaceAll)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EditPreview{
+		NewContent: newText,
+		Diff:       unifiedDiff(path, string(content), newText),
+	}, nil
+}
+
+// MultiEdit applies an ordered list of replacements to a single file atomically:
+// each edit sees the result of the previous ones, and the file is only written if every edit succeeds.
+func (s *Backend) MultiEdit(ctx context.Context, req *MultiEditRequest) (err error) {
+	defer func() { s.audit(ctx, "MultiEdit", req, err) }()
+
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	path, err := s.validatePath(req.FilePath, "", false)
+	if err != nil {
+		return err
+	}
+
+	if len(req.Edits) == 0 {
+		return fmt.Errorf("at least one edit is required")
+	}
+	for i, edit := range req.Edits {
+		if err := validateEdit(edit.OldString, edit.NewString); err != nil {
+			return fmt.Errorf("edit %d: %w", i, err)
+		}
 	}
 
 	content, err := os.ReadFile(path)
@@ -384,26 +985,147 @@ func (s *backend) Edit(ctx context.Context, req *filesystem.EditRequest) error {
 	}
 
 	text := string(content)
-	count := strings.Count(text, req.OldString)
+	for i, edit := range req.Edits {
+		text, err = replaceString(text, edit.OldString, edit.NewString, edit.ReplaceAll)
+		if err != nil {
+			return fmt.Errorf("edit %d: %w", i, err)
+		}
+	}
+
+	return os.WriteFile(path, []byte(text), 0644)
+}
+
+func validateEdit(oldString, newString string) error {
+	if oldString == "" {
+		return fmt.Errorf("old string is required")
+	}
+
+	if oldString == newString {
+		return fmt.Errorf("new string must be different from old string")
+	}
+
+	return nil
+}
+
+// replaceString replaces oldString in text, requiring it to be unique unless replaceAll is set.
+func replaceString(text, oldString, newString string, replaceAll bool) (string, error) {
+	count := strings.Count(text, oldString)
 
 	if count == 0 {
-		return fmt.Errorf("string not found in file: '%s'", req.OldString)
+		return "", fmt.Errorf("string not found in file: '%s'", oldString)
 	}
-	if count > 1 && !req.ReplaceAll {
-		return fmt.Errorf("string '%s' appears multiple times. Use replace_all=True to replace all occurrences", req.OldString)
+	if count > 1 && !replaceAll {
+		return "", fmt.Errorf("string '%s' appears multiple times. Use replace_all=True to replace all occurrences", oldString)
 	}
 
-	var newText string
-	if req.ReplaceAll {
-		newText = strings.Replace(text, req.OldString, req.NewString, -1)
-	} else {
-		newText = strings.Replace(text, req.OldString, req.NewString, 1)
+	if replaceAll {
+		return strings.Replace(text, oldString, newString, -1), nil
 	}
+	return strings.Replace(text, oldString, newString, 1), nil
+}
 
-	return os.WriteFile(path, []byte(newText), 0644)
+// WatchStream is the stream of changes returned by Watch. Closing it stops the polling.
+type WatchStream struct {
+	*schema.StreamReader[filesystem.FileInfo]
+
+	once    sync.Once
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// Close stops the polling and closes the underlying stream.
+func (ws *WatchStream) Close() {
+	ws.once.Do(func() { close(ws.done) })
+	ws.StreamReader.Close()
+}
+
+// Watch polls path every interval and emits a FileInfo each time its size, modification time or existence
+// changes, until ctx is cancelled or the returned stream is closed. The stream ends with io.EOF on cancellation.
+// A zero or negative interval defaults to one second. The path does not need to exist yet.
+func (s *Backend) Watch(ctx context.Context, path string, interval time.Duration) (_ *WatchStream, err error) {
+	defer func() { s.audit(ctx, "Watch", path, err) }()
+
+	path, err = s.validatePath(path, "", false)
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	last, err := statForWatch(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, w := schema.Pipe[filesystem.FileInfo](1)
+	ws := &WatchStream{StreamReader: sr, done: make(chan struct{}), stopped: make(chan struct{})}
+
+	go func() {
+		defer close(ws.stopped)
+		defer func() {
+			if pe := recover(); pe != nil {
+				w.Send(filesystem.FileInfo{}, newPanicErr(pe, debug.Stack()))
+			}
+			w.Close()
+		}()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ws.done:
+				return
+			case <-ticker.C:
+			}
+
+			cur, err := statForWatch(path)
+			if err != nil {
+				w.Send(filesystem.FileInfo{}, err)
+				return
+			}
+			if cur == last {
+				continue
+			}
+			last = cur
+
+			if closed := w.Send(filesystem.FileInfo{Path: path}, nil); closed {
+				return
+			}
+		}
+	}()
+
+	return ws, nil
+}
+
+// watchState is the part of a file's metadata that Watch compares between polls.
+type watchState struct {
+	exists  bool
+	size    int64
+	modTime int64
 }
 
-func (s *backend) ExecuteStreaming(ctx context.Context, input *filesystem.ExecuteRequest) (result *schema.StreamReader[*filesystem.ExecuteResponse], err error) {
+func statForWatch(path string) (watchState, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return watchState{}, nil
+		}
+		return watchState{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+	return watchState{exists: true, size: info.Size(), modTime: info.ModTime().UnixNano()}, nil
+}
+
+func (s *Backend) ExecuteStreaming(ctx context.Context, input *filesystem.ExecuteRequest) (result *schema.StreamReader[*filesystem.ExecuteResponse], err error) {
+	defer func() { s.audit(ctx, "ExecuteStreaming", input, err) }()
+
+	if s.readOnly {
+		return nil, ErrReadOnly
+	}
+
 	if input.Command == "" {
 		return nil, fmt.Errorf("command is required")
 	}
@@ -511,6 +1233,67 @@ func (s *backend) ExecuteStreaming(ctx context.Context, input *filesystem.Execut
 	return sr, nil
 }
 
+// validatePath cleans and checks a request path, jailing it to the configured root directory if any.
+// pathutil.ValidatePath only checks the path lexically, so the symlinks of the path and of the root
+// are resolved as well, so that a link inside the root cannot lead outside of it.
+func (s *Backend) validatePath(path, defaultPath string, allowRelative bool) (string, error) {
+	path, err := pathutil.ValidatePath(path, pathutil.Options{
+		DefaultPath:   defaultPath,
+		AllowRelative: allowRelative,
+		Root:          s.rootDir,
+	})
+	if err != nil || s.rootDir == "" {
+		return path, err
+	}
+
+	root, err := resolveSymlinks(filepath.Clean(s.rootDir))
+	if err != nil {
+		return "", err
+	}
+	resolved, err := resolveSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err = pathutil.ValidatePath(resolved, pathutil.Options{Root: root}); err != nil {
+		return "", fmt.Errorf("%s resolves to %s: %w", path, resolved, err)
+	}
+
+	return path, nil
+}
+
+// resolveSymlinks resolves the symlinks of the longest existing prefix of the absolute path,
+// keeping the elements that do not exist yet, e.g. the file about to be written, as they are.
+func resolveSymlinks(path string) (string, error) {
+	rest := ""
+	for p := path; ; p = filepath.Dir(p) {
+		resolved, err := filepath.EvalSymlinks(p)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		// an existing entry that cannot be resolved is a dangling or looping symlink, whose target is unknown
+		if _, lerr := os.Lstat(p); lerr == nil {
+			return "", fmt.Errorf("failed to resolve symlinks of %s: %w", path, err)
+		}
+		if filepath.Dir(p) == p {
+			return path, nil
+		}
+		rest = filepath.Join(filepath.Base(p), rest)
+	}
+}
+
+// audit reports an operation to the configured AuditFunc, recovering from any panic it raises.
+func (s *Backend) audit(ctx context.Context, op string, req any, err error) {
+	if s.auditFunc == nil {
+		return
+	}
+	defer func() {
+		if pe := recover(); pe != nil {
+			log.Printf("audit func panicked on %s: %v", op, pe)
+		}
+	}()
+	s.auditFunc(ctx, op, req, err)
+}
+
 type panicErr struct {
 	info  any
 	stack []byte
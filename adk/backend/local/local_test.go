@@ -18,15 +18,21 @@ package local
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino/adk/filesystem"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/adk/backend/internal/pathutil"
 )
 
 func setupTestDir(t *testing.T) string {
@@ -138,6 +144,152 @@ func TestRead(t *testing.T) {
 	})
 }
 
+func TestReadWithInfo(t *testing.T) {
+	ctx := context.Background()
+
+	dir := setupTestDir(t)
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "large.txt")
+
+	f, err := os.Create(filePath)
+	assert.NoError(t, err)
+	for i := 0; i < 1000; i++ {
+		f.WriteString(fmt.Sprintf("line %d\n", i))
+	}
+	f.Close()
+
+	b, err := NewBackend(ctx, &Config{})
+	assert.NoError(t, err)
+
+	t.Run("window in the middle", func(t *testing.T) {
+		res, err := b.ReadWithInfo(ctx, &filesystem.ReadRequest{FilePath: filePath, Offset: 500, Limit: 5})
+		assert.NoError(t, err)
+		assert.Equal(t, 1000, res.TotalLines)
+		assert.True(t, res.Truncated)
+
+		lines := strings.Split(strings.TrimSpace(res.Content), "\n")
+		assert.Len(t, lines, 5)
+		assert.Contains(t, lines[0], "line 500")
+		assert.Contains(t, lines[4], "line 504")
+	})
+
+	t.Run("window reaches end of file", func(t *testing.T) {
+		res, err := b.ReadWithInfo(ctx, &filesystem.ReadRequest{FilePath: filePath, Offset: 995, Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, 1000, res.TotalLines)
+		assert.False(t, res.Truncated)
+		assert.Len(t, strings.Split(strings.TrimSpace(res.Content), "\n"), 5)
+	})
+
+	t.Run("offset past end of file", func(t *testing.T) {
+		res, err := b.ReadWithInfo(ctx, &filesystem.ReadRequest{FilePath: filePath, Offset: 2000})
+		assert.NoError(t, err)
+		assert.Equal(t, 1000, res.TotalLines)
+		assert.False(t, res.Truncated)
+		assert.Empty(t, res.Content)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		emptyPath := filepath.Join(dir, "empty.txt")
+		assert.NoError(t, os.WriteFile(emptyPath, []byte(""), 0644))
+		res, err := b.ReadWithInfo(ctx, &filesystem.ReadRequest{FilePath: emptyPath})
+		assert.NoError(t, err)
+		assert.Equal(t, &ReadResult{}, res)
+	})
+
+	t.Run("non-existent file", func(t *testing.T) {
+		_, err := b.ReadWithInfo(ctx, &filesystem.ReadRequest{FilePath: filepath.Join(dir, "missing.txt")})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "file not found")
+	})
+
+	t.Run("capped by MaxReadBytes", func(t *testing.T) {
+		b, err := NewBackend(ctx, &Config{MaxReadBytes: 40})
+		assert.NoError(t, err)
+
+		// Each numbered line is 14 bytes ("     1\tline 0\n"), so only two fit in 40 bytes.
+		res, err := b.ReadWithInfo(ctx, &filesystem.ReadRequest{FilePath: filePath, Limit: 5})
+		assert.NoError(t, err)
+		assert.Equal(t, 1000, res.TotalLines)
+		assert.True(t, res.Truncated)
+		assert.Equal(t, "     1\tline 0\n     2\tline 1\n", res.Content)
+	})
+}
+
+func TestHash(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewBackend(ctx, &Config{})
+	assert.NoError(t, err)
+
+	dir := setupTestDir(t)
+	defer os.RemoveAll(dir)
+	fileA := filepath.Join(dir, "a.txt")
+	fileB := filepath.Join(dir, "b.txt")
+	assert.NoError(t, os.WriteFile(fileA, []byte("hello world"), 0644))
+	assert.NoError(t, os.WriteFile(fileB, []byte("hello world"), 0644))
+
+	hashA, err := b.Hash(ctx, fileA)
+	assert.NoError(t, err)
+	assert.Equal(t, "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", hashA)
+
+	hashB, err := b.Hash(ctx, fileB)
+	assert.NoError(t, err)
+	assert.Equal(t, hashA, hashB)
+
+	assert.NoError(t, b.Edit(ctx, &filesystem.EditRequest{FilePath: fileB, OldString: "world", NewString: "there"}))
+	hashB, err = b.Hash(ctx, fileB)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hashA, hashB)
+
+	_, err = b.Hash(ctx, filepath.Join(dir, "missing.txt"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "file not found")
+}
+
+func TestStat(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewBackend(ctx, &Config{})
+	assert.NoError(t, err)
+
+	dir := setupTestDir(t)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "a.txt")
+	assert.NoError(t, os.WriteFile(file, []byte("hello"), 0640))
+	mtime := time.Unix(1700000000, 0)
+	assert.NoError(t, os.Chtimes(file, mtime, mtime))
+
+	t.Run("file", func(t *testing.T) {
+		st, err := b.Stat(ctx, file)
+		assert.NoError(t, err)
+		assert.Equal(t, file, st.Path)
+		assert.Equal(t, int64(5), st.Size)
+		assert.True(t, st.ModTime.Equal(mtime))
+		assert.False(t, st.IsDir)
+		assert.Equal(t, os.FileMode(0640), st.Mode)
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		st, err := b.Stat(ctx, dir)
+		assert.NoError(t, err)
+		assert.Equal(t, dir, st.Path)
+		assert.True(t, st.IsDir)
+		assert.True(t, st.Mode.IsDir())
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		missing := filepath.Join(dir, "missing.txt")
+		_, err := b.Stat(ctx, missing)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.EqualError(t, err, "file not found: "+missing)
+	})
+
+	t.Run("relative path", func(t *testing.T) {
+		_, err := b.Stat(ctx, "a.txt")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNotFound)
+	})
+}
+
 func TestWrite(t *testing.T) {
 	ctx := context.Background()
 	s, err := NewBackend(ctx, &Config{})
@@ -170,6 +322,167 @@ func TestWrite(t *testing.T) {
 	})
 }
 
+func TestMove(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewBackend(ctx, &Config{})
+	assert.NoError(t, err)
+
+	t.Run("rename in the same directory", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		src := filepath.Join(dir, "old.txt")
+		dst := filepath.Join(dir, "new.txt")
+		assert.NoError(t, os.WriteFile(src, []byte("hello"), 0644))
+
+		assert.NoError(t, s.Move(ctx, &MoveRequest{Src: src, Dst: dst}))
+		_, err := os.Stat(src)
+		assert.True(t, os.IsNotExist(err))
+		content, err := os.ReadFile(dst)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(content))
+	})
+
+	t.Run("move into another directory", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		src := filepath.Join(dir, "a", "file.txt")
+		dst := filepath.Join(dir, "b", "c", "file.txt")
+		assert.NoError(t, os.MkdirAll(filepath.Dir(src), 0755))
+		assert.NoError(t, os.WriteFile(src, []byte("hello"), 0644))
+
+		assert.NoError(t, s.Move(ctx, &MoveRequest{Src: src, Dst: dst}))
+		content, err := os.ReadFile(dst)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(content))
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+
+		err := s.Move(ctx, &MoveRequest{Src: filepath.Join(dir, "missing.txt"), Dst: filepath.Join(dir, "new.txt")})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "does not exist")
+	})
+
+	t.Run("existing destination", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		src := filepath.Join(dir, "src.txt")
+		dst := filepath.Join(dir, "dst.txt")
+		assert.NoError(t, os.WriteFile(src, []byte("new"), 0644))
+		assert.NoError(t, os.WriteFile(dst, []byte("old"), 0644))
+
+		err := s.Move(ctx, &MoveRequest{Src: src, Dst: dst})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+
+		assert.NoError(t, s.Move(ctx, &MoveRequest{Src: src, Dst: dst, Overwrite: true}))
+		content, err := os.ReadFile(dst)
+		assert.NoError(t, err)
+		assert.Equal(t, "new", string(content))
+	})
+
+	t.Run("overwrite directory", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		src := filepath.Join(dir, "src")
+		dst := filepath.Join(dir, "dst")
+		assert.NoError(t, os.MkdirAll(src, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(src, "new.txt"), []byte("new"), 0644))
+		assert.NoError(t, os.MkdirAll(dst, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dst, "old.txt"), []byte("old"), 0644))
+
+		assert.NoError(t, s.Move(ctx, &MoveRequest{Src: src, Dst: dst, Overwrite: true}))
+		content, err := os.ReadFile(filepath.Join(dst, "new.txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, "new", string(content))
+		_, err = os.Stat(filepath.Join(dst, "old.txt"))
+		assert.True(t, os.IsNotExist(err))
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("nested paths", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		parent := filepath.Join(dir, "parent")
+		child := filepath.Join(parent, "child")
+		assert.NoError(t, os.MkdirAll(child, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(child, "file.txt"), []byte("hello"), 0644))
+
+		err := s.Move(ctx, &MoveRequest{Src: child, Dst: parent, Overwrite: true})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "inside the other")
+		err = s.Move(ctx, &MoveRequest{Src: parent, Dst: filepath.Join(child, "moved"), Overwrite: true})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "inside the other")
+
+		content, err := os.ReadFile(filepath.Join(child, "file.txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(content))
+	})
+
+	t.Run("relative path", func(t *testing.T) {
+		err := s.Move(ctx, &MoveRequest{Src: "a.txt", Dst: "/tmp/b.txt"})
+		assert.Error(t, err)
+	})
+
+	t.Run("copy fallback", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		src := filepath.Join(dir, "src")
+		assert.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(src, "sub", "file.txt"), []byte("hello"), 0600))
+		assert.NoError(t, os.Symlink("sub/file.txt", filepath.Join(src, "link")))
+
+		dst := filepath.Join(dir, "dst")
+		assert.NoError(t, copyPath(src, dst))
+		content, err := os.ReadFile(filepath.Join(dst, "link"))
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(content))
+		info, err := os.Stat(filepath.Join(dst, "sub", "file.txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("failed copy across devices keeps destination", func(t *testing.T) {
+		defer func(r func(string, string) error, c func(string, string) error) {
+			rename, copyAcrossDevices = r, c
+		}(rename, copyAcrossDevices)
+		rename = func(oldpath, newpath string) error {
+			if filepath.Base(oldpath) == "src.txt" {
+				return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EXDEV}
+			}
+			return os.Rename(oldpath, newpath)
+		}
+		copyAcrossDevices = func(src, dst string) error {
+			_ = os.WriteFile(dst, []byte("partial"), 0644)
+			return errors.New("disk full")
+		}
+
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		src := filepath.Join(dir, "src.txt")
+		dst := filepath.Join(dir, "dst.txt")
+		assert.NoError(t, os.WriteFile(src, []byte("new"), 0644))
+		assert.NoError(t, os.WriteFile(dst, []byte("old"), 0644))
+
+		err := s.Move(ctx, &MoveRequest{Src: src, Dst: dst, Overwrite: true})
+		assert.ErrorContains(t, err, "disk full")
+		content, err := os.ReadFile(dst)
+		assert.NoError(t, err)
+		assert.Equal(t, "old", string(content))
+		content, err = os.ReadFile(src)
+		assert.NoError(t, err)
+		assert.Equal(t, "new", string(content))
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+}
+
 func TestEdit(t *testing.T) {
 	ctx := context.Background()
 	s, err := NewBackend(ctx, &Config{})
@@ -230,6 +543,170 @@ func TestEdit(t *testing.T) {
 	})
 }
 
+func TestDryRunEdit(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewBackend(ctx, &Config{})
+	assert.NoError(t, err)
+
+	t.Run("preview without writing", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		filePath := filepath.Join(dir, "test.txt")
+		var sb strings.Builder
+		for i := 1; i <= 10; i++ {
+			sb.WriteString(fmt.Sprintf("line %d\n", i))
+		}
+		original := sb.String()
+		assert.NoError(t, os.WriteFile(filePath, []byte(original), 0644))
+
+		preview, err := b.DryRunEdit(ctx, &filesystem.EditRequest{FilePath: filePath, OldString: "line 5", NewString: "LINE five"})
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Replace(original, "line 5", "LINE five", 1), preview.NewContent)
+		assert.Equal(t, "--- "+filePath+"\n"+
+			"+++ "+filePath+"\n"+
+			"@@ -2,7 +2,7 @@\n"+
+			" line 2\n"+
+			" line 3\n"+
+			" line 4\n"+
+			"-line 5\n"+
+			"+LINE five\n"+
+			" line 6\n"+
+			" line 7\n"+
+			" line 8\n", preview.Diff)
+
+		content, err := os.ReadFile(filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, original, string(content))
+	})
+
+	t.Run("allowed in read-only mode", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		filePath := filepath.Join(dir, "test.txt")
+		assert.NoError(t, os.WriteFile(filePath, []byte("hello world"), 0644))
+
+		b, err := NewBackend(ctx, &Config{ReadOnly: true})
+		assert.NoError(t, err)
+		preview, err := b.DryRunEdit(ctx, &filesystem.EditRequest{FilePath: filePath, OldString: "world", NewString: "go"})
+		assert.NoError(t, err)
+		assert.Equal(t, "hello go", preview.NewContent)
+		assert.Equal(t, "--- "+filePath+"\n+++ "+filePath+"\n@@ -1 +1 @@\n-hello world\n+hello go\n", preview.Diff)
+	})
+
+	t.Run("validation errors match Edit", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		filePath := filepath.Join(dir, "test.txt")
+		assert.NoError(t, os.WriteFile(filePath, []byte("hello world, beautiful world"), 0644))
+
+		_, err := b.DryRunEdit(ctx, &filesystem.EditRequest{FilePath: filePath, OldString: "world", NewString: "go"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "appears multiple times")
+	})
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "insert into empty file",
+			old:  "",
+			new:  "a\n",
+			want: "--- /f\n+++ /f\n@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			name: "delete line",
+			old:  "a\nb\nc\n",
+			new:  "a\nc\n",
+			want: "--- /f\n+++ /f\n@@ -1,3 +1,2 @@\n a\n-b\n c\n",
+		},
+		{
+			name: "separate hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			new:  "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			want: "--- /f\n+++ /f\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+		{
+			name: "no change",
+			old:  "a\n",
+			new:  "a\n",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unifiedDiff("/f", tt.old, tt.new))
+		})
+	}
+}
+
+func TestMultiEdit(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewBackend(ctx, &Config{})
+	assert.NoError(t, err)
+
+	t.Run("apply edits in order", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		filePath := filepath.Join(dir, "test.txt")
+		assert.NoError(t, os.WriteFile(filePath, []byte("hello world, beautiful world"), 0644))
+
+		err := b.MultiEdit(ctx, &MultiEditRequest{
+			FilePath: filePath,
+			Edits: []EditOperation{
+				{OldString: "hello", NewString: "goodbye"},
+				{OldString: "world", NewString: "go", ReplaceAll: true},
+				{OldString: "goodbye go", NewString: "hi go"},
+			},
+		})
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "hi go, beautiful go", string(content))
+	})
+
+	t.Run("failing edit rolls back all", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		filePath := filepath.Join(dir, "test.txt")
+		assert.NoError(t, os.WriteFile(filePath, []byte("hello world, beautiful world"), 0644))
+
+		err := b.MultiEdit(ctx, &MultiEditRequest{
+			FilePath: filePath,
+			Edits: []EditOperation{
+				{OldString: "hello", NewString: "goodbye"},
+				{OldString: "world", NewString: "go"},
+			},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "edit 1")
+		assert.Contains(t, err.Error(), "appears multiple times")
+
+		content, err := os.ReadFile(filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world, beautiful world", string(content))
+	})
+
+	t.Run("invalid edit", func(t *testing.T) {
+		err := b.MultiEdit(ctx, &MultiEditRequest{
+			FilePath: "/tmp/test.txt",
+			Edits:    []EditOperation{{OldString: "same", NewString: "same"}},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "edit 0: new string must be different from old string")
+	})
+
+	t.Run("no edits", func(t *testing.T) {
+		err := b.MultiEdit(ctx, &MultiEditRequest{FilePath: "/tmp/test.txt"})
+		assert.Error(t, err)
+	})
+}
+
 func TestGrepRaw(t *testing.T) {
 	ctx := context.Background()
 	s, err := NewBackend(ctx, &Config{})
@@ -276,6 +753,56 @@ func TestGrepRaw(t *testing.T) {
 	})
 }
 
+func TestGrepPaged(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewBackend(ctx, &Config{})
+	assert.NoError(t, err)
+
+	dir := setupTestDir(t)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("go\nx\ngo\ngo"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("go\ngo"), 0644))
+
+	type pos struct {
+		file string
+		line int
+	}
+	positions := func(matches []filesystem.GrepMatch) []pos {
+		var ret []pos
+		for _, m := range matches {
+			ret = append(ret, pos{filepath.Base(m.Path), m.Line})
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name       string
+		offset     int
+		maxMatches int
+		want       []pos
+		hasMore    bool
+	}{
+		{name: "no cap", want: []pos{{"a.txt", 1}, {"a.txt", 3}, {"a.txt", 4}, {"b.txt", 1}, {"b.txt", 2}}},
+		{name: "first page", maxMatches: 2, want: []pos{{"a.txt", 1}, {"a.txt", 3}}, hasMore: true},
+		{name: "page across files", offset: 2, maxMatches: 2, want: []pos{{"a.txt", 4}, {"b.txt", 1}}, hasMore: true},
+		{name: "last full page", offset: 3, maxMatches: 2, want: []pos{{"b.txt", 1}, {"b.txt", 2}}},
+		{name: "past the end", offset: 10, maxMatches: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := b.GrepPaged(ctx, &PagedGrepRequest{
+				GrepRequest: filesystem.GrepRequest{Path: dir, Pattern: "go"},
+				Offset:      tt.offset,
+				MaxMatches:  tt.maxMatches,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, positions(page.Matches))
+			assert.Equal(t, tt.hasMore, page.HasMore)
+		})
+	}
+}
+
 func TestGlobInfo(t *testing.T) {
 	ctx := context.Background()
 	s, err := NewBackend(ctx, &Config{})
@@ -370,6 +897,114 @@ func TestGlobInfo(t *testing.T) {
 	})
 }
 
+func TestGlobSorted(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewBackend(ctx, &Config{})
+	assert.NoError(t, err)
+
+	dir := setupTestDir(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	files := []struct {
+		name    string
+		content string
+		age     time.Duration
+	}{
+		{"a.txt", "aaaaa", 2 * time.Hour},
+		{"b.txt", "b", 0},
+		{"c.txt", "ccc", time.Hour},
+	}
+	for _, f := range files {
+		p := filepath.Join(dir, f.name)
+		assert.NoError(t, os.WriteFile(p, []byte(f.content), 0644))
+		assert.NoError(t, os.Chtimes(p, now.Add(-f.age), now.Add(-f.age)))
+	}
+
+	paths := func(entries []GlobEntry) []string {
+		var result []string
+		for _, e := range entries {
+			result = append(result, e.Path)
+		}
+		return result
+	}
+
+	t.Run("mtime descending", func(t *testing.T) {
+		entries, err := b.GlobSorted(ctx, &SortedGlobRequest{
+			GlobInfoRequest: filesystem.GlobInfoRequest{Path: dir, Pattern: "*.txt"},
+			SortBy:          GlobSortByMtime,
+			Descending:      true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b.txt", "c.txt", "a.txt"}, paths(entries))
+		assert.True(t, entries[0].ModTime.After(entries[1].ModTime))
+	})
+
+	t.Run("size ascending", func(t *testing.T) {
+		entries, err := b.GlobSorted(ctx, &SortedGlobRequest{
+			GlobInfoRequest: filesystem.GlobInfoRequest{Path: dir, Pattern: "*.txt"},
+			SortBy:          GlobSortBySize,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b.txt", "c.txt", "a.txt"}, paths(entries))
+		assert.Equal(t, int64(1), entries[0].Size)
+	})
+
+	t.Run("default name order", func(t *testing.T) {
+		entries, err := b.GlobSorted(ctx, &SortedGlobRequest{
+			GlobInfoRequest: filesystem.GlobInfoRequest{Path: dir, Pattern: "*.txt"},
+			Descending:      true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c.txt", "b.txt", "a.txt"}, paths(entries))
+	})
+
+	t.Run("unsupported sort key", func(t *testing.T) {
+		_, err := b.GlobSorted(ctx, &SortedGlobRequest{
+			GlobInfoRequest: filesystem.GlobInfoRequest{Path: dir, Pattern: "*.txt"},
+			SortBy:          "owner",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported glob sort key")
+	})
+}
+
+func TestRenderTree(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewBackend(ctx, &Config{})
+	assert.NoError(t, err)
+
+	dir := setupTestDir(t)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "util"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "empty"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "main.go"), make([]byte, 1536), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "util", "util.go"), []byte("package util\n"), 0644))
+
+	t.Run("with sizes", func(t *testing.T) {
+		entries, err := b.GlobSorted(ctx, &SortedGlobRequest{
+			GlobInfoRequest: filesystem.GlobInfoRequest{Path: dir, Pattern: "**"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "empty/\n"+
+			"src/\n"+
+			"  util/\n"+
+			"    util.go (13 B)\n"+
+			"  main.go (1.5 KB)\n"+
+			"README.md (5 B)\n", RenderGlobTree(entries))
+	})
+
+	t.Run("without sizes", func(t *testing.T) {
+		files, err := b.GlobInfo(ctx, &filesystem.GlobInfoRequest{Path: dir, Pattern: "**/*.go"})
+		assert.NoError(t, err)
+		assert.Equal(t, "src/\n"+
+			"  util/\n"+
+			"    util.go\n"+
+			"  main.go\n", RenderTree(files))
+	})
+}
+
 func TestPathCleaning(t *testing.T) {
 	ctx := context.Background()
 	s, err := NewBackend(ctx, &Config{})
@@ -458,6 +1093,262 @@ func TestPathCleaning(t *testing.T) {
 	})
 }
 
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewBackend(ctx, &Config{ReadOnly: true})
+	assert.NoError(t, err)
+
+	dir := setupTestDir(t)
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "test.txt")
+	assert.NoError(t, os.WriteFile(filePath, []byte("hello world"), 0644))
+
+	t.Run("reads succeed", func(t *testing.T) {
+		files, err := s.LsInfo(ctx, &filesystem.LsInfoRequest{Path: dir})
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+
+		content, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: filePath})
+		assert.NoError(t, err)
+		assert.Contains(t, content, "hello world")
+
+		matches, err := s.GrepRaw(ctx, &filesystem.GrepRequest{Path: dir, Pattern: "hello"})
+		assert.NoError(t, err)
+		assert.Len(t, matches, 1)
+
+		files, err = s.GlobInfo(ctx, &filesystem.GlobInfoRequest{Path: dir, Pattern: "*.txt"})
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+	})
+
+	t.Run("write rejected", func(t *testing.T) {
+		newPath := filepath.Join(dir, "new.txt")
+		err := s.Write(ctx, &filesystem.WriteRequest{FilePath: newPath, Content: "new"})
+		assert.ErrorIs(t, err, ErrReadOnly)
+		_, statErr := os.Stat(newPath)
+		assert.True(t, os.IsNotExist(statErr))
+	})
+
+	t.Run("edit rejected", func(t *testing.T) {
+		err := s.Edit(ctx, &filesystem.EditRequest{FilePath: filePath, OldString: "world", NewString: "go"})
+		assert.ErrorIs(t, err, ErrReadOnly)
+		content, err := os.ReadFile(filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", string(content))
+	})
+
+	t.Run("execute rejected", func(t *testing.T) {
+		_, err := s.ExecuteStreaming(ctx, &filesystem.ExecuteRequest{Command: "echo hello"})
+		assert.ErrorIs(t, err, ErrReadOnly)
+	})
+}
+
+func TestAuditFunc(t *testing.T) {
+	ctx := context.Background()
+
+	type auditRecord struct {
+		op  string
+		req any
+		err error
+	}
+	var records []auditRecord
+	s, err := NewBackend(ctx, &Config{
+		AuditFunc: func(ctx context.Context, op string, req any, err error) {
+			records = append(records, auditRecord{op: op, req: req, err: err})
+		},
+	})
+	assert.NoError(t, err)
+
+	dir := setupTestDir(t)
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "test.txt")
+
+	writeReq := &filesystem.WriteRequest{FilePath: filePath, Content: "hello"}
+	assert.NoError(t, s.Write(ctx, writeReq))
+	readReq := &filesystem.ReadRequest{FilePath: filePath}
+	_, err = s.Read(ctx, readReq)
+	assert.NoError(t, err)
+	assert.Error(t, s.Write(ctx, writeReq))
+
+	execReq := &filesystem.ExecuteRequest{Command: "echo hello"}
+	sr, err := s.ExecuteStreaming(ctx, execReq)
+	assert.NoError(t, err)
+	sr.Close()
+
+	assert.Len(t, records, 4)
+	assert.Equal(t, "Write", records[0].op)
+	assert.Equal(t, writeReq, records[0].req)
+	assert.NoError(t, records[0].err)
+	assert.Equal(t, "Read", records[1].op)
+	assert.Equal(t, readReq, records[1].req)
+	assert.NoError(t, records[1].err)
+	assert.Equal(t, "Write", records[2].op)
+	assert.Error(t, records[2].err)
+	assert.Equal(t, "ExecuteStreaming", records[3].op)
+	assert.Equal(t, execReq, records[3].req)
+
+	t.Run("panic in audit func does not fail the operation", func(t *testing.T) {
+		s, err := NewBackend(ctx, &Config{
+			AuditFunc: func(ctx context.Context, op string, req any, err error) {
+				panic("audit failure")
+			},
+		})
+		assert.NoError(t, err)
+
+		content, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: filePath})
+		assert.NoError(t, err)
+		assert.Contains(t, content, "hello")
+	})
+}
+
+func TestRootDir(t *testing.T) {
+	ctx := context.Background()
+
+	dir := setupTestDir(t)
+	defer os.RemoveAll(dir)
+	root := filepath.Join(dir, "root")
+	assert.NoError(t, os.Mkdir(root, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "in.txt"), []byte("inside"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "out.txt"), []byte("outside"), 0644))
+
+	s, err := NewBackend(ctx, &Config{RootDir: root})
+	assert.NoError(t, err)
+
+	t.Run("empty path defaults to root", func(t *testing.T) {
+		files, err := s.LsInfo(ctx, &filesystem.LsInfoRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, []filesystem.FileInfo{{Path: "in.txt"}}, files)
+	})
+
+	t.Run("read inside root", func(t *testing.T) {
+		content, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: filepath.Join(root, "in.txt")})
+		assert.NoError(t, err)
+		assert.Contains(t, content, "inside")
+	})
+
+	t.Run("traversal out of root rejected", func(t *testing.T) {
+		_, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: root + "/../out.txt"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "path escapes root directory")
+
+		err = s.Write(ctx, &filesystem.WriteRequest{FilePath: root + "/../new.txt", Content: "x"})
+		assert.Error(t, err)
+		_, statErr := os.Stat(filepath.Join(dir, "new.txt"))
+		assert.True(t, os.IsNotExist(statErr))
+
+		_, err = s.GrepRaw(ctx, &filesystem.GrepRequest{Path: dir, Pattern: "outside"})
+		assert.Error(t, err)
+	})
+
+	t.Run("symlink out of root rejected", func(t *testing.T) {
+		assert.NoError(t, os.Symlink(filepath.Join(dir, "out.txt"), filepath.Join(root, "out-link.txt")))
+		assert.NoError(t, os.Symlink(dir, filepath.Join(root, "out-dir")))
+		defer os.Remove(filepath.Join(root, "out-link.txt"))
+		defer os.Remove(filepath.Join(root, "out-dir"))
+
+		_, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: filepath.Join(root, "out-link.txt")})
+		assert.ErrorIs(t, err, pathutil.ErrOutsideRoot)
+
+		err = s.Write(ctx, &filesystem.WriteRequest{FilePath: filepath.Join(root, "out-dir", "new.txt"), Content: "x"})
+		assert.ErrorIs(t, err, pathutil.ErrOutsideRoot)
+		_, statErr := os.Stat(filepath.Join(dir, "new.txt"))
+		assert.True(t, os.IsNotExist(statErr))
+	})
+
+	t.Run("dangling symlink rejected", func(t *testing.T) {
+		assert.NoError(t, os.Symlink(filepath.Join(dir, "missing.txt"), filepath.Join(root, "dangling.txt")))
+		defer os.Remove(filepath.Join(root, "dangling.txt"))
+
+		err := s.Write(ctx, &filesystem.WriteRequest{FilePath: filepath.Join(root, "dangling.txt"), Content: "x"})
+		assert.Error(t, err)
+		_, statErr := os.Stat(filepath.Join(dir, "missing.txt"))
+		assert.True(t, os.IsNotExist(statErr))
+	})
+
+	t.Run("symlink inside root allowed", func(t *testing.T) {
+		assert.NoError(t, os.Symlink(filepath.Join(root, "in.txt"), filepath.Join(root, "in-link.txt")))
+		defer os.Remove(filepath.Join(root, "in-link.txt"))
+
+		content, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: filepath.Join(root, "in-link.txt")})
+		assert.NoError(t, err)
+		assert.Contains(t, content, "inside")
+	})
+}
+
+func TestWatch(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewBackend(ctx, &Config{})
+	assert.NoError(t, err)
+
+	t.Run("emits on change", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		file := filepath.Join(dir, "build.log")
+		assert.NoError(t, os.WriteFile(file, []byte("start\n"), 0644))
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		sr, err := b.Watch(ctx, file, 10*time.Millisecond)
+		assert.NoError(t, err)
+		defer sr.Close()
+
+		assert.NoError(t, os.WriteFile(file, []byte("start\nmore output\n"), 0644))
+		info, err := sr.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, file, info.Path)
+
+		assert.NoError(t, os.Remove(file))
+		info, err = sr.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, file, info.Path)
+
+		cancel()
+		_, err = sr.Recv()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("file created after watch starts", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		file := filepath.Join(dir, "later.txt")
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		sr, err := b.Watch(ctx, file, 10*time.Millisecond)
+		assert.NoError(t, err)
+		defer sr.Close()
+
+		assert.NoError(t, os.WriteFile(file, []byte("x"), 0644))
+		info, err := sr.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, file, info.Path)
+	})
+
+	t.Run("close stops polling an unchanged file", func(t *testing.T) {
+		dir := setupTestDir(t)
+		defer os.RemoveAll(dir)
+		file := filepath.Join(dir, "idle.txt")
+		assert.NoError(t, os.WriteFile(file, []byte("x"), 0644))
+
+		sr, err := b.Watch(ctx, file, 10*time.Millisecond)
+		assert.NoError(t, err)
+		time.Sleep(30 * time.Millisecond)
+		sr.Close()
+
+		select {
+		case <-sr.stopped:
+		case <-time.After(time.Second):
+			t.Fatal("watch goroutine still polling after the stream was closed")
+		}
+	})
+
+	t.Run("relative path", func(t *testing.T) {
+		_, err := b.Watch(ctx, "build.log", time.Second)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "path must be an absolute path")
+	})
+}
+
 func TestExecuteStreaming(t *testing.T) {
 	ctx := context.Background()
 	s, err := NewBackend(ctx, &Config{})
@@ -465,7 +1356,7 @@ func TestExecuteStreaming(t *testing.T) {
 
 	t.Run("ExecuteStreaming with echo", func(t *testing.T) {
 		req := &filesystem.ExecuteRequest{Command: "echo line1 && echo line2 && echo line3"}
-		sr, err := s.(*backend).ExecuteStreaming(ctx, req)
+		sr, err := s.ExecuteStreaming(ctx, req)
 		assert.NoError(t, err)
 
 		var outputs []string
@@ -487,7 +1378,7 @@ func TestExecuteStreaming(t *testing.T) {
 
 	t.Run("ExecuteStreaming with ping", func(t *testing.T) {
 		req := &filesystem.ExecuteRequest{Command: "ping -c 3 127.0.0.1"}
-		sr, err := s.(*backend).ExecuteStreaming(ctx, req)
+		sr, err := s.ExecuteStreaming(ctx, req)
 		assert.NoError(t, err)
 
 		var lineCount int
@@ -506,7 +1397,7 @@ func TestExecuteStreaming(t *testing.T) {
 
 	t.Run("ExecuteStreaming with seq command", func(t *testing.T) {
 		req := &filesystem.ExecuteRequest{Command: "seq 1 5"}
-		sr, err := s.(*backend).ExecuteStreaming(ctx, req)
+		sr, err := s.ExecuteStreaming(ctx, req)
 		assert.NoError(t, err)
 
 		var numbers []string
@@ -530,7 +1421,7 @@ func TestExecuteStreaming(t *testing.T) {
 		defer cancel()
 
 		req := &filesystem.ExecuteRequest{Command: "seq 1 1000000"}
-		sr, err := s.(*backend).ExecuteStreaming(cancelCtx, req)
+		sr, err := s.ExecuteStreaming(cancelCtx, req)
 		assert.NoError(t, err)
 
 		var lineCount int
@@ -552,7 +1443,7 @@ func TestExecuteStreaming(t *testing.T) {
 
 	t.Run("ExecuteStreaming with command failure", func(t *testing.T) {
 		req := &filesystem.ExecuteRequest{Command: "echo output && exit 1"}
-		sr, err := s.(*backend).ExecuteStreaming(ctx, req)
+		sr, err := s.ExecuteStreaming(ctx, req)
 		assert.NoError(t, err)
 
 		var hasOutput bool
@@ -575,7 +1466,7 @@ func TestExecuteStreaming(t *testing.T) {
 
 	t.Run("ExecuteStreaming with stderr output", func(t *testing.T) {
 		req := &filesystem.ExecuteRequest{Command: "echo stdout && echo stderr >&2 && exit 1"}
-		sr, err := s.(*backend).ExecuteStreaming(ctx, req)
+		sr, err := s.ExecuteStreaming(ctx, req)
 		assert.NoError(t, err)
 
 		var outputs []string
@@ -600,14 +1491,14 @@ func TestExecuteStreaming(t *testing.T) {
 
 	t.Run("ExecuteStreaming with empty command", func(t *testing.T) {
 		req := &filesystem.ExecuteRequest{Command: ""}
-		_, err := s.(*backend).ExecuteStreaming(ctx, req)
+		_, err := s.ExecuteStreaming(ctx, req)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "command is required")
 	})
 
 	t.Run("ExecuteStreaming with large output", func(t *testing.T) {
 		req := &filesystem.ExecuteRequest{Command: "seq 1 100"}
-		sr, err := s.(*backend).ExecuteStreaming(ctx, req)
+		sr, err := s.ExecuteStreaming(ctx, req)
 		assert.NoError(t, err)
 
 		var lineCount int
@@ -626,7 +1517,7 @@ func TestExecuteStreaming(t *testing.T) {
 
 	t.Run("ExecuteStreaming with normal completion", func(t *testing.T) {
 		req := &filesystem.ExecuteRequest{Command: "echo test"}
-		sr, err := s.(*backend).ExecuteStreaming(ctx, req)
+		sr, err := s.ExecuteStreaming(ctx, req)
 		assert.NoError(t, err)
 
 		var receivedOutput bool
@@ -645,7 +1536,7 @@ func TestExecuteStreaming(t *testing.T) {
 
 	t.Run("ExecuteStreaming with invalid command", func(t *testing.T) {
 		req := &filesystem.ExecuteRequest{Command: "/nonexistent/command"}
-		sr, err := s.(*backend).ExecuteStreaming(ctx, req)
+		sr, err := s.ExecuteStreaming(ctx, req)
 		assert.NoError(t, err)
 
 		var lastErr error
@@ -665,7 +1556,7 @@ func TestExecuteStreaming(t *testing.T) {
 
 	t.Run("ExecuteStreaming with no stdout output", func(t *testing.T) {
 		req := &filesystem.ExecuteRequest{Command: "true"}
-		sr, err := s.(*backend).ExecuteStreaming(ctx, req)
+		sr, err := s.ExecuteStreaming(ctx, req)
 		assert.NoError(t, err)
 
 		var receivedResponse bool
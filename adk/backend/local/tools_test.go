@@ -0,0 +1,59 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package local
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTools(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewBackend(ctx, &Config{})
+	require.NoError(t, err)
+
+	tools, err := Tools(ctx, s)
+	require.NoError(t, err)
+
+	byName := map[string]tool.InvokableTool{}
+	for _, bt := range tools {
+		info, err := bt.Info(ctx)
+		require.NoError(t, err)
+		doc, err := info.ParamsOneOf.ToJSONSchema()
+		require.NoError(t, err)
+		assert.NotZero(t, doc.Properties.Len(), info.Name)
+		byName[info.Name] = bt.(tool.InvokableTool)
+	}
+	assert.Len(t, byName, 7)
+	assert.Contains(t, byName, "execute")
+
+	dir := setupTestDir(t)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "a.txt")
+
+	_, err = byName["write_file"].InvokableRun(ctx, `{"file_path": "`+file+`", "content": "hello"}`)
+	require.NoError(t, err)
+
+	out, err := byName["read_file"].InvokableRun(ctx, `{"file_path": "`+file+`"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "     1\thello\n", out)
+}
@@ -0,0 +1,461 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/adk/filesystem"
+)
+
+const defaultRootPath = "/"
+
+type Config struct {
+	// Files seeds the backend with initial files, keyed by absolute file path.
+	// Parent directories are created implicitly.
+	// Optional.
+	Files map[string]string
+
+	// Dirs seeds the backend with additional (possibly empty) directories.
+	// Optional.
+	Dirs []string
+
+	// ExecuteFunc handles Execute calls.
+	// The memory backend has no shell, so Execute returns an error when ExecuteFunc is nil.
+	// Optional.
+	ExecuteFunc func(ctx context.Context, input *filesystem.ExecuteRequest) (*filesystem.ExecuteResponse, error)
+}
+
+type node struct {
+	isDir   bool
+	content string
+}
+
+var _ filesystem.Backend = (*Backend)(nil)
+
+// Backend is a filesystem.Backend that keeps every file in memory.
+type Backend struct {
+	mu          sync.RWMutex
+	nodes       map[string]*node
+	executeFunc func(ctx context.Context, input *filesystem.ExecuteRequest) (*filesystem.ExecuteResponse, error)
+}
+
+// NewBackend creates a new in-memory filesystem backend instance.
+//
+// The backend keeps every file in memory and never touches the local filesystem,
+// which makes it suitable for fast and hermetic tests of agents and middlewares.
+// Paths are slash-separated and must be absolute, mirroring the local backend.
+func NewBackend(_ context.Context, cfg *Config) (*Backend, error) {
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+
+	b := &Backend{
+		nodes: map[string]*node{
+			defaultRootPath: {isDir: true},
+		},
+		executeFunc: cfg.ExecuteFunc,
+	}
+
+	for _, dir := range cfg.Dirs {
+		p, err := cleanPath(dir)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.mkdirAll(p); err != nil {
+			return nil, err
+		}
+	}
+
+	filePaths := make([]string, 0, len(cfg.Files))
+	for p := range cfg.Files {
+		filePaths = append(filePaths, p)
+	}
+	sort.Strings(filePaths)
+	for _, fp := range filePaths {
+		p, err := cleanPath(fp)
+		if err != nil {
+			return nil, err
+		}
+		if err := b.mkdirAll(path.Dir(p)); err != nil {
+			return nil, err
+		}
+		if n, ok := b.nodes[p]; ok && n.isDir {
+			return nil, fmt.Errorf("seed file '%s' conflicts with a directory", p)
+		}
+		b.nodes[p] = &node{content: cfg.Files[fp]}
+	}
+
+	return b, nil
+}
+
+func (s *Backend) LsInfo(ctx context.Context, req *filesystem.LsInfoRequest) ([]filesystem.FileInfo, error) {
+	if req.Path == "" {
+		req.Path = defaultRootPath
+	}
+
+	p, err := cleanPath(req.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	n, ok := s.nodes[p]
+	if !ok {
+		return nil, nil
+	}
+	if !n.isDir {
+		return nil, fmt.Errorf("failed to read directory: not a directory: %s", p)
+	}
+
+	var files []filesystem.FileInfo
+	for _, child := range s.children(p) {
+		files = append(files, filesystem.FileInfo{
+			Path: path.Base(child),
+		})
+	}
+
+	return files, nil
+}
+
+func (s *Backend) Read(ctx context.Context, req *filesystem.ReadRequest) (string, error) {
+	p, err := cleanPath(req.FilePath)
+	if err != nil {
+		return "", err
+	}
+
+	// Copy the content under the lock, since Edit rewrites it in place.
+	s.mu.RLock()
+	n, ok := s.nodes[p]
+	var isDir bool
+	var content string
+	if ok {
+		isDir, content = n.isDir, n.content
+	}
+	s.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("file not found: %s", p)
+	}
+	if isDir {
+		return "", fmt.Errorf("failed to read file: is a directory: %s", p)
+	}
+	if content == "" {
+		return "", nil
+	}
+
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 200
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	var result strings.Builder
+	lineIdx := 0
+	linesRead := 0
+
+	for scanner.Scan() {
+		if lineIdx >= offset {
+			result.WriteString(fmt.Sprintf("%6d\t%s\n", lineIdx+1, scanner.Text()))
+			linesRead++
+			if linesRead >= limit {
+				break
+			}
+		}
+		lineIdx++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading file: %w", err)
+	}
+
+	return result.String(), nil
+}
+
+func (s *Backend) GrepRaw(ctx context.Context, req *filesystem.GrepRequest) ([]filesystem.GrepMatch, error) {
+	root := req.Path
+	if root == "" {
+		root = defaultRootPath
+	}
+	root = path.Clean(root)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []filesystem.GrepMatch
+
+	err := s.walk(ctx, root, func(p string, n *node) error {
+		if n.isDir {
+			return nil
+		}
+
+		if req.Glob != "" {
+			matched, err := path.Match(req.Glob, path.Base(p))
+			if err != nil {
+				return err
+			}
+			if !matched {
+				return nil
+			}
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(n.content))
+		lineNumber := 1
+		for scanner.Scan() {
+			if strings.Contains(scanner.Text(), req.Pattern) {
+				matches = append(matches, filesystem.GrepMatch{
+					Path:    p,
+					Line:    lineNumber,
+					Content: scanner.Text(),
+				})
+			}
+			lineNumber++
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to scan file %s: %w", p, err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error during grep operation: %w", err)
+	}
+
+	return matches, nil
+}
+
+func (s *Backend) GlobInfo(ctx context.Context, req *filesystem.GlobInfoRequest) ([]filesystem.FileInfo, error) {
+	if req.Path == "" {
+		req.Path = defaultRootPath
+	}
+	root := path.Clean(req.Path)
+
+	regex, err := globToRegex(req.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob pattern: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []string
+	err = s.walk(ctx, root, func(p string, _ *node) error {
+		if p == root {
+			return nil
+		}
+
+		relPath := strings.TrimPrefix(p, root)
+		relPath = strings.TrimPrefix(relPath, "/")
+
+		if regex.MatchString(relPath) {
+			matches = append(matches, relPath)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	sort.Strings(matches)
+
+	var files []filesystem.FileInfo
+	for _, match := range matches {
+		files = append(files, filesystem.FileInfo{
+			Path: match,
+		})
+	}
+
+	return files, nil
+}
+
+func (s *Backend) Write(ctx context.Context, req *filesystem.WriteRequest) error {
+	p, err := cleanPath(req.FilePath)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mkdirAll(path.Dir(p)); err != nil {
+		return fmt.Errorf("failed to create parent directory: %w", err)
+	}
+
+	if _, ok := s.nodes[p]; ok {
+		return fmt.Errorf("file '%s' already exists", p)
+	}
+
+	s.nodes[p] = &node{content: req.Content}
+
+	return nil
+}
+
+func (s *Backend) Edit(ctx context.Context, req *filesystem.EditRequest) error {
+	p, err := cleanPath(req.FilePath)
+	if err != nil {
+		return err
+	}
+
+	if req.OldString == "" {
+		return fmt.Errorf("old string is required")
+	}
+
+	if req.OldString == req.NewString {
+		return fmt.Errorf("new string must be different from old string")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.nodes[p]
+	if !ok {
+		return fmt.Errorf("failed to read file: file not found: %s", p)
+	}
+	if n.isDir {
+		return fmt.Errorf("failed to read file: is a directory: %s", p)
+	}
+
+	text := n.content
+	count := strings.Count(text, req.OldString)
+
+	if count == 0 {
+		return fmt.Errorf("string not found in file: '%s'", req.OldString)
+	}
+	if count > 1 && !req.ReplaceAll {
+		return fmt.Errorf("string '%s' appears multiple times. Use replace_all=True to replace all occurrences", req.OldString)
+	}
+
+	if req.ReplaceAll {
+		n.content = strings.Replace(text, req.OldString, req.NewString, -1)
+	} else {
+		n.content = strings.Replace(text, req.OldString, req.NewString, 1)
+	}
+
+	return nil
+}
+
+// Execute delegates to Config.ExecuteFunc, since the memory backend cannot run shell commands itself.
+func (s *Backend) Execute(ctx context.Context, input *filesystem.ExecuteRequest) (*filesystem.ExecuteResponse, error) {
+	if input.Command == "" {
+		return nil, fmt.Errorf("command is required")
+	}
+
+	if s.executeFunc == nil {
+		return nil, fmt.Errorf("command execution is not supported by the memory backend")
+	}
+
+	return s.executeFunc(ctx, input)
+}
+
+// mkdirAll creates dir and all of its missing parents. The caller must hold the write lock.
+func (s *Backend) mkdirAll(dir string) error {
+	var missing []string
+	for p := dir; ; p = path.Dir(p) {
+		if n, ok := s.nodes[p]; ok {
+			if !n.isDir {
+				return fmt.Errorf("not a directory: %s", p)
+			}
+			break
+		}
+		missing = append(missing, p)
+	}
+
+	for _, p := range missing {
+		s.nodes[p] = &node{isDir: true}
+	}
+	return nil
+}
+
+// children returns the sorted direct children of dir. The caller must hold the lock.
+func (s *Backend) children(dir string) []string {
+	var result []string
+	for p := range s.nodes {
+		if p != dir && path.Dir(p) == dir {
+			result = append(result, p)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// walk visits root and its descendants in lexical order, like filepath.WalkDir.
+// The caller must hold the lock.
+func (s *Backend) walk(ctx context.Context, root string, fn func(p string, n *node) error) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	n, ok := s.nodes[root]
+	if !ok {
+		return fmt.Errorf("no such file or directory: %s", root)
+	}
+
+	if err := fn(root, n); err != nil {
+		return err
+	}
+	if !n.isDir {
+		return nil
+	}
+
+	for _, child := range s.children(root) {
+		if err := s.walk(ctx, child, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cleanPath(p string) (string, error) {
+	p = path.Clean(p)
+	if !path.IsAbs(p) {
+		return "", fmt.Errorf("path must be an absolute path: %s", p)
+	}
+	return p, nil
+}
+
+func globToRegex(pattern string) (*regexp.Regexp, error) {
+	// Quote meta characters so that they are matched literally by default,
+	// then restore the glob wildcards: **/ matches zero or more directories,
+	// ** matches anything, * matches within a path segment and ? matches one character.
+	pattern = regexp.QuoteMeta(pattern)
+	pattern = strings.ReplaceAll(pattern, "\\*\\*/", "(.*\\/)?")
+	pattern = strings.ReplaceAll(pattern, "\\*\\*", ".*")
+	pattern = strings.ReplaceAll(pattern, "\\*", "[^/]*")
+	pattern = strings.ReplaceAll(pattern, "\\?", ".")
+
+	// Unescape brackets to keep regex character class functionality.
+	pattern = strings.ReplaceAll(pattern, "\\[", "[")
+	pattern = strings.ReplaceAll(pattern, "\\]", "]")
+
+	return regexp.Compile("^" + pattern + "$")
+}
@@ -0,0 +1,449 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/adk/filesystem"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBackend(t *testing.T, cfg *Config) *Backend {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	s, err := NewBackend(context.Background(), cfg)
+	assert.NoError(t, err)
+	return s
+}
+
+func readRaw(t *testing.T, b *Backend, filePath string) string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	n, ok := b.nodes[filePath]
+	assert.True(t, ok, "file should exist: "+filePath)
+	if !ok {
+		return ""
+	}
+	return n.content
+}
+
+func TestNewBackend(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := NewBackend(ctx, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("seed files and dirs", func(t *testing.T) {
+		s := newTestBackend(t, &Config{
+			Files: map[string]string{"/a/b/c.txt": "hello"},
+			Dirs:  []string{"/empty"},
+		})
+
+		files, err := s.LsInfo(ctx, &filesystem.LsInfoRequest{Path: "/"})
+		assert.NoError(t, err)
+		assert.Equal(t, []filesystem.FileInfo{{Path: "a"}, {Path: "empty"}}, files)
+		assert.Equal(t, "hello", readRaw(t, s, "/a/b/c.txt"))
+	})
+
+	t.Run("relative seed path rejected", func(t *testing.T) {
+		_, err := NewBackend(ctx, &Config{Files: map[string]string{"a.txt": ""}})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "path must be an absolute path")
+	})
+
+	t.Run("seed file conflicts with directory", func(t *testing.T) {
+		_, err := NewBackend(ctx, &Config{Files: map[string]string{"/a": "", "/a/b.txt": ""}})
+		assert.Error(t, err)
+	})
+}
+
+func TestLsInfo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("list directory successfully", func(t *testing.T) {
+		s := newTestBackend(t, &Config{
+			Files: map[string]string{"/dir/file1.txt": ""},
+			Dirs:  []string{"/dir/subdir"},
+		})
+
+		files, err := s.LsInfo(ctx, &filesystem.LsInfoRequest{Path: "/dir"})
+		assert.NoError(t, err)
+		assert.Len(t, files, 2)
+		assert.Equal(t, "file1.txt", files[0].Path)
+		assert.Equal(t, "subdir", files[1].Path)
+	})
+
+	t.Run("list non-existent directory", func(t *testing.T) {
+		s := newTestBackend(t, nil)
+		files, err := s.LsInfo(ctx, &filesystem.LsInfoRequest{Path: "/non-existent-dir"})
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("path is a file, not a directory", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/dir/file.txt": ""}})
+		_, err := s.LsInfo(ctx, &filesystem.LsInfoRequest{Path: "/dir/file.txt"})
+		assert.Error(t, err)
+	})
+}
+
+func TestRead(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("read file successfully", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/test.txt": "line 1\nline 2\nline 3"}})
+		result, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: "/test.txt", Offset: 1, Limit: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, "     2\tline 2\n", result)
+	})
+
+	t.Run("read empty file", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/empty.txt": ""}})
+		result, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: "/empty.txt"})
+		assert.NoError(t, err)
+		assert.Equal(t, "", result)
+	})
+
+	t.Run("read non-existent file", func(t *testing.T) {
+		s := newTestBackend(t, nil)
+		_, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: "/non-existent-file.txt"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "file not found")
+	})
+
+	t.Run("read directory", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Dirs: []string{"/dir"}})
+		_, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: "/dir"})
+		assert.Error(t, err)
+	})
+
+	t.Run("read large file with pagination", func(t *testing.T) {
+		var sb strings.Builder
+		for i := 0; i < 1000; i++ {
+			sb.WriteString(fmt.Sprintf("line %d\n", i))
+		}
+		s := newTestBackend(t, &Config{Files: map[string]string{"/large.txt": sb.String()}})
+
+		result, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: "/large.txt", Offset: 500, Limit: 5})
+		assert.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(result), "\n")
+		assert.Len(t, lines, 5)
+		assert.Contains(t, lines[0], "line 500")
+		assert.Contains(t, lines[4], "line 504")
+	})
+}
+
+func TestWrite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("write new file successfully", func(t *testing.T) {
+		s := newTestBackend(t, nil)
+		err := s.Write(ctx, &filesystem.WriteRequest{FilePath: "/dir/newfile.txt", Content: "hello"})
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", readRaw(t, s, "/dir/newfile.txt"))
+
+		files, err := s.LsInfo(ctx, &filesystem.LsInfoRequest{Path: "/"})
+		assert.NoError(t, err)
+		assert.Equal(t, []filesystem.FileInfo{{Path: "dir"}}, files)
+	})
+
+	t.Run("write to existing file", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/existing.txt": "initial"}})
+		err := s.Write(ctx, &filesystem.WriteRequest{FilePath: "/existing.txt", Content: "new content"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+		assert.Equal(t, "initial", readRaw(t, s, "/existing.txt"))
+	})
+
+	t.Run("parent is a file", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/file.txt": ""}})
+		err := s.Write(ctx, &filesystem.WriteRequest{FilePath: "/file.txt/child.txt", Content: ""})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create parent directory")
+	})
+}
+
+func TestEdit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("edit file successfully - replace one", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/test.txt": "hello world"}})
+		err := s.Edit(ctx, &filesystem.EditRequest{FilePath: "/test.txt", OldString: "world", NewString: "go"})
+		assert.NoError(t, err)
+		assert.Equal(t, "hello go", readRaw(t, s, "/test.txt"))
+	})
+
+	t.Run("edit file successfully - replace all", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/test.txt": "hello world, beautiful world"}})
+		err := s.Edit(ctx, &filesystem.EditRequest{FilePath: "/test.txt", OldString: "world", NewString: "go", ReplaceAll: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "hello go, beautiful go", readRaw(t, s, "/test.txt"))
+	})
+
+	t.Run("string not found in file", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/test.txt": "hello world"}})
+		err := s.Edit(ctx, &filesystem.EditRequest{FilePath: "/test.txt", OldString: "nonexistent", NewString: "go"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "string not found")
+	})
+
+	t.Run("multiple occurrences without replace_all", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/test.txt": "hello world, beautiful world"}})
+		err := s.Edit(ctx, &filesystem.EditRequest{FilePath: "/test.txt", OldString: "world", NewString: "go"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "appears multiple times")
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		s := newTestBackend(t, nil)
+		err := s.Edit(ctx, &filesystem.EditRequest{FilePath: "/test.txt", OldString: "a", NewString: "b"})
+		assert.Error(t, err)
+	})
+
+	t.Run("concurrent read and edit", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/test.txt": "ping"}})
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				from, to := "ping", "pong"
+				if i%2 == 1 {
+					from, to = to, from
+				}
+				assert.NoError(t, s.Edit(ctx, &filesystem.EditRequest{FilePath: "/test.txt", OldString: from, NewString: to}))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				content, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: "/test.txt"})
+				assert.NoError(t, err)
+				assert.Contains(t, []string{"     1\tping\n", "     1\tpong\n"}, content)
+			}
+		}()
+		wg.Wait()
+	})
+}
+
+func TestGrepRaw(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("grep successfully", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/dir/test.txt": "hello\ngo\nworld\ngo"}})
+		matches, err := s.GrepRaw(ctx, &filesystem.GrepRequest{Path: "/dir", Pattern: "go"})
+		assert.NoError(t, err)
+		assert.Len(t, matches, 2)
+		assert.Equal(t, "/dir/test.txt", matches[0].Path)
+		assert.Equal(t, 2, matches[0].Line)
+		assert.Equal(t, "go", matches[0].Content)
+		assert.Equal(t, 4, matches[1].Line)
+	})
+
+	t.Run("grep with glob", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{
+			"/dir/test.txt": "hello go",
+			"/dir/test.log": "hello go",
+		}})
+		matches, err := s.GrepRaw(ctx, &filesystem.GrepRequest{Path: "/dir", Pattern: "go", Glob: "*.txt"})
+		assert.NoError(t, err)
+		assert.Len(t, matches, 1)
+		assert.True(t, strings.HasSuffix(matches[0].Path, ".txt"))
+	})
+
+	t.Run("grep with no matches", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/dir/test.txt": "hello world"}})
+		matches, err := s.GrepRaw(ctx, &filesystem.GrepRequest{Path: "/dir", Pattern: "nonexistent"})
+		assert.NoError(t, err)
+		assert.Empty(t, matches)
+	})
+
+	t.Run("grep walks in lexical order", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{
+			"/dir/b.txt":     "go",
+			"/dir/a/x.txt":   "go",
+			"/dir/a.txt":     "go",
+			"/other/out.txt": "go",
+		}})
+		matches, err := s.GrepRaw(ctx, &filesystem.GrepRequest{Path: "/dir", Pattern: "go"})
+		assert.NoError(t, err)
+		var paths []string
+		for _, m := range matches {
+			paths = append(paths, m.Path)
+		}
+		assert.Equal(t, []string{"/dir/a/x.txt", "/dir/a.txt", "/dir/b.txt"}, paths)
+	})
+
+	t.Run("grep non-existent path", func(t *testing.T) {
+		s := newTestBackend(t, nil)
+		_, err := s.GrepRaw(ctx, &filesystem.GrepRequest{Path: "/non-existent-dir", Pattern: "go"})
+		assert.Error(t, err)
+	})
+}
+
+func TestGlobInfo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("glob successfully", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{
+			"/dir/a.txt": "",
+			"/dir/b.txt": "",
+			"/dir/c.log": "",
+		}})
+		files, err := s.GlobInfo(ctx, &filesystem.GlobInfoRequest{Path: "/dir", Pattern: "*.txt"})
+		assert.NoError(t, err)
+		assert.Len(t, files, 2)
+		assert.Equal(t, "a.txt", files[0].Path)
+		assert.Equal(t, "b.txt", files[1].Path)
+	})
+
+	t.Run("glob with no matches", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Dirs: []string{"/dir"}})
+		files, err := s.GlobInfo(ctx, &filesystem.GlobInfoRequest{Path: "/dir", Pattern: "*.nonexistent"})
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("glob recursive", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{
+			"/dir/root.txt":            "",
+			"/dir/sub/sub.txt":         "",
+			"/dir/sub/subsub/deep.txt": "",
+		}})
+		files, err := s.GlobInfo(ctx, &filesystem.GlobInfoRequest{Path: "/dir", Pattern: "**/*.txt"})
+		assert.NoError(t, err)
+
+		expected := []string{"root.txt", "sub/sub.txt", "sub/subsub/deep.txt"}
+		var actual []string
+		for _, f := range files {
+			actual = append(actual, f.Path)
+		}
+		assert.ElementsMatch(t, expected, actual)
+	})
+
+	t.Run("glob with question mark", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{
+			"/dir/file1.txt":  "",
+			"/dir/fileA.txt":  "",
+			"/dir/file10.txt": "",
+		}})
+		files, err := s.GlobInfo(ctx, &filesystem.GlobInfoRequest{Path: "/dir", Pattern: "file?.txt"})
+		assert.NoError(t, err)
+
+		var actual []string
+		for _, f := range files {
+			actual = append(actual, f.Path)
+		}
+		assert.ElementsMatch(t, []string{"file1.txt", "fileA.txt"}, actual)
+	})
+
+	t.Run("glob with brackets", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{
+			"/dir/file1.txt": "",
+			"/dir/file2.txt": "",
+			"/dir/file3.txt": "",
+		}})
+		files, err := s.GlobInfo(ctx, &filesystem.GlobInfoRequest{Path: "/dir", Pattern: "file[13].txt"})
+		assert.NoError(t, err)
+
+		var actual []string
+		for _, f := range files {
+			actual = append(actual, f.Path)
+		}
+		assert.ElementsMatch(t, []string{"file1.txt", "file3.txt"}, actual)
+	})
+
+	t.Run("glob from root", func(t *testing.T) {
+		s := newTestBackend(t, &Config{Files: map[string]string{"/a.txt": "", "/dir/b.txt": ""}})
+		files, err := s.GlobInfo(ctx, &filesystem.GlobInfoRequest{Pattern: "*.txt"})
+		assert.NoError(t, err)
+		assert.Equal(t, []filesystem.FileInfo{{Path: "a.txt"}}, files)
+	})
+}
+
+func TestPathCleaning(t *testing.T) {
+	ctx := context.Background()
+	s := newTestBackend(t, &Config{Files: map[string]string{"/dir/test.txt": "hello world"}})
+
+	t.Run("Read with dirty path", func(t *testing.T) {
+		res, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: "/dir/../dir/test.txt"})
+		assert.NoError(t, err)
+		assert.Contains(t, res, "hello world")
+	})
+
+	t.Run("Read with repeated slashes", func(t *testing.T) {
+		res, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: "//dir//test.txt"})
+		assert.NoError(t, err)
+		assert.Contains(t, res, "hello world")
+	})
+
+	t.Run("Write with dirty path", func(t *testing.T) {
+		err := s.Write(ctx, &filesystem.WriteRequest{FilePath: "/dir/subdir/../write_test.txt", Content: "new content"})
+		assert.NoError(t, err)
+		assert.Equal(t, "new content", readRaw(t, s, "/dir/write_test.txt"))
+	})
+
+	t.Run("LsInfo with dirty path", func(t *testing.T) {
+		files, err := s.LsInfo(ctx, &filesystem.LsInfoRequest{Path: "/dir/."})
+		assert.NoError(t, err)
+		assert.Contains(t, files, filesystem.FileInfo{Path: "test.txt"})
+	})
+
+	t.Run("Relative path rejected", func(t *testing.T) {
+		_, err := s.Read(ctx, &filesystem.ReadRequest{FilePath: "relative/path.txt"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "path must be an absolute path")
+	})
+}
+
+func TestExecute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("empty command", func(t *testing.T) {
+		s := newTestBackend(t, nil)
+		_, err := s.Execute(ctx, &filesystem.ExecuteRequest{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "command is required")
+	})
+
+	t.Run("no execute func", func(t *testing.T) {
+		s := newTestBackend(t, nil)
+		_, err := s.Execute(ctx, &filesystem.ExecuteRequest{Command: "ls"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not supported")
+	})
+
+	t.Run("with execute func", func(t *testing.T) {
+		s := newTestBackend(t, &Config{
+			ExecuteFunc: func(ctx context.Context, input *filesystem.ExecuteRequest) (*filesystem.ExecuteResponse, error) {
+				return &filesystem.ExecuteResponse{Output: "ran: " + input.Command}, nil
+			},
+		})
+		resp, err := s.Execute(ctx, &filesystem.ExecuteRequest{Command: "echo hi"})
+		assert.NoError(t, err)
+		assert.Equal(t, "ran: echo hi", resp.Output)
+	})
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/adk/filesystem"
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-ext/adk/backend/internal/fstool"
+)
+
+// Tools returns the operations of b (ls, read_file, write_file, edit_file, glob, grep and,
+// when b supports it, execute) as individual tools, so they can be registered selectively
+// without the filesystem middleware. b is typically a backend created by NewBackend.
+func Tools(ctx context.Context, b filesystem.Backend) ([]tool.BaseTool, error) {
+	return fstool.New(ctx, b)
+}
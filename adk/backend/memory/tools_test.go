@@ -0,0 +1,52 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTools(t *testing.T) {
+	ctx := context.Background()
+	s := newTestBackend(t, &Config{Files: map[string]string{"/src/main.go": "package main\n"}})
+
+	tools, err := Tools(ctx, s)
+	require.NoError(t, err)
+
+	byName := map[string]tool.InvokableTool{}
+	for _, bt := range tools {
+		info, err := bt.Info(ctx)
+		require.NoError(t, err)
+		doc, err := info.ParamsOneOf.ToJSONSchema()
+		require.NoError(t, err)
+		assert.NotZero(t, doc.Properties.Len(), info.Name)
+		byName[info.Name] = bt.(tool.InvokableTool)
+	}
+	assert.Len(t, byName, 7)
+
+	out, err := byName["glob"].InvokableRun(ctx, `{"pattern": "**/*.go"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "src/main.go\n", out)
+
+	out, err = byName["grep"].InvokableRun(ctx, `{"pattern": "main"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "/src/main.go:1:package main\n", out)
+}
@@ -18,6 +18,8 @@ package cozeloop
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/schema"
@@ -30,6 +32,7 @@ func NewLoopHandler(client cozeloop.Client, opts ...Option) callbacks.Handler {
 
 	o := &options{
 		enableTracing: true,
+		flushTimeout:  defaultFlushTimeout,
 	}
 
 	for _, opt := range opts {
@@ -41,16 +44,49 @@ func NewLoopHandler(client cozeloop.Client, opts ...Option) callbacks.Handler {
 	}
 
 	return &Handler{
-		Client:  client,
-		handler: handler,
+		Client:       client,
+		handler:      handler,
+		flushTimeout: o.flushTimeout,
 	}
 }
 
+const defaultFlushTimeout = 5 * time.Second
+
 type Handler struct {
 	cozeloop.Client
 
 	// internal fields
-	handler callbacks.Handler
+	handler      callbacks.Handler
+	flushTimeout time.Duration
+}
+
+// Shutdown flushes in-flight spans and closes the underlying client, call it once before the process exits
+// so that the last traces are not lost. It returns an error if flushing does not finish within the flush timeout
+// (see WithFlushTimeout) or before ctx is done.
+func (h *Handler) Shutdown(ctx context.Context) error {
+	if h == nil || h.Client == nil {
+		return nil
+	}
+
+	if h.flushTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.flushTimeout)
+		defer cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.Client.Flush(ctx)
+		h.Client.Close(ctx)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("[cozeloop] shutdown before spans were flushed: %w", ctx.Err())
+	}
 }
 
 func (h *Handler) OnStart(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
@@ -24,6 +24,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bytedance/mockey"
 	"github.com/cloudwego/eino/callbacks"
@@ -211,3 +212,52 @@ func TestCozeLoopCallback(t *testing.T) {
 		cbh.OnEndWithStreamOutput(ctx2, &callbacks.RunInfo{Component: components.ComponentOfChatModel}, outsr)
 	})
 }
+
+type flushRecordingClient struct {
+	cozeloop.Client
+	flushed bool
+	closed  bool
+	block   chan struct{}
+}
+
+func (c *flushRecordingClient) Flush(ctx context.Context) {
+	if c.block != nil {
+		<-c.block
+	}
+	c.flushed = true
+}
+
+func (c *flushRecordingClient) Close(ctx context.Context) {
+	c.closed = true
+}
+
+func TestHandler_Shutdown(t *testing.T) {
+	mockey.PatchConvey("test shutdown flushes and closes client", t, func() {
+		client := &flushRecordingClient{}
+		h := NewLoopHandler(client, WithEnableTracing(false)).(*Handler)
+
+		if err := h.Shutdown(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !client.flushed || !client.closed {
+			t.Fatal("Shutdown should flush and close the client")
+		}
+	})
+
+	mockey.PatchConvey("test shutdown times out", t, func() {
+		client := &flushRecordingClient{block: make(chan struct{})}
+		defer close(client.block)
+		h := NewLoopHandler(client, WithEnableTracing(false), WithFlushTimeout(10*time.Millisecond)).(*Handler)
+
+		if err := h.Shutdown(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected deadline exceeded, got %v", err)
+		}
+	})
+
+	mockey.PatchConvey("test nil handler", t, func() {
+		var h *Handler
+		if err := h.Shutdown(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
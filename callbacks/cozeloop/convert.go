@@ -24,9 +24,12 @@ import (
 	"github.com/bytedance/sonic"
 	"github.com/coze-dev/cozeloop-go/spec/tracespec"
 
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/components/prompt"
 	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/schema"
 )
 
@@ -216,6 +219,16 @@ func convertAssistantGenMultiContent(parts []schema.MessageOutputPart) []*traces
 					Signature: sign,
 				})
 			}
+		case schema.ChatMessagePartTypeAudioURL:
+			if part.Audio == nil {
+				continue
+			}
+			result = append(result, convertOutputMediaPart(part.Type, &part.Audio.MessagePartCommon, sign)...)
+		case schema.ChatMessagePartTypeVideoURL:
+			if part.Video == nil {
+				continue
+			}
+			result = append(result, convertOutputMediaPart(part.Type, &part.Video.MessagePartCommon, sign)...)
 		default:
 			log.Printf("unknown part type: %s", part.Type)
 		}
@@ -223,6 +236,30 @@ func convertAssistantGenMultiContent(parts []schema.MessageOutputPart) []*traces
 	return result
 }
 
+// convertOutputMediaPart records generated audio/video as file parts, as the trace spec has no dedicated media parts.
+func convertOutputMediaPart(typ schema.ChatMessagePartType, common *schema.MessagePartCommon, sign string) []*tracespec.ModelMessagePart {
+	var result []*tracespec.ModelMessagePart
+	if common.URL != nil {
+		result = append(result, &tracespec.ModelMessagePart{
+			Type: tracespec.ModelMessagePartType(typ),
+			FileURL: &tracespec.ModelFileURL{
+				URL: *common.URL,
+			},
+			Signature: sign,
+		})
+	}
+	if common.Base64Data != nil {
+		result = append(result, &tracespec.ModelMessagePart{
+			Type: tracespec.ModelMessagePartType(typ),
+			FileURL: &tracespec.ModelFileURL{
+				URL: fmt.Sprintf("data:%s;base64,%s", common.MIMEType, *common.Base64Data),
+			},
+			Signature: sign,
+		})
+	}
+	return result
+}
+
 func convertMultiContent(parts []schema.ChatMessagePart) []*tracespec.ModelMessagePart {
 	result := make([]*tracespec.ModelMessagePart, len(parts))
 	for i := range parts {
@@ -373,6 +410,42 @@ func convertPromptArguments(variables map[string]any) []*tracespec.PromptArgumen
 	return resp
 }
 
+// Embedding
+
+type embeddingInput struct {
+	Texts []string `json:"texts"`
+}
+
+type embeddingOutput struct {
+	Embeddings [][]float64 `json:"embeddings"`
+	Dimension  int         `json:"dimension,omitempty"`
+}
+
+func convertEmbeddingInput(input *embedding.CallbackInput) *embeddingInput {
+	if input == nil {
+		return nil
+	}
+
+	return &embeddingInput{
+		Texts: input.Texts,
+	}
+}
+
+func convertEmbeddingOutput(output *embedding.CallbackOutput) *embeddingOutput {
+	if output == nil {
+		return nil
+	}
+
+	resp := &embeddingOutput{
+		Embeddings: output.Embeddings,
+	}
+	if len(output.Embeddings) > 0 {
+		resp.Dimension = len(output.Embeddings[0])
+	}
+
+	return resp
+}
+
 // Retriever
 
 func convertRetrieverOutput(output *retriever.CallbackOutput) *tracespec.RetrieverOutput {
@@ -416,6 +489,72 @@ func convertDocument(doc *schema.Document) *tracespec.RetrieverDocument {
 	}
 }
 
+// Indexer
+
+type indexerInput struct {
+	DocCount  int                            `json:"doc_count"`
+	Documents []*tracespec.RetrieverDocument `json:"documents,omitempty"`
+}
+
+type indexerOutput struct {
+	Count int      `json:"count"`
+	IDs   []string `json:"ids,omitempty"`
+}
+
+func convertIndexerInput(input *indexer.CallbackInput) *indexerInput {
+	if input == nil {
+		return nil
+	}
+
+	return &indexerInput{
+		DocCount:  len(input.Docs),
+		Documents: iterSlice(input.Docs, convertDocument),
+	}
+}
+
+func convertIndexerOutput(output *indexer.CallbackOutput) *indexerOutput {
+	if output == nil {
+		return nil
+	}
+
+	return &indexerOutput{
+		Count: len(output.IDs),
+		IDs:   output.IDs,
+	}
+}
+
+// Tool
+
+type toolInput struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments"`
+}
+
+type toolOutput struct {
+	Result string `json:"result"`
+}
+
+func convertToolInput(name string, input *tool.CallbackInput) *toolInput {
+	if input == nil {
+		return nil
+	}
+
+	return &toolInput{
+		Name:      name,
+		Arguments: input.ArgumentsInJSON,
+	}
+}
+
+func convertToolOutput(output *tool.CallbackOutput) *toolOutput {
+	if output == nil {
+		return nil
+	}
+
+	return &toolOutput{
+		Result: output.Response,
+	}
+}
+
 func iterSlice[A, B any](sa []A, fb func(a A) B) []B {
 	r := make([]B, len(sa))
 	for i := range sa {
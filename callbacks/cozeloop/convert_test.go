@@ -699,3 +699,36 @@ func Test_convertModelMessage(t *testing.T) {
 		})
 	})
 }
+
+func Test_convertAssistantGenMultiContent(t *testing.T) {
+	mockey.PatchConvey("测试 convertAssistantGenMultiContent 函数", t, func() {
+		mockey.PatchConvey("生成的音频与视频应记录为文件", func() {
+			audioURL := "https://example.com/speech.wav"
+			videoData := "AAAA"
+			parts := []schema.MessageOutputPart{
+				{Type: schema.ChatMessagePartTypeText, Text: "here you go"},
+				{
+					Type:  schema.ChatMessagePartTypeAudioURL,
+					Audio: &schema.MessageOutputAudio{MessagePartCommon: schema.MessagePartCommon{URL: &audioURL}},
+				},
+				{
+					Type: schema.ChatMessagePartTypeVideoURL,
+					Video: &schema.MessageOutputVideo{MessagePartCommon: schema.MessagePartCommon{
+						Base64Data: &videoData,
+						MIMEType:   "video/mp4",
+					}},
+				},
+				{Type: schema.ChatMessagePartTypeAudioURL},
+			}
+
+			result := convertAssistantGenMultiContent(parts)
+
+			So(len(result), ShouldEqual, 3)
+			So(result[0].Text, ShouldEqual, "here you go")
+			So(result[1].Type, ShouldEqual, tracespec.ModelMessagePartType(schema.ChatMessagePartTypeAudioURL))
+			So(result[1].FileURL.URL, ShouldEqual, audioURL)
+			So(result[2].Type, ShouldEqual, tracespec.ModelMessagePartType(schema.ChatMessagePartTypeVideoURL))
+			So(result[2].FileURL.URL, ShouldEqual, "data:video/mp4;base64,AAAA")
+		})
+	})
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/components/prompt"
 	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 )
@@ -50,16 +51,102 @@ func NewDefaultDataParser(enableAggrMessageOutput bool) CallbackDataParser {
 	return &defaultDataParser{concatFuncs: make(map[reflect.Type]any), enableAggrMessageOutput: enableAggrMessageOutput}
 }
 
-func newDefaultDataParserWithConcatFuncs(concatFuncs map[reflect.Type]any, enableAggrMessageOutput bool) CallbackDataParser {
+func newDefaultDataParserWithOptions(o *options) CallbackDataParser {
+	concatFuncs := o.concatFuncs
 	if concatFuncs == nil {
-		return NewDefaultDataParser(enableAggrMessageOutput)
+		concatFuncs = make(map[reflect.Type]any)
+	}
+	return &defaultDataParser{
+		concatFuncs:             concatFuncs,
+		enableAggrMessageOutput: o.enableAggrOutput,
+		redactor:                newRedactor(o.redactRules),
+		maxFieldBytes:           o.maxFieldBytes,
+		maxDocumentBytes:        o.maxDocumentBytes,
+		includeDocumentVector:   o.includeDocumentVector,
+		cumulativeStreamUsage:   o.cumulativeStreamUsage,
 	}
-	return &defaultDataParser{concatFuncs: concatFuncs, enableAggrMessageOutput: enableAggrMessageOutput}
 }
 
 type defaultDataParser struct {
 	concatFuncs             map[reflect.Type]any
 	enableAggrMessageOutput bool
+	redactor                *redactor
+	maxFieldBytes           int
+	maxDocumentBytes        int
+	includeDocumentVector   bool
+	cumulativeStreamUsage   bool
+}
+
+// sanitize redacts and then truncates message text before it is reported.
+func (d defaultDataParser) sanitize(s string) string {
+	return d.truncate(d.redactor.redactString(s))
+}
+
+func (d defaultDataParser) truncate(s string) string {
+	return truncateString(s, d.maxFieldBytes)
+}
+
+func (d defaultDataParser) convertModelMessage(message *schema.Message) *tracespec.ModelMessage {
+	return rewriteModelMessage(convertModelMessage(message), d.sanitize)
+}
+
+func (d defaultDataParser) convertModelInput(input *model.CallbackInput) *tracespec.ModelInput {
+	return rewriteModelInput(convertModelInput(input), d.sanitize)
+}
+
+func (d defaultDataParser) convertModelOutput(output *model.CallbackOutput) *tracespec.ModelOutput {
+	return rewriteModelOutput(convertModelOutput(output), d.sanitize)
+}
+
+func (d defaultDataParser) convertIndexerInput(input *indexer.CallbackInput) *indexerInput {
+	in := convertIndexerInput(input)
+	if in != nil {
+		d.trimDocuments(in.Documents)
+	}
+	return in
+}
+
+func (d defaultDataParser) convertRetrieverOutput(output *retriever.CallbackOutput) *tracespec.RetrieverOutput {
+	out := convertRetrieverOutput(output)
+	if out != nil {
+		d.trimDocuments(out.Documents)
+	}
+	return out
+}
+
+// trimDocuments drops dense vectors unless enabled and truncates document content,
+// using the document specific limit if set and the field limit otherwise.
+func (d defaultDataParser) trimDocuments(docs []*tracespec.RetrieverDocument) {
+	maxBytes := d.maxFieldBytes
+	if d.maxDocumentBytes > 0 {
+		maxBytes = d.maxDocumentBytes
+	}
+
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		if !d.includeDocumentVector {
+			doc.Vector = nil
+		}
+		doc.Content = truncateString(doc.Content, maxBytes)
+	}
+}
+
+func (d defaultDataParser) convertToolInput(name string, input *tool.CallbackInput) *toolInput {
+	in := convertToolInput(name, input)
+	if in != nil {
+		in.Arguments = d.truncate(in.Arguments)
+	}
+	return in
+}
+
+func (d defaultDataParser) convertToolOutput(output *tool.CallbackOutput) *toolOutput {
+	out := convertToolOutput(output)
+	if out != nil {
+		out.Result = d.truncate(out.Result)
+	}
+	return out
 }
 
 func (d defaultDataParser) ParseInput(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) map[string]any {
@@ -76,7 +163,7 @@ func (d defaultDataParser) ParseInput(ctx context.Context, info *callbacks.RunIn
 	case components.ComponentOfChatModel:
 		cbInput := model.ConvCallbackInput(input)
 		if cbInput != nil {
-			tags.set(tracespec.Input, convertModelInput(cbInput))
+			tags.set(tracespec.Input, d.convertModelInput(cbInput))
 			tags.set(consts.CustomSpanTagKeyExtra, cbInput.Extra)
 
 			if cbInput.Config != nil {
@@ -99,13 +186,15 @@ func (d defaultDataParser) ParseInput(ctx context.Context, info *callbacks.RunIn
 	case components.ComponentOfEmbedding:
 		cbInput := embedding.ConvCallbackInput(input)
 		if cbInput != nil {
-			tags.set(tracespec.Input, cbInput.Texts)
+			tags.set(tracespec.Input, convertEmbeddingInput(cbInput))
 
 			if cbInput.Config != nil {
 				tags.set(tracespec.ModelName, cbInput.Config.Model)
 			}
 		}
 
+		tags.set(tracespec.ModelProvider, info.Type)
+
 	case components.ComponentOfRetriever:
 		cbInput := retriever.ConvCallbackInput(input)
 		if cbInput != nil {
@@ -125,17 +214,28 @@ func (d defaultDataParser) ParseInput(ctx context.Context, info *callbacks.RunIn
 	case components.ComponentOfIndexer:
 		cbInput := indexer.ConvCallbackInput(input)
 		if cbInput != nil {
-			// rewrite if not suitable here
-			tags.set(tracespec.Input, parseAny(ctx, cbInput.Docs, false))
+			tags.set(tracespec.Input, d.convertIndexerInput(cbInput))
+			tags.set(consts.CustomSpanTagKeyExtra, cbInput.Extra)
 		}
 
+	case components.ComponentOfTool:
+		cbInput := tool.ConvCallbackInput(input)
+		if cbInput != nil {
+			tags.set(tracespec.Input, d.convertToolInput(info.Name, cbInput))
+			tags.set(consts.CustomSpanTagKeyExtra, cbInput.Extra)
+		} else {
+			tags.set(tracespec.Input, parseAny(ctx, input, false))
+		}
+
+		tags.set(consts.CustomSpanTagKeyToolName, info.Name)
+
 	case compose.ComponentOfLambda:
 		tags.set(tracespec.Input, parseAny(ctx, input, false))
 
 	default:
 		messages, ok := input.([]*schema.Message)
 		if ok && level == 1 {
-			collectOutput.addMessages(iterSlice(messages, convertModelMessage)...)
+			collectOutput.addMessages(iterSlice(messages, d.convertModelMessage)...)
 		}
 		tags.set(tracespec.Input, parseAny(ctx, input, false))
 	}
@@ -157,7 +257,7 @@ func (d defaultDataParser) ParseOutput(ctx context.Context, info *callbacks.RunI
 	case components.ComponentOfChatModel:
 		cbOutput := model.ConvCallbackOutput(output)
 		if cbOutput != nil {
-			finalOutput := convertModelOutput(cbOutput)
+			finalOutput := d.convertModelOutput(cbOutput)
 			if level == 2 {
 				if len(finalOutput.Choices) > 0 {
 					collectOutput.addMessages(finalOutput.Choices[0].Message)
@@ -172,8 +272,15 @@ func (d defaultDataParser) ParseOutput(ctx context.Context, info *callbacks.RunI
 					set(tracespec.OutputTokens, cbOutput.TokenUsage.CompletionTokens).
 					set(tracespec.InputCachedTokens, cbOutput.TokenUsage.PromptTokenDetails.CachedTokens)
 			}
+
+			if cbOutput.Config != nil {
+				tags.setIfNotZero(tracespec.ModelName, cbOutput.Config.Model)
+			}
 		}
 
+		// the provider comes from the component's GetType, so spans stay comparable across providers
+		// even when Config.Model is a generic model id
+		tags.setIfNotZero(tracespec.ModelProvider, info.Type)
 		tags.set(tracespec.Stream, false)
 
 		if tv, ok := getTraceVariablesValue(ctx); ok {
@@ -193,7 +300,7 @@ func (d defaultDataParser) ParseOutput(ctx context.Context, info *callbacks.RunI
 	case components.ComponentOfEmbedding:
 		cbOutput := embedding.ConvCallbackOutput(output)
 		if cbOutput != nil {
-			tags.set(tracespec.Output, parseAny(ctx, cbOutput.Embeddings, false))
+			tags.set(tracespec.Output, convertEmbeddingOutput(cbOutput))
 
 			if cbOutput.TokenUsage != nil {
 				tags.set(tracespec.Tokens, cbOutput.TokenUsage.TotalTokens).
@@ -209,14 +316,14 @@ func (d defaultDataParser) ParseOutput(ctx context.Context, info *callbacks.RunI
 	case components.ComponentOfIndexer:
 		cbOutput := indexer.ConvCallbackOutput(output)
 		if cbOutput != nil {
-			tags.set(tracespec.Output, parseAny(ctx, cbOutput.IDs, false))
+			tags.set(tracespec.Output, convertIndexerOutput(cbOutput))
 		}
 
 	case components.ComponentOfRetriever:
 		cbOutput := retriever.ConvCallbackOutput(output)
 		if cbOutput != nil {
 			// rewrite if not suitable here
-			tags.set(tracespec.Output, convertRetrieverOutput(cbOutput))
+			tags.set(tracespec.Output, d.convertRetrieverOutput(cbOutput))
 		}
 
 	case components.ComponentOfTool:
@@ -224,19 +331,27 @@ func (d defaultDataParser) ParseOutput(ctx context.Context, info *callbacks.RunI
 		if toolCallID != "" {
 			tags.set(tracespec.ToolCallID, toolCallID)
 		}
-		tags.set(tracespec.Output, parseAny(ctx, output, false))
+
+		cbOutput := tool.ConvCallbackOutput(output)
+		if cbOutput != nil {
+			tags.set(tracespec.Output, d.convertToolOutput(cbOutput))
+		} else {
+			tags.set(tracespec.Output, parseAny(ctx, output, false))
+		}
+
+		tags.set(consts.CustomSpanTagKeyToolName, info.Name)
 
 	case compose.ComponentOfLambda:
 		messages, ok := output.([]*schema.Message)
 		if ok && level == 2 {
-			collectOutput.addMessages(iterSlice(messages, convertModelMessage)...)
+			collectOutput.addMessages(iterSlice(messages, d.convertModelMessage)...)
 		}
 		tags.set(tracespec.Output, parseAny(ctx, output, false))
 
 	case compose.ComponentOfToolsNode:
 		messages, ok := output.([]*schema.Message)
 		if ok && level == 2 {
-			collectOutput.addMessages(iterSliceWithCtx(ctx, iterSlice(messages, convertModelMessage), addToolName)...)
+			collectOutput.addMessages(iterSliceWithCtx(ctx, iterSlice(messages, d.convertModelMessage), addToolName)...)
 		}
 		tags.set(tracespec.Output, parseAny(ctx, output, false))
 	default:
@@ -245,7 +360,7 @@ func (d defaultDataParser) ParseOutput(ctx context.Context, info *callbacks.RunI
 		} else {
 			messages, ok := output.([]*schema.Message)
 			if ok && level == 2 {
-				collectOutput.addMessages(iterSlice(messages, convertModelMessage)...)
+				collectOutput.addMessages(iterSlice(messages, d.convertModelMessage)...)
 			}
 			tags.set(tracespec.Output, parseAny(ctx, output, false))
 		}
@@ -321,7 +436,7 @@ func (d defaultDataParser) ParseChatModelStreamOutput(ctx context.Context, outpu
 		chunks  []*schema.Message
 		onceSet bool
 		tags    = make(spanTags)
-		usage   *model.TokenUsage
+		usages  []*model.TokenUsage
 	)
 
 	level := getGraphNodeLevelFromCtx(ctx)
@@ -347,11 +462,7 @@ func (d defaultDataParser) ParseChatModelStreamOutput(ctx context.Context, outpu
 		}
 
 		if cbOutput.TokenUsage != nil {
-			usage = &model.TokenUsage{
-				PromptTokens:     cbOutput.TokenUsage.PromptTokens,
-				CompletionTokens: cbOutput.TokenUsage.CompletionTokens,
-				TotalTokens:      cbOutput.TokenUsage.TotalTokens,
-			}
+			usages = append(usages, cbOutput.TokenUsage)
 		}
 
 		if cbOutput.Config != nil && !onceSet {
@@ -373,13 +484,13 @@ func (d defaultDataParser) ParseChatModelStreamOutput(ctx context.Context, outpu
 			})
 		}
 	} else {
-		tags.set(tracespec.Output, convertModelOutput(&model.CallbackOutput{Message: msg}))
+		tags.set(tracespec.Output, d.convertModelOutput(&model.CallbackOutput{Message: msg}))
 		if level == 2 {
-			collectOutput.addMessages(convertModelMessage(msg))
+			collectOutput.addMessages(d.convertModelMessage(msg))
 		}
 	}
 
-	if usage != nil {
+	if usage := mergeStreamUsage(usages, d.cumulativeStreamUsage); usage != nil {
 		tags.set(tracespec.Tokens, usage.TotalTokens).
 			set(tracespec.InputTokens, usage.PromptTokens).
 			set(tracespec.OutputTokens, usage.CompletionTokens).
@@ -389,6 +500,29 @@ func (d defaultDataParser) ParseChatModelStreamOutput(ctx context.Context, outpu
 	return tags
 }
 
+// mergeStreamUsage combines the token usages reported on the chunks of a stream.
+// By default the usages are increments, e.g. the prompt tokens first and the completion tokens last,
+// and are summed. When cumulative is set every chunk reports the usage so far, as Gemini does,
+// and the last one is kept.
+func mergeStreamUsage(usages []*model.TokenUsage, cumulative bool) *model.TokenUsage {
+	if len(usages) == 0 {
+		return nil
+	}
+	if cumulative {
+		last := *usages[len(usages)-1]
+		return &last
+	}
+
+	usage := &model.TokenUsage{}
+	for _, u := range usages {
+		usage.PromptTokens += u.PromptTokens
+		usage.PromptTokenDetails.CachedTokens += u.PromptTokenDetails.CachedTokens
+		usage.CompletionTokens += u.CompletionTokens
+		usage.TotalTokens += u.TotalTokens
+	}
+	return usage
+}
+
 func (d defaultDataParser) ParseDefaultStreamInput(ctx context.Context, input *schema.StreamReader[callbacks.CallbackInput]) (chunks []any, err error) {
 	for {
 		item, recvErr := input.Recv()
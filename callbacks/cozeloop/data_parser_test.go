@@ -25,8 +25,10 @@ import (
 
 	"github.com/bytedance/mockey"
 	"github.com/cloudwego/eino-ext/callbacks/cozeloop/internal/async"
+	"github.com/cloudwego/eino-ext/callbacks/cozeloop/internal/consts"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/components/indexer"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/components/prompt"
@@ -74,6 +76,60 @@ func Test_defaultDataParser_ParseInput(t *testing.T) {
 			convey.So(result, convey.ShouldNotBeNil)
 		})
 
+		mockey.PatchConvey("测试 ComponentOfEmbedding 场景", func() {
+			ctx := context.Background()
+			info := &callbacks.RunInfo{
+				Name:      "test",
+				Type:      "testType",
+				Component: components.ComponentOfEmbedding,
+			}
+			var input callbacks.CallbackInput = &embedding.CallbackInput{
+				Texts:  []string{"hello", "world"},
+				Config: &embedding.Config{Model: "test-embedding"},
+			}
+			d := defaultDataParser{}
+
+			result := d.ParseInput(ctx, info, input)
+			convey.So(result, convey.ShouldNotBeNil)
+			convey.So(result[tracespec.Input], convey.ShouldEqual, `{"texts":["hello","world"]}`)
+			convey.So(result[tracespec.ModelName], convey.ShouldEqual, "test-embedding")
+			convey.So(result[tracespec.ModelProvider], convey.ShouldEqual, "testType")
+		})
+
+		mockey.PatchConvey("测试 ComponentOfIndexer 场景", func() {
+			ctx := context.Background()
+			info := &callbacks.RunInfo{
+				Name:      "test",
+				Type:      "testType",
+				Component: components.ComponentOfIndexer,
+			}
+			var input callbacks.CallbackInput = []*schema.Document{
+				{ID: "1", Content: "doc1"},
+				{ID: "2", Content: "doc2"},
+			}
+			d := defaultDataParser{}
+
+			result := d.ParseInput(ctx, info, input)
+			convey.So(result, convey.ShouldNotBeNil)
+			convey.So(result[tracespec.Input], convey.ShouldContainSubstring, `"doc_count":2`)
+		})
+
+		mockey.PatchConvey("测试 ComponentOfTool 场景", func() {
+			ctx := context.Background()
+			info := &callbacks.RunInfo{
+				Name:      "get_weather",
+				Type:      "testType",
+				Component: components.ComponentOfTool,
+			}
+			var input callbacks.CallbackInput = `{"city":"beijing"}`
+			d := defaultDataParser{}
+
+			result := d.ParseInput(ctx, info, input)
+			convey.So(result, convey.ShouldNotBeNil)
+			convey.So(result[tracespec.Input], convey.ShouldEqual, `{"name":"get_weather","arguments":"{\"city\":\"beijing\"}"}`)
+			convey.So(result[consts.CustomSpanTagKeyToolName], convey.ShouldEqual, "get_weather")
+		})
+
 		mockey.PatchConvey("测试 info 为 nil 的场景", func() {
 			ctx := context.Background()
 			var info *callbacks.RunInfo = nil
@@ -214,14 +270,19 @@ func Test_defaultDataParser_ParseOutput(t *testing.T) {
 			info := &callbacks.RunInfo{
 				Component: components.ComponentOfEmbedding,
 			}
-
-			mockParseAny := mockey.Mock(parseAny).Return("test_output").Build()
+			var output callbacks.CallbackOutput = &embedding.CallbackOutput{
+				Embeddings: [][]float64{{0.1, 0.2, 0.3}},
+				Config:     &embedding.Config{Model: "test-embedding"},
+				TokenUsage: &embedding.TokenUsage{PromptTokens: 3, TotalTokens: 3},
+			}
 
 			result := d.ParseOutput(ctx, info, output)
 
 			convey.So(result, convey.ShouldNotBeNil)
-
-			mockParseAny.UnPatch()
+			convey.So(result[tracespec.Output], convey.ShouldEqual, `{"embeddings":[[0.1,0.2,0.3]],"dimension":3}`)
+			convey.So(result[tracespec.ModelName], convey.ShouldEqual, "test-embedding")
+			convey.So(result[tracespec.Tokens], convey.ShouldEqual, 3)
+			convey.So(result[tracespec.InputTokens], convey.ShouldEqual, 3)
 		})
 
 		mockey.PatchConvey("当 info.Component 为 ComponentOfIndexer 时", func() {
@@ -282,6 +343,30 @@ func Test_defaultDataParser_ParseOutput(t *testing.T) {
 			mockParseAny.UnPatch()
 		})
 
+		mockey.PatchConvey("当 info.Component 为 ComponentOfTool 且输出为字符串时", func() {
+			info := &callbacks.RunInfo{
+				Name:      "get_weather",
+				Component: components.ComponentOfTool,
+			}
+			var output callbacks.CallbackOutput = "sunny"
+
+			result := d.ParseOutput(ctx, info, output)
+
+			convey.So(result[tracespec.Output], convey.ShouldEqual, `{"result":"sunny"}`)
+			convey.So(result[consts.CustomSpanTagKeyToolName], convey.ShouldEqual, "get_weather")
+		})
+
+		mockey.PatchConvey("当 info.Component 为 ComponentOfIndexer 且输出为 ids 时", func() {
+			info := &callbacks.RunInfo{
+				Component: components.ComponentOfIndexer,
+			}
+			var output callbacks.CallbackOutput = []string{"id1", "id2"}
+
+			result := d.ParseOutput(ctx, info, output)
+
+			convey.So(result[tracespec.Output], convey.ShouldEqual, `{"count":2,"ids":["id1","id2"]}`)
+		})
+
 		mockey.PatchConvey("当 info.Component 为 compose.ComponentOfLambda 时，且为2级节点", func() {
 			info := &callbacks.RunInfo{
 				Component: compose.ComponentOfLambda,
@@ -775,3 +860,123 @@ func Test_parseSpanTypeFromComponent(t *testing.T) {
 		})
 	})
 }
+
+func Test_defaultDataParser_ParseChatModelStreamOutput_usage(t *testing.T) {
+	mockey.PatchConvey("测试流式输出中多个 chunk 携带 usage 时累加", t, func() {
+		sr, sw := schema.Pipe[callbacks.CallbackOutput](3)
+		sw.Send(&model.CallbackOutput{
+			Message: &schema.Message{Role: schema.Assistant, Content: "hello"},
+			TokenUsage: &model.TokenUsage{
+				PromptTokens: 10,
+				TotalTokens:  10,
+			},
+		}, nil)
+		sw.Send(&model.CallbackOutput{
+			Message: &schema.Message{Role: schema.Assistant, Content: " world"},
+		}, nil)
+		sw.Send(&model.CallbackOutput{
+			Message: &schema.Message{Role: schema.Assistant, Content: "!"},
+			TokenUsage: &model.TokenUsage{
+				CompletionTokens: 5,
+				TotalTokens:      5,
+			},
+		}, nil)
+		sw.Close()
+
+		d := defaultDataParser{}
+		result := d.ParseChatModelStreamOutput(context.Background(), sr)
+
+		convey.So(result[tracespec.InputTokens], convey.ShouldEqual, 10)
+		convey.So(result[tracespec.OutputTokens], convey.ShouldEqual, 5)
+		convey.So(result[tracespec.Tokens], convey.ShouldEqual, 15)
+	})
+}
+
+func Test_defaultDataParser_ParseChatModelStreamOutput_incrementalUsage(t *testing.T) {
+	stream := func(usages ...*model.TokenUsage) *schema.StreamReader[callbacks.CallbackOutput] {
+		sr, sw := schema.Pipe[callbacks.CallbackOutput](len(usages))
+		for _, usage := range usages {
+			sw.Send(&model.CallbackOutput{
+				Message:    &schema.Message{Role: schema.Assistant, Content: "a"},
+				TokenUsage: usage,
+			}, nil)
+		}
+		sw.Close()
+		return sr
+	}
+
+	mockey.PatchConvey("测试流式输出中每个 chunk 携带相同的增量 usage 时累加", t, func() {
+		usages := make([]*model.TokenUsage, 4)
+		for i := range usages {
+			usages[i] = &model.TokenUsage{CompletionTokens: 1, TotalTokens: 1}
+		}
+
+		d := defaultDataParser{}
+		result := d.ParseChatModelStreamOutput(context.Background(), stream(usages...))
+
+		convey.So(result[tracespec.OutputTokens], convey.ShouldEqual, 4)
+		convey.So(result[tracespec.Tokens], convey.ShouldEqual, 4)
+	})
+
+	mockey.PatchConvey("测试流式输出中增量 usage 递增时累加", t, func() {
+		d := defaultDataParser{}
+		result := d.ParseChatModelStreamOutput(context.Background(), stream(
+			&model.TokenUsage{CompletionTokens: 2, TotalTokens: 2},
+			&model.TokenUsage{CompletionTokens: 3, TotalTokens: 3},
+		))
+
+		convey.So(result[tracespec.OutputTokens], convey.ShouldEqual, 5)
+		convey.So(result[tracespec.Tokens], convey.ShouldEqual, 5)
+	})
+}
+
+func Test_defaultDataParser_ParseChatModelStreamOutput_cumulativeUsage(t *testing.T) {
+	mockey.PatchConvey("测试开启 WithCumulativeStreamUsage 后取最后一个 chunk 的 usage", t, func() {
+		sr, sw := schema.Pipe[callbacks.CallbackOutput](3)
+		for i, content := range []string{"hello", " world", "!"} {
+			sw.Send(&model.CallbackOutput{
+				Message: &schema.Message{Role: schema.Assistant, Content: content},
+				TokenUsage: &model.TokenUsage{
+					PromptTokens:     10,
+					CompletionTokens: i + 1,
+					TotalTokens:      10 + i + 1,
+				},
+			}, nil)
+		}
+		sw.Close()
+
+		o := &options{}
+		WithCumulativeStreamUsage(true)(o)
+		d := newDefaultDataParserWithOptions(o).(*defaultDataParser)
+		result := d.ParseChatModelStreamOutput(context.Background(), sr)
+
+		convey.So(result[tracespec.InputTokens], convey.ShouldEqual, 10)
+		convey.So(result[tracespec.OutputTokens], convey.ShouldEqual, 3)
+		convey.So(result[tracespec.Tokens], convey.ShouldEqual, 13)
+	})
+}
+
+func Test_defaultDataParser_ParseOutput_modelProvider(t *testing.T) {
+	mockey.PatchConvey("测试 ChatModel span 的 provider 标签", t, func() {
+		d := defaultDataParser{}
+		output := &model.CallbackOutput{
+			Message: &schema.Message{Role: schema.Assistant, Content: "hi"},
+			Config:  &model.Config{Model: "generic-model-id"},
+		}
+
+		mockey.PatchConvey("RunInfo.Type 作为 provider 标签", func() {
+			info := &callbacks.RunInfo{Type: "Claude", Component: components.ComponentOfChatModel}
+
+			result := d.ParseOutput(context.Background(), info, output)
+			convey.So(result[tracespec.ModelProvider], convey.ShouldEqual, "Claude")
+			convey.So(result[tracespec.ModelName], convey.ShouldEqual, "generic-model-id")
+		})
+
+		mockey.PatchConvey("RunInfo.Type 为空时不设置 provider 标签", func() {
+			info := &callbacks.RunInfo{Component: components.ComponentOfChatModel}
+
+			result := d.ParseOutput(context.Background(), info, output)
+			convey.So(result, convey.ShouldNotContainKey, tracespec.ModelProvider)
+		})
+	})
+}
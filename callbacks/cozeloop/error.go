@@ -0,0 +1,117 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cozeloop
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrorWithStatusCode is implemented by errors carrying a status code, e.g. the HTTP status of a failed API call.
+type ErrorWithStatusCode interface {
+	error
+	StatusCode() int
+}
+
+// ErrorWithCode is implemented by errors carrying a provider specific error code.
+type ErrorWithCode interface {
+	error
+	ErrorCode() string
+}
+
+// ErrorWithType is implemented by errors carrying a provider specific error type, e.g. "rate_limit_error".
+type ErrorWithType interface {
+	error
+	ErrorType() string
+}
+
+type errorDetail struct {
+	typ        string
+	code       string
+	statusCode int
+}
+
+// extractErrorDetail collects structured metadata from err and the errors it wraps.
+// Errors implementing ErrorWithStatusCode, ErrorWithCode or ErrorWithType take precedence,
+// otherwise well-known exported fields of API error structs (StatusCode, HTTPStatusCode, Code, Type) are used.
+// Nothing is extracted for plain errors, whose message is recorded as the error tag anyway.
+func extractErrorDetail(err error) errorDetail {
+	var detail errorDetail
+	if err == nil {
+		return detail
+	}
+
+	var sc ErrorWithStatusCode
+	if errors.As(err, &sc) {
+		detail.statusCode = sc.StatusCode()
+	}
+
+	var c ErrorWithCode
+	if errors.As(err, &c) {
+		detail.code = c.ErrorCode()
+	}
+
+	var t ErrorWithType
+	if errors.As(err, &t) {
+		detail.typ = t.ErrorType()
+	}
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		fillErrorDetailFromFields(e, &detail)
+	}
+
+	return detail
+}
+
+func fillErrorDetailFromFields(err error, detail *errorDetail) {
+	rv := reflect.ValueOf(err)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+
+	if detail.statusCode == 0 {
+		for _, name := range []string{"StatusCode", "HTTPStatusCode"} {
+			if f := rv.FieldByName(name); f.IsValid() && f.CanInt() && f.Int() != 0 {
+				detail.statusCode = int(f.Int())
+				break
+			}
+		}
+	}
+
+	if detail.code == "" {
+		if f := rv.FieldByName("Code"); f.IsValid() && f.CanInterface() && !f.IsZero() {
+			v := f.Interface()
+			if rf := reflect.ValueOf(v); rf.IsValid() && !rf.IsZero() {
+				detail.code = fmt.Sprint(v)
+			}
+		}
+	}
+
+	if detail.typ == "" {
+		if f := rv.FieldByName("Type"); f.IsValid() && f.Kind() == reflect.String {
+			detail.typ = f.String()
+		}
+	}
+}
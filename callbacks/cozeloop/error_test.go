@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cozeloop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/cloudwego/eino-ext/callbacks/cozeloop/internal/consts"
+	"github.com/coze-dev/cozeloop-go/spec/tracespec"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+type typedAPIError struct {
+	status int
+	code   string
+	typ    string
+}
+
+func (e *typedAPIError) Error() string     { return "api error: " + e.code }
+func (e *typedAPIError) StatusCode() int   { return e.status }
+func (e *typedAPIError) ErrorCode() string { return e.code }
+func (e *typedAPIError) ErrorType() string { return e.typ }
+
+// fieldAPIError mirrors API error structs exposing their metadata as fields, e.g. openai.APIError.
+type fieldAPIError struct {
+	Code           any
+	Type           string
+	HTTPStatusCode int
+}
+
+func (e *fieldAPIError) Error() string { return "field api error" }
+
+func Test_getErrorTags(t *testing.T) {
+	mockey.PatchConvey("测试 getErrorTags 提取结构化错误信息", t, func() {
+		ctx := context.Background()
+
+		mockey.PatchConvey("实现了错误接口的错误", func() {
+			err := fmt.Errorf("call model: %w", &typedAPIError{status: 429, code: "rate_limited", typ: "rate_limit_error"})
+
+			tags := getErrorTags(ctx, err)
+			convey.So(tags[tracespec.Error], convey.ShouldEqual, err.Error())
+			convey.So(tags[consts.CustomSpanTagKeyErrorType], convey.ShouldEqual, "rate_limit_error")
+			convey.So(tags[consts.CustomSpanTagKeyErrorCode], convey.ShouldEqual, "rate_limited")
+			convey.So(tags[consts.CustomSpanTagKeyErrorStatusCode], convey.ShouldEqual, 429)
+		})
+
+		mockey.PatchConvey("通过字段暴露信息的错误", func() {
+			err := &fieldAPIError{Code: "invalid_api_key", Type: "invalid_request_error", HTTPStatusCode: 401}
+
+			tags := getErrorTags(ctx, err)
+			convey.So(tags[consts.CustomSpanTagKeyErrorType], convey.ShouldEqual, "invalid_request_error")
+			convey.So(tags[consts.CustomSpanTagKeyErrorCode], convey.ShouldEqual, "invalid_api_key")
+			convey.So(tags[consts.CustomSpanTagKeyErrorStatusCode], convey.ShouldEqual, 401)
+		})
+
+		mockey.PatchConvey("普通错误仅记录错误信息", func() {
+			tags := getErrorTags(ctx, errors.New("boom"))
+			convey.So(tags[tracespec.Error], convey.ShouldEqual, "boom")
+			convey.So(tags, convey.ShouldNotContainKey, consts.CustomSpanTagKeyErrorType)
+			convey.So(tags, convey.ShouldNotContainKey, consts.CustomSpanTagKeyErrorCode)
+			convey.So(tags, convey.ShouldNotContainKey, consts.CustomSpanTagKeyErrorStatusCode)
+		})
+	})
+}
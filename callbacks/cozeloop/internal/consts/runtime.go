@@ -24,4 +24,5 @@ const (
 	CozeLoopAggrMessageOutput = "cozeloop_aggr_message_output"
 	CozeLoopGraphNodeLevel    = "cozeloop_graph_node_level"
 	CozeLoopToolIDNameMap     = "cozeloop_tool_id_name_map"
+	CozeLoopSampled           = "cozeloop_sampled"
 )
@@ -21,5 +21,10 @@ const (
 	CustomSpanTagKeyType      = "eino_run_info_type"
 	CustomSpanTagKeyComponent = "eino_run_info_component"
 
-	CustomSpanTagKeyExtra = "extra"
+	CustomSpanTagKeyExtra    = "extra"
+	CustomSpanTagKeyToolName = "tool_name"
+
+	CustomSpanTagKeyErrorType       = "error_type"
+	CustomSpanTagKeyErrorCode       = "error_code"
+	CustomSpanTagKeyErrorStatusCode = "error_status_code"
 )
@@ -17,7 +17,9 @@
 package cozeloop
 
 import (
+	"context"
 	"reflect"
+	"time"
 
 	"github.com/coze-dev/cozeloop-go"
 )
@@ -31,6 +33,16 @@ type options struct {
 	einoVersionFn    EinoVersionFn
 	concatFuncs      map[reflect.Type]any
 	enableAggrOutput bool
+	redactRules      []RedactRule
+	sampleRate       *float64
+	maxFieldBytes    int
+	staticTags       map[string]string
+	tagsFromCtx      func(ctx context.Context) map[string]string
+	flushTimeout     time.Duration
+
+	maxDocumentBytes      int
+	includeDocumentVector bool
+	cumulativeStreamUsage bool
 }
 
 type Option func(o *options)
@@ -74,3 +86,85 @@ func WithAggrMessageOutput(enable bool) Option {
 		o.enableAggrOutput = enable
 	}
 }
+
+// WithRedactRules masks sensitive content in chat model messages before they are reported.
+// Rules are applied in order; it takes no effect when a custom parser is set by WithCallbackDataParser.
+func WithRedactRules(rules ...RedactRule) Option {
+	return func(o *options) {
+		o.redactRules = append(o.redactRules, rules...)
+	}
+}
+
+// WithSampleRate sets the probability in [0, 1] that a trace is recorded, default 1.
+// The decision is made once at the root span and inherited by all of its child spans.
+func WithSampleRate(rate float64) Option {
+	return func(o *options) {
+		if rate < 0 {
+			rate = 0
+		} else if rate > 1 {
+			rate = 1
+		}
+		o.sampleRate = &rate
+	}
+}
+
+// WithMaxFieldBytes truncates message content, tool arguments and results, and document content
+// longer than n bytes, appending a marker with the original length. A non-positive n disables truncation.
+// It takes no effect when a custom parser is set by WithCallbackDataParser.
+func WithMaxFieldBytes(n int) Option {
+	return func(o *options) {
+		o.maxFieldBytes = n
+	}
+}
+
+// WithStaticTags attaches tags such as env, version or tenant to every span.
+func WithStaticTags(tags map[string]string) Option {
+	return func(o *options) {
+		if o.staticTags == nil {
+			o.staticTags = make(map[string]string, len(tags))
+		}
+		for k, v := range tags {
+			o.staticTags[k] = v
+		}
+	}
+}
+
+// WithTagsFromContext attaches per-call tags read from ctx to every span, they take precedence over static tags.
+func WithTagsFromContext(fn func(ctx context.Context) map[string]string) Option {
+	return func(o *options) {
+		o.tagsFromCtx = fn
+	}
+}
+
+// WithFlushTimeout sets how long Handler.Shutdown waits for in-flight spans to be flushed, default 5s.
+// A non-positive value waits until the ctx passed to Shutdown is done.
+func WithFlushTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.flushTimeout = timeout
+	}
+}
+
+// WithMaxDocumentBytes truncates retriever and indexer document content longer than n bytes,
+// overriding WithMaxFieldBytes for documents. A non-positive n falls back to WithMaxFieldBytes.
+func WithMaxDocumentBytes(n int) Option {
+	return func(o *options) {
+		o.maxDocumentBytes = n
+	}
+}
+
+// WithDocumentVector reports the dense vectors of retriever and indexer documents, which are omitted by default
+// as high-dimensional vectors bloat the spans.
+func WithDocumentVector(include bool) Option {
+	return func(o *options) {
+		o.includeDocumentVector = include
+	}
+}
+
+// WithCumulativeStreamUsage reports the token usage of the last chunk of a streamed chat model output,
+// for models that report the usage so far on every chunk, e.g. Gemini. By default the usages of
+// the chunks are summed. It takes no effect when a custom parser is set by WithCallbackDataParser.
+func WithCumulativeStreamUsage(cumulative bool) Option {
+	return func(o *options) {
+		o.cumulativeStreamUsage = cumulative
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cozeloop
+
+import (
+	"regexp"
+
+	"github.com/coze-dev/cozeloop-go/spec/tracespec"
+)
+
+// RedactRule masks every match of Pattern in message content with Replacement before it is reported.
+type RedactRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+type redactor struct {
+	rules []RedactRule
+}
+
+func newRedactor(rules []RedactRule) *redactor {
+	valid := make([]RedactRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Pattern != nil {
+			valid = append(valid, rule)
+		}
+	}
+
+	if len(valid) == 0 {
+		return nil
+	}
+
+	return &redactor{rules: valid}
+}
+
+func (r *redactor) redactString(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+
+	for _, rule := range r.rules {
+		s = rule.Pattern.ReplaceAllString(s, rule.Replacement)
+	}
+
+	return s
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cozeloop
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/coze-dev/cozeloop-go/spec/tracespec"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func Test_redactor(t *testing.T) {
+	emailRule := RedactRule{
+		Pattern:     regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.]+`),
+		Replacement: "[EMAIL]",
+	}
+
+	mockey.PatchConvey("测试 redactor", t, func() {
+		mockey.PatchConvey("无有效规则时返回 nil", func() {
+			convey.So(newRedactor(nil), convey.ShouldBeNil)
+			convey.So(newRedactor([]RedactRule{{Replacement: "x"}}), convey.ShouldBeNil)
+
+			var r *redactor
+			convey.So(r.redactString("a@b.com"), convey.ShouldEqual, "a@b.com")
+		})
+
+		mockey.PatchConvey("脱敏消息内容、多模态文本与工具参数", func() {
+			r := newRedactor([]RedactRule{emailRule})
+			msg := convertModelMessage(&schema.Message{
+				Role:    schema.User,
+				Content: "contact me at foo.bar@example.com",
+				UserInputMultiContent: []schema.MessageInputPart{
+					{Type: schema.ChatMessagePartTypeText, Text: "or a@b.io"},
+				},
+				ToolCalls: []schema.ToolCall{
+					{ID: "1", Function: schema.FunctionCall{Name: "send", Arguments: `{"to":"x@y.org"}`}},
+				},
+			})
+
+			rewriteModelMessage(msg, r.redactString)
+			convey.So(msg.Content, convey.ShouldEqual, "contact me at [EMAIL]")
+			convey.So(msg.Parts[0].Text, convey.ShouldEqual, "or [EMAIL]")
+			convey.So(msg.ToolCalls[0].Function.Arguments, convey.ShouldEqual, `{"to":"[EMAIL]"}`)
+		})
+
+		mockey.PatchConvey("ParseInput 中的邮箱被脱敏", func() {
+			d := newDefaultDataParserWithOptions(&options{redactRules: []RedactRule{emailRule}})
+			info := &callbacks.RunInfo{
+				Type:      "testType",
+				Component: components.ComponentOfChatModel,
+			}
+			input := &model.CallbackInput{
+				Messages: []*schema.Message{schema.UserMessage("my email is foo@example.com")},
+			}
+
+			result := d.ParseInput(context.Background(), info, input)
+			convey.So(result[tracespec.Input], convey.ShouldContainSubstring, "my email is [EMAIL]")
+			convey.So(result[tracespec.Input], convey.ShouldNotContainSubstring, "foo@example.com")
+		})
+	})
+}
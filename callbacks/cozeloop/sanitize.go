@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cozeloop
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/coze-dev/cozeloop-go/spec/tracespec"
+)
+
+// truncateString cuts s to at most maxBytes bytes on a rune boundary and appends a marker with the original length.
+// A non-positive maxBytes disables truncation.
+func truncateString(s string, maxBytes int) string {
+	if maxBytes <= 0 || len(s) <= maxBytes {
+		return s
+	}
+
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return fmt.Sprintf("%s...[truncated, original length %d bytes]", s[:cut], len(s))
+}
+
+func rewriteModelMessage(msg *tracespec.ModelMessage, fn func(string) string) *tracespec.ModelMessage {
+	if msg == nil {
+		return msg
+	}
+
+	msg.Content = fn(msg.Content)
+	msg.ReasoningContent = fn(msg.ReasoningContent)
+
+	for _, part := range msg.Parts {
+		if part != nil {
+			part.Text = fn(part.Text)
+		}
+	}
+
+	for _, tc := range msg.ToolCalls {
+		if tc != nil && tc.Function != nil {
+			tc.Function.Arguments = fn(tc.Function.Arguments)
+		}
+	}
+
+	for k, v := range msg.Metadata {
+		msg.Metadata[k] = fn(v)
+	}
+
+	return msg
+}
+
+func rewriteModelInput(input *tracespec.ModelInput, fn func(string) string) *tracespec.ModelInput {
+	if input == nil {
+		return input
+	}
+
+	for _, msg := range input.Messages {
+		rewriteModelMessage(msg, fn)
+	}
+
+	return input
+}
+
+func rewriteModelOutput(output *tracespec.ModelOutput, fn func(string) string) *tracespec.ModelOutput {
+	if output == nil {
+		return output
+	}
+
+	for _, choice := range output.Choices {
+		if choice != nil {
+			rewriteModelMessage(choice.Message, fn)
+		}
+	}
+
+	return output
+}
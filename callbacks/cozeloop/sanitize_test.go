@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cozeloop
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/coze-dev/cozeloop-go/spec/tracespec"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func Test_truncateString(t *testing.T) {
+	mockey.PatchConvey("测试 truncateString", t, func() {
+		convey.So(truncateString("hello", 0), convey.ShouldEqual, "hello")
+		convey.So(truncateString("hello", 5), convey.ShouldEqual, "hello")
+		convey.So(truncateString("hello world", 5), convey.ShouldEqual, "hello...[truncated, original length 11 bytes]")
+		// 不会截断在多字节字符中间
+		convey.So(truncateString("你好世界", 4), convey.ShouldEqual, "你...[truncated, original length 12 bytes]")
+	})
+}
+
+func Test_defaultDataParser_maxFieldBytes(t *testing.T) {
+	mockey.PatchConvey("测试 WithMaxFieldBytes 截断超长字段", t, func() {
+		ctx := context.Background()
+		o := &options{}
+		WithMaxFieldBytes(10)(o)
+		d := newDefaultDataParserWithOptions(o)
+		long := strings.Repeat("a", 100)
+
+		mockey.PatchConvey("超长消息内容被截断", func() {
+			info := &callbacks.RunInfo{Component: components.ComponentOfChatModel}
+			result := d.ParseInput(ctx, info, &model.CallbackInput{
+				Messages: []*schema.Message{schema.UserMessage(long)},
+			})
+
+			convey.So(result[tracespec.Input], convey.ShouldContainSubstring, strings.Repeat("a", 10)+"...[truncated, original length 100 bytes]")
+			convey.So(result[tracespec.Input], convey.ShouldNotContainSubstring, strings.Repeat("a", 11))
+		})
+
+		mockey.PatchConvey("超长工具参数与结果被截断", func() {
+			info := &callbacks.RunInfo{Name: "t", Component: components.ComponentOfTool}
+
+			in := d.ParseInput(ctx, info, &tool.CallbackInput{ArgumentsInJSON: long})
+			convey.So(in[tracespec.Input], convey.ShouldContainSubstring, "original length 100 bytes")
+
+			out := d.ParseOutput(ctx, info, &tool.CallbackOutput{Response: long})
+			convey.So(out[tracespec.Output], convey.ShouldContainSubstring, "original length 100 bytes")
+		})
+
+		mockey.PatchConvey("超长文档内容被截断", func() {
+			info := &callbacks.RunInfo{Component: components.ComponentOfIndexer}
+			result := d.ParseInput(ctx, info, []*schema.Document{{ID: "1", Content: long}})
+
+			convey.So(result[tracespec.Input], convey.ShouldContainSubstring, "original length 100 bytes")
+		})
+	})
+}
+
+func Test_defaultDataParser_trimDocuments(t *testing.T) {
+	mockey.PatchConvey("测试 Retriever span 中的文档裁剪", t, func() {
+		ctx := context.Background()
+		info := &callbacks.RunInfo{Component: components.ComponentOfRetriever}
+		doc := func() *schema.Document {
+			return (&schema.Document{ID: "1", Content: strings.Repeat("b", 50)}).
+				WithDenseVector([]float64{0.1, 0.2, 0.3})
+		}
+
+		mockey.PatchConvey("默认不上报向量", func() {
+			d := newDefaultDataParserWithOptions(&options{})
+
+			result := d.ParseOutput(ctx, info, []*schema.Document{doc()})
+			convey.So(result[tracespec.Output], convey.ShouldNotContainSubstring, "0.2")
+			convey.So(result[tracespec.Output], convey.ShouldContainSubstring, strings.Repeat("b", 50))
+		})
+
+		mockey.PatchConvey("截断文档内容并可选上报向量", func() {
+			o := &options{}
+			WithMaxDocumentBytes(8)(o)
+			WithDocumentVector(true)(o)
+			d := newDefaultDataParserWithOptions(o)
+
+			result := d.ParseOutput(ctx, info, []*schema.Document{doc()})
+			convey.So(result[tracespec.Output], convey.ShouldContainSubstring, "0.2")
+			convey.So(result[tracespec.Output], convey.ShouldContainSubstring, strings.Repeat("b", 8)+"...[truncated, original length 50 bytes]")
+		})
+	})
+}
@@ -18,6 +18,7 @@ package cozeloop
 
 import (
 	"context"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -31,9 +32,12 @@ import (
 
 func newTraceCallbackHandler(client cozeloop.Client, o *options) callbacks.Handler {
 	tracer := &einoTracer{
-		client: client,
-		parser: newDefaultDataParserWithConcatFuncs(o.concatFuncs, o.enableAggrOutput),
-		logger: o.logger,
+		client:      client,
+		parser:      newDefaultDataParserWithOptions(o),
+		logger:      o.logger,
+		sampleRate:  o.sampleRate,
+		staticTags:  o.staticTags,
+		tagsFromCtx: o.tagsFromCtx,
 	}
 
 	if o.parser != nil {
@@ -60,10 +64,13 @@ func newTraceCallbackHandler(client cozeloop.Client, o *options) callbacks.Handl
 }
 
 type einoTracer struct {
-	client  cozeloop.Client
-	parser  CallbackDataParser
-	runtime *tracespec.Runtime
-	logger  cozeloop.Logger
+	client      cozeloop.Client
+	parser      CallbackDataParser
+	runtime     *tracespec.Runtime
+	logger      cozeloop.Logger
+	sampleRate  *float64
+	staticTags  map[string]string
+	tagsFromCtx func(ctx context.Context) map[string]string
 }
 
 type AggrMessageOutput struct {
@@ -88,6 +95,11 @@ func (l *einoTracer) OnStart(ctx context.Context, info *callbacks.RunInfo, input
 		return ctx
 	}
 
+	ctx, sampled := l.sample(ctx)
+	if !sampled {
+		return ctx
+	}
+
 	ctx = injectAggrMessageOutputHookToCtx(ctx)
 	ctx = injectGraphNodeLevelToCtx(ctx, getGraphNodeLevelFromCtx(ctx)+1)
 	ctx = injectToolIDNameMapToCtx(ctx, info, input)
@@ -100,6 +112,7 @@ func (l *einoTracer) OnStart(ctx context.Context, info *callbacks.RunInfo, input
 	ctx, span := l.client.StartSpan(ctx, spanName, parseSpanTypeFromComponent(info.Component))
 
 	l.setRunInfo(ctx, span, info)
+	l.setCustomTags(ctx, span)
 	l.setSpanContext(ctx, span)
 
 	if l.parser != nil {
@@ -112,7 +125,7 @@ func (l *einoTracer) OnStart(ctx context.Context, info *callbacks.RunInfo, input
 }
 
 func (l *einoTracer) OnEnd(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
-	if info == nil {
+	if info == nil || !l.isSampled(ctx) {
 		return ctx
 	}
 
@@ -139,7 +152,7 @@ func (l *einoTracer) OnEnd(ctx context.Context, info *callbacks.RunInfo, output
 }
 
 func (l *einoTracer) OnError(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
-	if info == nil {
+	if info == nil || !l.isSampled(ctx) {
 		return ctx
 	}
 
@@ -168,6 +181,12 @@ func (l *einoTracer) OnStartWithStreamInput(ctx context.Context, info *callbacks
 		return ctx
 	}
 
+	ctx, sampled := l.sample(ctx)
+	if !sampled {
+		input.Close()
+		return ctx
+	}
+
 	ctx = injectAggrMessageOutputHookToCtx(ctx)
 	ctx = injectGraphNodeLevelToCtx(ctx, getGraphNodeLevelFromCtx(ctx)+1)
 
@@ -181,6 +200,7 @@ func (l *einoTracer) OnStartWithStreamInput(ctx context.Context, info *callbacks
 	ctx = context.WithValue(ctx, async.TraceStreamInputAsyncKey{}, stopCh)
 
 	l.setRunInfo(ctx, span, info)
+	l.setCustomTags(ctx, span)
 	l.setSpanContext(ctx, span)
 
 	if l.parser != nil {
@@ -206,7 +226,7 @@ func (l *einoTracer) OnStartWithStreamInput(ctx context.Context, info *callbacks
 }
 
 func (l *einoTracer) OnEndWithStreamOutput(ctx context.Context, info *callbacks.RunInfo, output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
-	if info == nil {
+	if info == nil || !l.isSampled(ctx) {
 		output.Close()
 		return ctx
 	}
@@ -247,6 +267,26 @@ func (l *einoTracer) OnEndWithStreamOutput(ctx context.Context, info *callbacks.
 	return ctx
 }
 
+// sample makes a head-based sampling decision at the root span, child spans inherit the decision from ctx.
+func (l *einoTracer) sample(ctx context.Context) (context.Context, bool) {
+	if sampled, ok := getSampledFromCtx(ctx); ok {
+		return ctx, sampled
+	}
+
+	if l.sampleRate == nil {
+		return ctx, true
+	}
+
+	sampled := *l.sampleRate >= 1 || (*l.sampleRate > 0 && rand.Float64() < *l.sampleRate)
+
+	return injectSampledToCtx(ctx, sampled), sampled
+}
+
+func (l *einoTracer) isSampled(ctx context.Context) bool {
+	sampled, ok := getSampledFromCtx(ctx)
+	return !ok || sampled
+}
+
 func (l *einoTracer) setRunInfo(ctx context.Context, span cozeloop.Span, info *callbacks.RunInfo) {
 	span.SetTags(ctx, make(spanTags).
 		set(consts.CustomSpanTagKeyComponent, string(info.Component)).
@@ -259,6 +299,27 @@ func (l *einoTracer) setRunInfo(ctx context.Context, span cozeloop.Span, info *c
 	}
 }
 
+func (l *einoTracer) setCustomTags(ctx context.Context, span cozeloop.Span) {
+	if len(l.staticTags) == 0 && l.tagsFromCtx == nil {
+		return
+	}
+
+	tags := make(map[string]any, len(l.staticTags))
+	for k, v := range l.staticTags {
+		tags[k] = v
+	}
+
+	if l.tagsFromCtx != nil {
+		for k, v := range l.tagsFromCtx(ctx) {
+			tags[k] = v
+		}
+	}
+
+	if len(tags) > 0 {
+		span.SetTags(ctx, tags)
+	}
+}
+
 func (l *einoTracer) setSpanContext(ctx context.Context, span cozeloop.Span) {
 	spanContextImpl := getSpanContextImpl(ctx)
 	if spanContextImpl != nil && !spanContextImpl.isSet {
@@ -25,6 +25,10 @@ import (
 	"github.com/bytedance/mockey"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
 	"github.com/coze-dev/cozeloop-go"
 	"github.com/coze-dev/cozeloop-go/spec/tracespec"
 	"github.com/smartystreets/goconvey/convey"
@@ -135,3 +139,199 @@ func Test_einoTracer_OnError(t *testing.T) {
 		})
 	})
 }
+
+func Test_einoTracer_Embedding(t *testing.T) {
+	os.Setenv(cozeloop.EnvWorkspaceID, "1234567890")
+	os.Setenv(cozeloop.EnvApiToken, "xxxx")
+	mockey.PatchConvey("测试einoTracer处理Embedding组件", t, func() {
+		client, err := cozeloop.NewClient()
+		if err != nil {
+			return
+		}
+		l := &einoTracer{
+			client:  client,
+			parser:  NewDefaultDataParser(false),
+			runtime: &tracespec.Runtime{},
+		}
+
+		ctx := context.Background()
+		info := &callbacks.RunInfo{
+			Name:      "testEmbedding",
+			Type:      "testType",
+			Component: components.ComponentOfEmbedding,
+		}
+		input := &embedding.CallbackInput{
+			Texts:  []string{"hello"},
+			Config: &embedding.Config{Model: "test-embedding"},
+		}
+		output := &embedding.CallbackOutput{
+			Embeddings: [][]float64{{0.1, 0.2}},
+			Config:     &embedding.Config{Model: "test-embedding"},
+			TokenUsage: &embedding.TokenUsage{PromptTokens: 1, TotalTokens: 1},
+		}
+
+		ctx = l.OnStart(ctx, info, input)
+		convey.So(ctx, convey.ShouldNotBeNil)
+
+		result := l.OnEnd(ctx, info, output)
+		convey.So(result, convey.ShouldNotBeNil)
+	})
+}
+
+func Test_einoTracer_IndexerAndTool(t *testing.T) {
+	os.Setenv(cozeloop.EnvWorkspaceID, "1234567890")
+	os.Setenv(cozeloop.EnvApiToken, "xxxx")
+	mockey.PatchConvey("测试einoTracer处理Indexer和Tool组件", t, func() {
+		client, err := cozeloop.NewClient()
+		if err != nil {
+			return
+		}
+		l := &einoTracer{
+			client:  client,
+			parser:  NewDefaultDataParser(false),
+			runtime: &tracespec.Runtime{},
+		}
+
+		mockey.PatchConvey("Indexer 场景", func() {
+			info := &callbacks.RunInfo{
+				Name:      "testIndexer",
+				Type:      "testType",
+				Component: components.ComponentOfIndexer,
+			}
+
+			ctx := l.OnStart(context.Background(), info, &indexer.CallbackInput{
+				Docs: []*schema.Document{{ID: "1", Content: "doc"}},
+			})
+			convey.So(ctx, convey.ShouldNotBeNil)
+
+			result := l.OnEnd(ctx, info, &indexer.CallbackOutput{IDs: []string{"1"}})
+			convey.So(result, convey.ShouldNotBeNil)
+		})
+
+		mockey.PatchConvey("Tool 场景", func() {
+			info := &callbacks.RunInfo{
+				Name:      "get_weather",
+				Type:      "testType",
+				Component: components.ComponentOfTool,
+			}
+
+			ctx := l.OnStart(context.Background(), info, &tool.CallbackInput{ArgumentsInJSON: `{"city":"beijing"}`})
+			convey.So(ctx, convey.ShouldNotBeNil)
+
+			result := l.OnEnd(ctx, info, &tool.CallbackOutput{Response: "sunny"})
+			convey.So(result, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func Test_einoTracer_sample(t *testing.T) {
+	mockey.PatchConvey("测试einoTracer的采样", t, func() {
+		ctx := context.Background()
+		rate := func(r float64) *float64 { return &r }
+
+		mockey.PatchConvey("未设置采样率时全部采样", func() {
+			l := &einoTracer{}
+			for i := 0; i < 100; i++ {
+				_, sampled := l.sample(ctx)
+				convey.So(sampled, convey.ShouldBeTrue)
+			}
+		})
+
+		mockey.PatchConvey("采样率为0时全部丢弃", func() {
+			l := &einoTracer{sampleRate: rate(0)}
+			for i := 0; i < 100; i++ {
+				nCtx, sampled := l.sample(ctx)
+				convey.So(sampled, convey.ShouldBeFalse)
+				convey.So(l.isSampled(nCtx), convey.ShouldBeFalse)
+			}
+		})
+
+		mockey.PatchConvey("采样率为1时全部采样", func() {
+			l := &einoTracer{sampleRate: rate(1)}
+			for i := 0; i < 100; i++ {
+				nCtx, sampled := l.sample(ctx)
+				convey.So(sampled, convey.ShouldBeTrue)
+				convey.So(l.isSampled(nCtx), convey.ShouldBeTrue)
+			}
+		})
+
+		mockey.PatchConvey("子span继承父span的采样决策", func() {
+			parent := &einoTracer{sampleRate: rate(0)}
+			child := &einoTracer{sampleRate: rate(1)}
+
+			pCtx, sampled := parent.sample(ctx)
+			convey.So(sampled, convey.ShouldBeFalse)
+
+			_, sampled = child.sample(pCtx)
+			convey.So(sampled, convey.ShouldBeFalse)
+		})
+
+		mockey.PatchConvey("未采样时OnStart/OnEnd不创建span", func() {
+			l := &einoTracer{sampleRate: rate(0)}
+			info := &callbacks.RunInfo{
+				Name:      "testName",
+				Component: components.Component("testComponent"),
+			}
+
+			nCtx := l.OnStart(ctx, info, nil)
+			convey.So(l.isSampled(nCtx), convey.ShouldBeFalse)
+			convey.So(l.OnEnd(nCtx, info, nil), convey.ShouldEqual, nCtx)
+			convey.So(l.OnError(nCtx, info, errors.New("err")), convey.ShouldEqual, nCtx)
+		})
+	})
+}
+
+type tagRecordingSpan struct {
+	cozeloop.Span
+	tags map[string]any
+}
+
+func (s *tagRecordingSpan) SetTags(_ context.Context, tags map[string]any) {
+	if s.tags == nil {
+		s.tags = make(map[string]any)
+	}
+	for k, v := range tags {
+		s.tags[k] = v
+	}
+}
+
+type tenantKey struct{}
+
+func Test_einoTracer_setCustomTags(t *testing.T) {
+	mockey.PatchConvey("测试einoTracer的自定义标签", t, func() {
+		o := &options{}
+		WithStaticTags(map[string]string{"env": "prod", "tenant": "default"})(o)
+		WithTagsFromContext(func(ctx context.Context) map[string]string {
+			if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+				return map[string]string{"tenant": tenant}
+			}
+			return nil
+		})(o)
+
+		l := &einoTracer{staticTags: o.staticTags, tagsFromCtx: o.tagsFromCtx}
+
+		mockey.PatchConvey("静态标签与上下文标签合并，上下文标签优先", func() {
+			span := &tagRecordingSpan{}
+			ctx := context.WithValue(context.Background(), tenantKey{}, "t1")
+
+			l.setCustomTags(ctx, span)
+			convey.So(span.tags["env"], convey.ShouldEqual, "prod")
+			convey.So(span.tags["tenant"], convey.ShouldEqual, "t1")
+		})
+
+		mockey.PatchConvey("上下文无标签时仅有静态标签", func() {
+			span := &tagRecordingSpan{}
+
+			l.setCustomTags(context.Background(), span)
+			convey.So(span.tags["env"], convey.ShouldEqual, "prod")
+			convey.So(span.tags["tenant"], convey.ShouldEqual, "default")
+		})
+
+		mockey.PatchConvey("未配置时不设置标签", func() {
+			span := &tagRecordingSpan{}
+
+			(&einoTracer{}).setCustomTags(context.Background(), span)
+			convey.So(span.tags, convey.ShouldBeNil)
+		})
+	})
+}
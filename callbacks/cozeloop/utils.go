@@ -33,8 +33,15 @@ import (
 )
 
 func getErrorTags(_ context.Context, err error) spanTags {
-	return make(spanTags).
+	tags := make(spanTags).
 		set(tracespec.Error, err.Error())
+
+	detail := extractErrorDetail(err)
+	tags.setIfNotZero(consts.CustomSpanTagKeyErrorType, detail.typ)
+	tags.setIfNotZero(consts.CustomSpanTagKeyErrorCode, detail.code)
+	tags.setIfNotZero(consts.CustomSpanTagKeyErrorStatusCode, detail.statusCode)
+
+	return tags
 }
 
 type spanTags map[string]any
@@ -174,3 +181,12 @@ func getToolIDNameMapFromCtx(ctx context.Context) map[string]string {
 
 	return nil
 }
+
+func injectSampledToCtx(ctx context.Context, sampled bool) context.Context {
+	return context.WithValue(ctx, consts.CozeLoopSampled, sampled)
+}
+
+func getSampledFromCtx(ctx context.Context) (sampled bool, ok bool) {
+	sampled, ok = ctx.Value(consts.CozeLoopSampled).(bool)
+	return sampled, ok
+}
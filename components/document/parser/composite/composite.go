@@ -0,0 +1,193 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package composite provides a parser that dispatches each document to the
+// parser registered for its file extension or MIME type.
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/csv"
+	"github.com/cloudwego/eino-ext/components/document/parser/doc"
+	"github.com/cloudwego/eino-ext/components/document/parser/pdf"
+	"github.com/cloudwego/eino-ext/components/document/parser/xlsx"
+)
+
+// ErrUnknownType is returned, wrapped, by Parse when no parser is registered for
+// the extension or MIME type of a document.
+var ErrUnknownType = errors.New("unknown document type")
+
+type Config struct {
+	// Optional.
+	// Parsers maps file extensions such as ".md" or MIME types such as "text/markdown"
+	// to parsers, added to the default csv, xlsx, docx and pdf parsers or replacing them.
+	Parsers map[string]parser.Parser
+
+	// Optional. Default: nil, which makes Parse fail with ErrUnknownType.
+	// FallbackParser parses the documents no registered parser matches.
+	FallbackParser parser.Parser
+
+	// Optional. Default: false.
+	// DisableSniffing turns off the detection of the document type from its
+	// leading bytes, so that only WithMIMEType and the extension are used.
+	DisableSniffing bool
+}
+
+// Parser dispatches each document to the parser registered for its MIME type,
+// given with WithMIMEType, or else for the type sniffed from its content, or
+// else for the extension of its URI, given with parser.WithURI.
+type Parser struct {
+	mu       sync.RWMutex
+	parsers  map[string]parser.Parser
+	fallback parser.Parser
+	noSniff  bool
+}
+
+// NewParser creates a composite parser with the csv, xlsx, docx and pdf parsers
+// registered under their extensions and MIME types.
+func NewParser(ctx context.Context, config *Config) (*Parser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	csvParser, err := csv.NewCsvParser()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create csv parser: %w", err)
+	}
+	xlsxParser, err := xlsx.NewXlsxParser(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create xlsx parser: %w", err)
+	}
+	docParser, err := doc.NewDocParser()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create doc parser: %w", err)
+	}
+	pdfParser, err := pdf.NewPDFParser(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pdf parser: %w", err)
+	}
+
+	p := &Parser{
+		parsers:  make(map[string]parser.Parser),
+		fallback: config.FallbackParser,
+		noSniff:  config.DisableSniffing,
+	}
+	p.Register(csvParser, ".csv", "text/csv")
+	p.Register(xlsxParser, ".xlsx", mimeXLSX)
+	p.Register(docParser, ".docx", mimeDOCX)
+	p.Register(pdfParser, ".pdf", mimePDF)
+	for key, pr := range config.Parsers {
+		p.Register(pr, key)
+	}
+
+	return p, nil
+}
+
+// Register makes pr parse the documents with any of the given extensions, such
+// as ".md", or MIME types, such as "text/markdown", replacing the parser
+// previously registered for them. Keys are matched case-insensitively.
+func (p *Parser) Register(pr parser.Parser, keys ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, key := range keys {
+		p.parsers[normalizeKey(key)] = pr
+	}
+}
+
+// Parse parses reader with the parser registered for the MIME type of the
+// document or, when there is none, for the type sniffed from its content or
+// for the extension of its URI, so that renamed files still reach the right
+// parser.
+func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	commonOpts := parser.GetCommonOptions(&parser.Options{}, opts...)
+	specificOpts := parser.GetImplSpecificOptions(&options{}, opts...)
+
+	var sniffed string
+	if !p.noSniff && !p.has(specificOpts.mimeType) {
+		var err error
+		sniffed, reader, err = sniff(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect document type: %w", err)
+		}
+	}
+
+	pr, err := p.resolve(specificOpts.mimeType, sniffed, filepath.Ext(commonOpts.URI))
+	if err != nil {
+		return nil, err
+	}
+
+	return pr.Parse(ctx, reader, opts...)
+}
+
+func (p *Parser) has(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	_, ok := p.parsers[normalizeKey(key)]
+	return ok
+}
+
+func (p *Parser) resolve(mimeType, sniffed, ext string) (parser.Parser, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, key := range []string{mimeType, sniffed} {
+		if key == "" {
+			continue
+		}
+		if pr, ok := p.parsers[normalizeKey(key)]; ok {
+			return pr, nil
+		}
+	}
+	if ext != "" {
+		if pr, ok := p.parsers[normalizeKey(ext)]; ok {
+			return pr, nil
+		}
+	}
+	if p.fallback != nil {
+		return p.fallback, nil
+	}
+
+	return nil, fmt.Errorf("%w: no parser registered for extension %q or MIME type %q", ErrUnknownType, ext, mimeType)
+}
+
+// normalizeKey lower-cases key and drops the parameters of a MIME type, such
+// as the charset of "text/csv; charset=utf-8".
+func normalizeKey(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if strings.Contains(key, "/") {
+		if mediaType, _, err := mime.ParseMediaType(key); err == nil {
+			return mediaType
+		}
+	}
+	return key
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package composite
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/xlsx"
+)
+
+func TestParser_Parse(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewParser(ctx, nil)
+	assert.NoError(t, err)
+
+	t.Run("csv by extension", func(t *testing.T) {
+		docs, err := p.Parse(ctx, strings.NewReader("name,age\nalice,30\n"), parser.WithURI("./data/People.CSV"))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "name: alice\nage: 30", docs[0].Content)
+	})
+
+	t.Run("xlsx by MIME type", func(t *testing.T) {
+		f, err := os.Open("./testdata/test.xlsx")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		docs, err := p.Parse(ctx, f,
+			parser.WithURI("upload.bin"),
+			WithMIMEType("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, docs)
+		assert.Equal(t, map[string]any{"年龄": "21", "性别": "男", "姓名": "张三"}, docs[0].MetaData[xlsx.MetaDataRow])
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := p.Parse(ctx, strings.NewReader("hello"), parser.WithURI("notes.md"))
+		assert.ErrorIs(t, err, ErrUnknownType)
+		assert.ErrorContains(t, err, `".md"`)
+	})
+
+	t.Run("custom parser", func(t *testing.T) {
+		custom, err := NewParser(ctx, &Config{Parsers: map[string]parser.Parser{".md": parser.TextParser{}}})
+		assert.NoError(t, err)
+		custom.Register(parser.TextParser{}, "text/plain")
+
+		docs, err := custom.Parse(ctx, strings.NewReader("# title"), parser.WithURI("notes.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, "# title", docs[0].Content)
+
+		docs, err = custom.Parse(ctx, strings.NewReader("plain"), WithMIMEType("text/plain; charset=utf-8"))
+		assert.NoError(t, err)
+		assert.Equal(t, "plain", docs[0].Content)
+	})
+}
+
+func TestParser_Sniffing(t *testing.T) {
+	ctx := context.Background()
+	xlsxData, err := os.ReadFile("./testdata/test.xlsx")
+	assert.NoError(t, err)
+
+	t.Run("mislabeled xlsx", func(t *testing.T) {
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(xlsxData), parser.WithURI("report.csv"))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, docs)
+		assert.Equal(t, map[string]any{"年龄": "21", "性别": "男", "姓名": "张三"}, docs[0].MetaData[xlsx.MetaDataRow])
+	})
+
+	t.Run("falls back to extension", func(t *testing.T) {
+		p, err := NewParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader("name\nalice\n"), parser.WithURI("people.csv"))
+		assert.NoError(t, err)
+		assert.Equal(t, "name: alice", docs[0].Content)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{
+			Parsers:         map[string]parser.Parser{".txt": parser.TextParser{}},
+			DisableSniffing: true,
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader("%PDF-1.4"), parser.WithURI("notes.txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, "%PDF-1.4", docs[0].Content)
+	})
+}
+
+func TestSniff(t *testing.T) {
+	zipWith := func(name string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		_, err := zw.Create("[Content_Types].xml")
+		assert.NoError(t, err)
+		_, err = zw.Create(name)
+		assert.NoError(t, err)
+		assert.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+
+	for name, tc := range map[string]struct {
+		data []byte
+		want string
+	}{
+		"pdf":   {[]byte("%PDF-1.7\n..."), mimePDF},
+		"docx":  {zipWith("word/document.xml"), mimeDOCX},
+		"xlsx":  {zipWith("xl/workbook.xml"), mimeXLSX},
+		"zip":   {zipWith("readme.txt"), ""},
+		"ole":   {append([]byte(nil), magicOLE...), mimeOLE},
+		"text":  {[]byte("a,b\n1,2\n"), ""},
+		"short": {[]byte("PK"), ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, r, err := sniff(bytes.NewReader(tc.data))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+
+			rest, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.data, rest)
+		})
+	}
+}
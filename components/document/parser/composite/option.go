@@ -0,0 +1,31 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package composite
+
+import "github.com/cloudwego/eino/components/document/parser"
+
+type options struct {
+	mimeType string
+}
+
+// WithMIMEType is a parser option that specifies the MIME type of the document,
+// such as "text/csv". It takes precedence over the extension of the URI.
+func WithMIMEType(mimeType string) parser.Option {
+	return parser.WrapImplSpecificOptFn(func(opts *options) {
+		opts.mimeType = mimeType
+	})
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2026 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package composite
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+const (
+	mimePDF  = "application/pdf"
+	mimeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	mimeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	// mimeOLE covers the legacy binary Office formats, such as .doc and .xls.
+	mimeOLE = "application/x-ole-storage"
+)
+
+var (
+	magicPDF = []byte("%PDF-")
+	magicZIP = []byte("PK\x03\x04")
+	magicOLE = []byte("\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1")
+)
+
+// sniff detects the MIME type of the document read by r from its leading
+// bytes, returning "" when it is not recognized, together with a reader that
+// still yields the whole document. Office Open XML documents are ZIP archives
+// told apart by their entries, so they are read into memory to be inspected.
+func sniff(r io.Reader) (string, io.Reader, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(len(magicOLE))
+	if err != nil && err != io.EOF {
+		return "", nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(head, magicPDF):
+		return mimePDF, br, nil
+	case bytes.HasPrefix(head, magicOLE):
+		return mimeOLE, br, nil
+	case bytes.HasPrefix(head, magicZIP):
+		data, err := io.ReadAll(br)
+		if err != nil {
+			return "", nil, err
+		}
+		return sniffZIP(data), bytes.NewReader(data), nil
+	default:
+		return "", br, nil
+	}
+}
+
+func sniffZIP(data []byte) string {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return ""
+	}
+	for _, f := range zr.File {
+		switch {
+		case strings.HasPrefix(f.Name, "word/"):
+			return mimeDOCX
+		case strings.HasPrefix(f.Name, "xl/"):
+			return mimeXLSX
+		}
+	}
+	return ""
+}
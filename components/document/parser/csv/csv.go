@@ -6,13 +6,48 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
 )
 
+// MetaDataValues is the metadata key of the typed cell values of a row, a
+// map[string]any from column name to value, set when WithInferTypes is used.
+const MetaDataValues = "values"
+
+// RowError reports a malformed row skipped in lenient mode.
+type RowError struct {
+	// Row is the number of the data row, counted like the "row" metadata.
+	Row int
+	// Line is the line of the file where the row starts.
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// RowErrors is returned by Parse in lenient mode, alongside the parsed
+// documents, when some rows were skipped.
+type RowErrors []*RowError
+
+func (e RowErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d malformed rows skipped: %s", len(e), strings.Join(msgs, "; "))
+}
+
 // CsvParser reads from io.Reader and parse its content as plain text.
 // Attention: This is in alpha stage, and may not support all csv use cases well enough.
 // For example, it will not preserve whitespace and new line for now.
@@ -26,17 +61,67 @@ func NewCsvParser(columns ...string) (*CsvParser, error) {
 }
 
 func (cp *CsvParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) (docs []*schema.Document, err error) {
+	err = cp.parse(ctx, reader, opts, func(doc *schema.Document) bool {
+		docs = append(docs, doc)
+		return true
+	})
+	var rowErrs RowErrors
+	if err != nil && !errors.As(err, &rowErrs) {
+		return nil, err
+	}
+
+	return docs, err
+}
+
+// ParseStream is like Parse, but emits the document of each row as soon as it is
+// read instead of holding every document in memory, for files too large to parse
+// at once. Parsing stops when ctx is done, in which case the stream reports the
+// context error, or when the returned stream is closed. In lenient mode, the
+// RowErrors of the skipped rows are reported once every row has been emitted.
+func (cp *CsvParser) ParseStream(ctx context.Context, reader io.Reader, opts ...parser.Option) (*schema.StreamReader[*schema.Document], error) {
+	sr, sw := schema.Pipe[*schema.Document](1)
+	go func() {
+		defer sw.Close()
+
+		err := cp.parse(ctx, reader, opts, func(doc *schema.Document) bool {
+			return !sw.Send(doc, nil)
+		})
+		if err != nil {
+			sw.Send(nil, err)
+		}
+	}()
+
+	return sr, nil
+}
+
+// parse reads reader row by row and passes the document of each row to emit,
+// stopping early once emit returns false.
+func (cp *CsvParser) parse(ctx context.Context, reader io.Reader, opts []parser.Option, emit func(*schema.Document) bool) error {
 	var header []string
 	var rown int
+	var rowErrs RowErrors
+
+	option := parser.GetCommonOptions(&parser.Options{}, opts...)
+	specificOpts := parser.GetImplSpecificOptions(&options{}, opts...)
 
 	rd := csv.NewReader(reader)
 	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		row, err := rd.Read()
 		if errors.Is(err, io.EOF) {
 			break
 		}
 		if err != nil {
-			return nil, err
+			var parseErr *csv.ParseError
+			if !specificOpts.lenient || len(header) == 0 || !errors.As(err, &parseErr) {
+				return err
+			}
+			rown++
+			rowErrs = append(rowErrs, &RowError{Row: rown, Line: parseErr.StartLine, Err: err})
+			continue
 		}
 		if len(header) == 0 {
 			header = append(header, row...)
@@ -44,6 +129,10 @@ func (cp *CsvParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 		}
 
 		var content []string
+		var values map[string]any
+		if specificOpts.inferTypes {
+			values = make(map[string]any, len(row))
+		}
 		for i, value := range row {
 			if len(cp.columns) > 0 &&
 				!slices.Contains(cp.columns, header[i]) {
@@ -52,23 +141,53 @@ func (cp *CsvParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 
 			line := fmt.Sprintf("%s: %s", header[i], value)
 			content = append(content, line)
+			if values != nil {
+				values[header[i]] = inferType(value)
+			}
 		}
 
 		rown++
 
 		meta := make(map[string]any, 0)
-		option := parser.GetCommonOptions(&parser.Options{}, opts...)
 		if option.ExtraMeta != nil {
 			for k, v := range option.ExtraMeta {
 				meta[k] = v
 			}
 		}
 		meta["row"] = rown
-		docs = append(docs, &schema.Document{
+		if values != nil {
+			meta[MetaDataValues] = values
+		}
+		if !emit(&schema.Document{
 			Content:  strings.Join(content, "\n"),
 			MetaData: meta,
-		})
+		}) {
+			return nil
+		}
 	}
 
-	return docs, nil
+	if len(rowErrs) > 0 {
+		return rowErrs
+	}
+	return nil
+}
+
+// inferType converts a cell value to an int, a float64 or a bool when it reads
+// as one, and returns it unchanged otherwise.
+func inferType(value string) any {
+	s := strings.TrimSpace(value)
+	if i, err := strconv.Atoi(s); err == nil {
+		return i
+	}
+	// ParseFloat also accepts words such as "NaN" and "Inf", which are kept as text.
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return f
+	}
+	switch strings.ToLower(s) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return value
 }
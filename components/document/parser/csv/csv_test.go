@@ -0,0 +1,146 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCSV = "name,age,city\nalice,30,paris\nbob,25,berlin\ncarol,41,rome\n"
+
+func TestCsvParser_Parse(t *testing.T) {
+	p, err := NewCsvParser("name", "city")
+	assert.NoError(t, err)
+
+	docs, err := p.Parse(context.Background(), strings.NewReader(testCSV), parser.WithExtraMeta(map[string]any{"source": "test"}))
+	assert.NoError(t, err)
+	assert.Len(t, docs, 3)
+	assert.Equal(t, "name: alice\ncity: paris", docs[0].Content)
+	assert.Equal(t, map[string]any{"source": "test", "row": 1}, docs[0].MetaData)
+}
+
+func TestCsvParser_ParseStream(t *testing.T) {
+	t.Run("streams rows", func(t *testing.T) {
+		p, err := NewCsvParser("name", "city")
+		assert.NoError(t, err)
+
+		sr, err := p.ParseStream(context.Background(), strings.NewReader(testCSV))
+		assert.NoError(t, err)
+		defer sr.Close()
+
+		var docs []string
+		for {
+			doc, err := sr.Recv()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, len(docs)+1, doc.MetaData["row"])
+			docs = append(docs, doc.Content)
+		}
+		assert.Equal(t, []string{"name: alice\ncity: paris", "name: bob\ncity: berlin", "name: carol\ncity: rome"}, docs)
+	})
+
+	t.Run("stops on cancellation", func(t *testing.T) {
+		p, err := NewCsvParser()
+		assert.NoError(t, err)
+
+		pr, pw := io.Pipe()
+		defer pw.Close()
+		go func() {
+			_, _ = io.WriteString(pw, "id\n")
+			for {
+				if _, err := io.WriteString(pw, "row\n"); err != nil {
+					return
+				}
+			}
+		}()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		sr, err := p.ParseStream(ctx, pr)
+		assert.NoError(t, err)
+		defer sr.Close()
+
+		doc, err := sr.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, "id: row", doc.Content)
+
+		cancel()
+		for {
+			_, err = sr.Recv()
+			if err != nil {
+				break
+			}
+		}
+		assert.ErrorIs(t, err, context.Canceled)
+		_ = pr.Close()
+	})
+}
+
+func TestCsvParser_InferTypes(t *testing.T) {
+	p, err := NewCsvParser()
+	assert.NoError(t, err)
+
+	body := "name,age,score,active,note\nalice, 30 ,9.5,TRUE,NaN\n"
+	docs, err := p.Parse(context.Background(), strings.NewReader(body), WithInferTypes(true))
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, "name: alice\nage:  30 \nscore: 9.5\nactive: TRUE\nnote: NaN", docs[0].Content)
+	assert.Equal(t, map[string]any{
+		"name":   "alice",
+		"age":    30,
+		"score":  9.5,
+		"active": true,
+		"note":   "NaN",
+	}, docs[0].MetaData[MetaDataValues])
+
+	docs, err = p.Parse(context.Background(), strings.NewReader(body))
+	assert.NoError(t, err)
+	assert.NotContains(t, docs[0].MetaData, MetaDataValues)
+}
+
+func TestCsvParser_Lenient(t *testing.T) {
+	p, err := NewCsvParser()
+	assert.NoError(t, err)
+
+	body := "name,age\nalice,30\nbob,\"3\"0\ndave\ncarol,41\n"
+
+	_, err = p.Parse(context.Background(), strings.NewReader(body))
+	assert.Error(t, err)
+
+	docs, err := p.Parse(context.Background(), strings.NewReader(body), WithLenient(true))
+	assert.Len(t, docs, 2)
+	assert.Equal(t, "name: alice\nage: 30", docs[0].Content)
+	assert.Equal(t, "name: carol\nage: 41", docs[1].Content)
+	assert.Equal(t, 4, docs[1].MetaData["row"])
+
+	var rowErrs RowErrors
+	assert.True(t, errors.As(err, &rowErrs))
+	assert.Len(t, rowErrs, 2)
+	assert.Equal(t, 2, rowErrs[0].Row)
+	assert.Equal(t, 3, rowErrs[0].Line)
+	assert.Equal(t, 3, rowErrs[1].Row)
+	assert.ErrorIs(t, rowErrs[1], csv.ErrFieldCount)
+}
@@ -7,6 +7,7 @@ toolchain go1.24.2
 require (
 	code.sajari.com/docconv/v2 v2.0.0-pre.4
 	github.com/cloudwego/eino v0.3.20
+	github.com/stretchr/testify v1.9.0
 	github.com/xuri/excelize/v2 v2.9.0
 )
 
@@ -19,6 +20,7 @@ require (
 	github.com/bytedance/sonic v1.13.2 // indirect
 	github.com/bytedance/sonic/loader v0.2.4 // indirect
 	github.com/cloudwego/base64x v0.1.5 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/dustin/go-humanize v1.0.1 // indirect
 	github.com/fatih/set v0.2.1 // indirect
 	github.com/getkin/kin-openapi v0.118.0 // indirect
@@ -44,6 +46,7 @@ require (
 	github.com/pelletier/go-toml/v2 v2.0.9 // indirect
 	github.com/perimeterx/marshmallow v1.1.4 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/richardlehane/mscfb v1.0.4 // indirect
 	github.com/richardlehane/msoleps v1.0.4 // indirect
 	github.com/sirupsen/logrus v1.9.3 // indirect
@@ -0,0 +1,26 @@
+package csv
+
+import "github.com/cloudwego/eino/components/document/parser"
+
+type options struct {
+	inferTypes bool
+	lenient    bool
+}
+
+// WithInferTypes is a parser option that specifies whether to infer the types of
+// the cell values of each row and store them in the document metadata under
+// MetaDataValues, as int, float64, bool or string values.
+func WithInferTypes(inferTypes bool) parser.Option {
+	return parser.WrapImplSpecificOptFn(func(opts *options) {
+		opts.inferTypes = inferTypes
+	})
+}
+
+// WithLenient is a parser option that specifies whether to skip malformed rows
+// instead of aborting on the first one. The rows that do parse are returned
+// together with a RowErrors error listing the skipped ones.
+func WithLenient(lenient bool) parser.Option {
+	return parser.WrapImplSpecificOptFn(func(opts *options) {
+		opts.lenient = lenient
+	})
+}
@@ -59,6 +59,8 @@ type EmbeddingConfig struct {
 	Model string `json:"model"`
 }
 
+var _ embedding.Embedder = (*Embedder)(nil)
+
 type Embedder struct {
 	config *EmbeddingConfig
 }
@@ -96,8 +98,12 @@ func NewEmbedder(ctx context.Context, config *EmbeddingConfig) (*Embedder, error
 */
 func (e *Embedder) EmbedMultiModal(ctx context.Context, texts []map[string]string, opts ...embedding.Option) ([][]float64, error) {
 
+	options := embedding.GetCommonOptions(&embedding.Options{
+		Model: &e.config.Model,
+	}, opts...)
+
 	config := &RequestConfig{
-		Model:  e.config.Model,
+		Model:  *options.Model,
 		ApiKey: e.config.APIKey,
 		Input: &RequestConfigInput{
 			Contents: make([]map[string]string, 0),
@@ -123,6 +129,60 @@ func (e *Embedder) EmbedMultiModal(ctx context.Context, texts []map[string]strin
 
 }
 
+// EmbedStrings embeds each text as a separate input, the returned vectors keep the order of texts.
+// embedding.WithModel overrides the configured model for the call.
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	contents := make([]map[string]string, len(texts))
+	for i, text := range texts {
+		contents[i] = map[string]string{"text": text}
+	}
+
+	return e.EmbedMultiModal(ctx, contents, opts...)
+}
+
+// EmbedImages embeds each image url as a separate input, the returned vectors keep the order of urls.
+func (e *Embedder) EmbedImages(ctx context.Context, urls []string, opts ...embedding.Option) ([][]float64, error) {
+	contents := make([]map[string]string, len(urls))
+	for i, url := range urls {
+		contents[i] = map[string]string{"image": url}
+	}
+
+	return e.EmbedMultiModal(ctx, contents, opts...)
+}
+
+// TextImagePair is a caption and an image url embedded together into a single vector.
+type TextImagePair struct {
+	Text  string
+	Image string
+}
+
+// EmbedTextImagePairs embeds each pair as one content item carrying both the text and the image,
+// returning one vector per pair in the order of pairs. Empty fields are left out of the item.
+func (e *Embedder) EmbedTextImagePairs(ctx context.Context, pairs []TextImagePair, opts ...embedding.Option) ([][]float64, error) {
+	contents := make([]map[string]string, len(pairs))
+	for i, pair := range pairs {
+		content := make(map[string]string, 2)
+		if len(pair.Text) > 0 {
+			content["text"] = pair.Text
+		}
+		if len(pair.Image) > 0 {
+			content["image"] = pair.Image
+		}
+		contents[i] = content
+	}
+
+	embeddings, err := e.EmbedMultiModal(ctx, contents, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(embeddings) != len(pairs) {
+		return nil, fmt.Errorf("invalid return length of vector, got=%d, expected=%d", len(embeddings), len(pairs))
+	}
+
+	return embeddings, nil
+}
+
 const typ = "BaiLian"
 
 func (e *Embedder) GetType() string {
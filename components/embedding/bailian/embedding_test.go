@@ -0,0 +1,141 @@
+package bailian
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestEmbedder returns an Embedder whose requests are captured and answered with one
+// embedding per content item, listed in reverse order to check the index mapping.
+func newTestEmbedder(t *testing.T, captured *RequestConfig) *Embedder {
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = json.Unmarshal(body, captured); err != nil {
+			t.Fatal(err)
+		}
+
+		output := &ReposeDataOutput{}
+		for i := len(captured.Input.Contents) - 1; i >= 0; i-- {
+			output.Embeddings = append(output.Embeddings, &ReposeDataOutputEmbeddings{
+				Index:     i,
+				Embedding: []float64{float64(i)},
+			})
+		}
+		resp, _ := json.Marshal(&ReposeData{Output: output})
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(string(resp))),
+		}, nil
+	})}
+
+	e, err := NewEmbedder(context.Background(), &EmbeddingConfig{APIKey: "key", HTTPClient: client})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return e
+}
+
+func TestEmbedImages(t *testing.T) {
+	captured := &RequestConfig{}
+	e := newTestEmbedder(t, captured)
+
+	urls := []string{"https://example.com/a.jpg", "https://example.com/b.jpg"}
+	embeddings, err := e.EmbedImages(context.Background(), urls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedContents := []map[string]string{{"image": urls[0]}, {"image": urls[1]}}
+	if !reflect.DeepEqual(captured.Input.Contents, expectedContents) {
+		t.Fatalf("unexpected contents: %v", captured.Input.Contents)
+	}
+
+	if !reflect.DeepEqual(embeddings, [][]float64{{0}, {1}}) {
+		t.Fatalf("unexpected embeddings: %v", embeddings)
+	}
+}
+
+func TestEmbedTextImagePairs(t *testing.T) {
+	captured := &RequestConfig{}
+	e := newTestEmbedder(t, captured)
+
+	pairs := []TextImagePair{
+		{Text: "red chair", Image: "https://example.com/chair.jpg"},
+		{Text: "oak table", Image: "https://example.com/table.jpg"},
+		{Image: "https://example.com/lamp.jpg"},
+	}
+	embeddings, err := e.EmbedTextImagePairs(context.Background(), pairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedContents := []map[string]string{
+		{"text": "red chair", "image": "https://example.com/chair.jpg"},
+		{"text": "oak table", "image": "https://example.com/table.jpg"},
+		{"image": "https://example.com/lamp.jpg"},
+	}
+	if !reflect.DeepEqual(captured.Input.Contents, expectedContents) {
+		t.Fatalf("unexpected contents: %v", captured.Input.Contents)
+	}
+
+	if len(embeddings) != len(pairs) {
+		t.Fatalf("expected one vector per pair, got %d", len(embeddings))
+	}
+	if !reflect.DeepEqual(embeddings, [][]float64{{0}, {1}, {2}}) {
+		t.Fatalf("unexpected embeddings: %v", embeddings)
+	}
+}
+
+func TestEmbedStringsModelOverride(t *testing.T) {
+	captured := &RequestConfig{}
+	e := newTestEmbedder(t, captured)
+
+	embeddings, err := e.EmbedStrings(context.Background(), []string{"hello", "world"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if captured.Model != "multimodal-embedding-v1" {
+		t.Fatalf("expected the configured model, got %s", captured.Model)
+	}
+	expectedContents := []map[string]string{{"text": "hello"}, {"text": "world"}}
+	if !reflect.DeepEqual(captured.Input.Contents, expectedContents) {
+		t.Fatalf("unexpected contents: %v", captured.Input.Contents)
+	}
+	if !reflect.DeepEqual(embeddings, [][]float64{{0}, {1}}) {
+		t.Fatalf("unexpected embeddings: %v", embeddings)
+	}
+
+	_, err = e.EmbedStrings(context.Background(), []string{"hello"}, embedding.WithModel("multimodal-embedding-v2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if captured.Model != "multimodal-embedding-v2" {
+		t.Fatalf("model override did not reach the request, got %s", captured.Model)
+	}
+
+	_, err = e.EmbedImages(context.Background(), []string{"https://example.com/a.jpg"}, embedding.WithModel("multimodal-embedding-v2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if captured.Model != "multimodal-embedding-v2" {
+		t.Fatalf("model override did not reach the request, got %s", captured.Model)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package normalize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+var ErrEmbedderRequired = errors.New("embedding/normalize: embedder is required")
+
+// NormalizingEmbedder wraps an [embedding.Embedder] to embed texts in fixed size batches
+// and optionally L2-normalize the returned vectors, so that vectors from different providers
+// are comparable with cosine similarity.
+type NormalizingEmbedder struct {
+	embedder  embedding.Embedder
+	batchSize int
+	normalize bool
+}
+
+var _ embedding.Embedder = (*NormalizingEmbedder)(nil)
+
+// NewNormalizingEmbedder creates a new [NormalizingEmbedder].
+// A non-positive batchSize sends all texts to the inner embedder in a single call.
+func NewNormalizingEmbedder(inner embedding.Embedder, batchSize int, normalize bool) (*NormalizingEmbedder, error) {
+	if inner == nil {
+		return nil, ErrEmbedderRequired
+	}
+
+	return &NormalizingEmbedder{
+		embedder:  inner,
+		batchSize: batchSize,
+		normalize: normalize,
+	}, nil
+}
+
+func (e *NormalizingEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	batchSize := e.batchSize
+	if batchSize <= 0 || batchSize > len(texts) {
+		batchSize = len(texts)
+	}
+
+	result := make([][]float64, 0, len(texts))
+	for start := 0; start < len(texts); start += batchSize {
+		end := min(start+batchSize, len(texts))
+
+		embeddings, err := e.embedder.EmbedStrings(ctx, texts[start:end], opts...)
+		if err != nil {
+			return nil, err
+		}
+		if len(embeddings) != end-start {
+			return nil, fmt.Errorf("embedding/normalize: expected %d embeddings for batch [%d, %d), got %d",
+				end-start, start, end, len(embeddings))
+		}
+
+		result = append(result, embeddings...)
+	}
+
+	if e.normalize {
+		// the inner embedder may keep the returned vectors, e.g. in a cache, so normalize copies
+		for i, vec := range result {
+			result[i] = L2Normalize(append([]float64(nil), vec...))
+		}
+	}
+
+	return result, nil
+}
+
+// L2Normalize scales vec in place to unit length. Zero vectors are left unchanged.
+func L2Normalize(vec []float64) []float64 {
+	var sum float64
+	for _, v := range vec {
+		sum += v * v
+	}
+
+	if sum == 0 {
+		return vec
+	}
+
+	norm := math.Sqrt(sum)
+	for i := range vec {
+		vec[i] /= norm
+	}
+
+	return vec
+}
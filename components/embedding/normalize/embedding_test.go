@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package normalize
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeEmbedder struct {
+	batches  [][]string
+	returned [][]float64
+	err      error
+}
+
+func (f *fakeEmbedder) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	f.batches = append(f.batches, texts)
+	result := make([][]float64, len(texts))
+	for i, text := range texts {
+		result[i] = []float64{float64(len(text)), 3, 4}
+	}
+	f.returned = append(f.returned, result...)
+
+	return result, nil
+}
+
+func TestNewNormalizingEmbedder(t *testing.T) {
+	_, err := NewNormalizingEmbedder(nil, 2, true)
+	assert.ErrorIs(t, err, ErrEmbedderRequired)
+}
+
+func TestNormalizingEmbedder_EmbedStrings(t *testing.T) {
+	ctx := context.Background()
+	texts := []string{"a", "bb", "ccc", "dddd", "eeeee"}
+
+	t.Run("normalize", func(t *testing.T) {
+		inner := &fakeEmbedder{}
+		e, err := NewNormalizingEmbedder(inner, 2, true)
+		assert.NoError(t, err)
+
+		vectors, err := e.EmbedStrings(ctx, texts)
+		assert.NoError(t, err)
+		assert.Len(t, vectors, len(texts))
+		assert.Equal(t, [][]string{{"a", "bb"}, {"ccc", "dddd"}, {"eeeee"}}, inner.batches)
+
+		for _, vec := range vectors {
+			var sum float64
+			for _, v := range vec {
+				sum += v * v
+			}
+			assert.InDelta(t, 1, math.Sqrt(sum), 1e-9)
+		}
+		// order is preserved across batches
+		assert.InDelta(t, 5/math.Sqrt(50), vectors[4][0], 1e-9)
+	})
+
+	t.Run("normalize leaves inner vectors untouched", func(t *testing.T) {
+		inner := &fakeEmbedder{}
+		e, err := NewNormalizingEmbedder(inner, 2, true)
+		assert.NoError(t, err)
+
+		_, err = e.EmbedStrings(ctx, texts)
+		assert.NoError(t, err)
+		for i, vec := range inner.returned {
+			assert.Equal(t, []float64{float64(len(texts[i])), 3, 4}, vec)
+		}
+	})
+
+	t.Run("without normalize", func(t *testing.T) {
+		inner := &fakeEmbedder{}
+		e, err := NewNormalizingEmbedder(inner, 0, false)
+		assert.NoError(t, err)
+
+		vectors, err := e.EmbedStrings(ctx, texts)
+		assert.NoError(t, err)
+		assert.Len(t, inner.batches, 1)
+		assert.Equal(t, []float64{3, 3, 4}, vectors[2])
+	})
+
+	t.Run("inner error", func(t *testing.T) {
+		e, err := NewNormalizingEmbedder(&fakeEmbedder{err: errors.New("boom")}, 2, true)
+		assert.NoError(t, err)
+
+		_, err = e.EmbedStrings(ctx, texts)
+		assert.EqualError(t, err, "boom")
+	})
+}
+
+func TestL2Normalize(t *testing.T) {
+	assert.Equal(t, []float64{0.6, 0.8}, L2Normalize([]float64{3, 4}))
+	assert.Equal(t, []float64{0, 0}, L2Normalize([]float64{0, 0}))
+}
@@ -21,11 +21,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
 	"github.com/ollama/ollama/api"
 )
 
@@ -33,6 +36,19 @@ var (
 	defaultBaseUrl = "http://localhost:11434"
 )
 
+const defaultStreamBatchSize = 64
+
+// OverlongPolicy selects how EmbedStrings handles texts longer than EmbeddingConfig.MaxInputChars.
+type OverlongPolicy string
+
+const (
+	// OverlongPolicyError fails the whole call when a text is too long.
+	OverlongPolicyError OverlongPolicy = "error"
+	// OverlongPolicySplit embeds a too long text as consecutive chunks of at most MaxInputChars characters
+	// and returns the average of the chunk vectors, weighted by chunk length.
+	OverlongPolicySplit OverlongPolicy = "split"
+)
+
 const (
 	TotalDuration   = "total_duration" // in milliseconds
 	LoadDuration    = "load_duration"  // in milliseconds
@@ -72,6 +88,26 @@ type EmbeddingConfig struct {
 	// Options lists model-specific options.
 	// Optional
 	Options map[string]any `json:"options,omitempty"`
+
+	// MaxInputChars is the maximum length, in characters, of a text sent to the model.
+	// Models silently degrade or fail on inputs past their context window, set it a bit below
+	// the context length of the model (roughly 4 characters per token) to guard against that.
+	// Optional. Default 0, which means no limit
+	MaxInputChars int `json:"max_input_chars,omitempty"`
+
+	// OverlongPolicy is applied to texts longer than MaxInputChars.
+	// Optional. Default OverlongPolicyError
+	OverlongPolicy OverlongPolicy `json:"overlong_policy,omitempty"`
+
+	// StreamBatchSize is the number of texts EmbedStreaming sends to Ollama per request.
+	// Optional. Default 64
+	StreamBatchSize int `json:"stream_batch_size,omitempty"`
+}
+
+// IndexedEmbedding is a vector emitted by EmbedStreaming, Index is the position of its text in the input.
+type IndexedEmbedding struct {
+	Index  int
+	Vector []float64
 }
 
 var _ embedding.Embedder = (*Embedder)(nil)
@@ -79,6 +115,9 @@ var _ embedding.Embedder = (*Embedder)(nil)
 type Embedder struct {
 	cli  *api.Client
 	conf *EmbeddingConfig
+
+	mu        sync.Mutex
+	lastUsage *embedding.TokenUsage
 }
 
 func NewEmbedder(ctx context.Context, config *EmbeddingConfig) (*Embedder, error) {
@@ -116,8 +155,13 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		}
 	}()
 
+	// embedding.WithModel overrides the configured model for this call
+	options := embedding.GetCommonOptions(&embedding.Options{
+		Model: &e.conf.Model,
+	}, opts...)
+
 	req := &api.EmbedRequest{
-		Model:    e.conf.Model,
+		Model:    *options.Model,
 		Input:    texts,
 		Truncate: e.conf.Truncate,
 		Options:  e.conf.Options,
@@ -126,10 +170,6 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		req.KeepAlive = &api.Duration{Duration: *e.conf.KeepAlive}
 	}
 
-	options := embedding.GetCommonOptions(&embedding.Options{
-		Model: &e.conf.Model,
-	}, opts...)
-
 	conf := &embedding.Config{
 		Model: *options.Model,
 	}
@@ -140,6 +180,14 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		Config: conf,
 	})
 
+	inputs, chunks, err := e.guardInputs(texts)
+	if err != nil {
+		return nil, err
+	}
+	if chunks != nil {
+		req.Input = inputs
+	}
+
 	resp, err := e.cli.Embed(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("[Ollama] EmbedStrings error: %v", err)
@@ -154,6 +202,22 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		}
 	}
 
+	if chunks != nil {
+		if result, err = mergeChunks(result, chunks); err != nil {
+			return nil, err
+		}
+	}
+
+	usage := &embedding.TokenUsage{
+		PromptTokens: resp.PromptEvalCount,
+		TotalTokens:  resp.PromptEvalCount,
+	}
+	if usage.PromptTokens == 0 {
+		usage.PromptTokens = estimateTokens(texts)
+		usage.TotalTokens = usage.PromptTokens
+	}
+	e.setLastUsage(usage)
+
 	extra := map[string]any{
 		TotalDuration:   resp.TotalDuration,
 		LoadDuration:    resp.LoadDuration,
@@ -163,12 +227,164 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
 		Embeddings: result,
 		Config:     conf,
+		TokenUsage: usage,
 		Extra:      extra,
 	})
 
 	return result, nil
 }
 
+// EmbedStreaming embeds texts in batches of StreamBatchSize and emits the vectors as soon as each batch
+// is done, so that only one batch of vectors is held in memory at a time. Vectors are emitted in input
+// order, each tagged with the index of its text. Every batch is a regular EmbedStrings call, firing its
+// own callbacks. An error stops the stream and is returned by Recv; closing the reader early, or
+// cancelling ctx, stops embedding the remaining batches.
+func (e *Embedder) EmbedStreaming(ctx context.Context, texts []string, opts ...embedding.Option) (
+	*schema.StreamReader[IndexedEmbedding], error) {
+	batchSize := e.conf.StreamBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultStreamBatchSize
+	}
+
+	sr, sw := schema.Pipe[IndexedEmbedding](batchSize)
+	go func() {
+		defer sw.Close()
+
+		for start := 0; start < len(texts); start += batchSize {
+			if err := ctx.Err(); err != nil {
+				sw.Send(IndexedEmbedding{}, err)
+				return
+			}
+
+			end := start + batchSize
+			if end > len(texts) {
+				end = len(texts)
+			}
+			vectors, err := e.EmbedStrings(ctx, texts[start:end], opts...)
+			if err == nil && len(vectors) != end-start {
+				err = fmt.Errorf("[Ollama] EmbedStreaming invalid return length of vector, got=%d, expected=%d", len(vectors), end-start)
+			}
+			if err != nil {
+				sw.Send(IndexedEmbedding{}, err)
+				return
+			}
+
+			for i, vector := range vectors {
+				if closed := sw.Send(IndexedEmbedding{Index: start + i, Vector: vector}, nil); closed {
+					return
+				}
+			}
+		}
+	}()
+
+	return sr, nil
+}
+
+// chunkSpan locates the chunks of one input text within the split inputs.
+type chunkSpan struct {
+	start   int
+	weights []float64
+}
+
+// guardInputs enforces MaxInputChars on texts. When some text is split, it returns the inputs to send
+// and the span of chunks of every text, otherwise chunks is nil and texts are sent as is.
+func (e *Embedder) guardInputs(texts []string) (inputs []string, chunks []chunkSpan, err error) {
+	limit := e.conf.MaxInputChars
+	if limit <= 0 {
+		return texts, nil, nil
+	}
+
+	split := false
+	for i, text := range texts {
+		if n := utf8.RuneCountInString(text); n > limit {
+			if e.conf.OverlongPolicy != OverlongPolicySplit {
+				return nil, nil, fmt.Errorf("[Ollama] EmbedStrings text %d is too long, got=%d chars, max=%d", i, n, limit)
+			}
+			split = true
+		}
+	}
+	if !split {
+		return texts, nil, nil
+	}
+
+	chunks = make([]chunkSpan, len(texts))
+	for i, text := range texts {
+		chunks[i].start = len(inputs)
+		runes := []rune(text)
+		for start := 0; start < len(runes) || start == 0; start += limit {
+			end := start + limit
+			if end > len(runes) {
+				end = len(runes)
+			}
+			inputs = append(inputs, string(runes[start:end]))
+			chunks[i].weights = append(chunks[i].weights, float64(end-start))
+		}
+	}
+	return inputs, chunks, nil
+}
+
+// mergeChunks averages the vectors of the chunks of every text, weighted by chunk length.
+func mergeChunks(vectors [][]float64, chunks []chunkSpan) ([][]float64, error) {
+	last := chunks[len(chunks)-1]
+	if expected := last.start + len(last.weights); len(vectors) != expected {
+		return nil, fmt.Errorf("[Ollama] EmbedStrings invalid return length of vector, got=%d, expected=%d", len(vectors), expected)
+	}
+
+	result := make([][]float64, len(chunks))
+	for i, span := range chunks {
+		if len(span.weights) == 1 {
+			result[i] = vectors[span.start]
+			continue
+		}
+
+		total := 0.0
+		for _, w := range span.weights {
+			total += w
+		}
+		merged := make([]float64, len(vectors[span.start]))
+		for k, w := range span.weights {
+			for j, v := range vectors[span.start+k] {
+				if j < len(merged) {
+					merged[j] += v * w / total
+				}
+			}
+		}
+		result[i] = merged
+	}
+	return result, nil
+}
+
+// LastUsage returns the token usage of the last successful EmbedStrings call, or nil if there was none.
+// The prompt token count reported by Ollama is used when available, otherwise it is estimated
+// from the input length, so treat it as approximate.
+func (e *Embedder) LastUsage() *embedding.TokenUsage {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.lastUsage == nil {
+		return nil
+	}
+	usage := *e.lastUsage
+	return &usage
+}
+
+func (e *Embedder) setLastUsage(usage *embedding.TokenUsage) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	u := *usage
+	e.lastUsage = &u
+}
+
+// estimateTokens approximates the token count of texts with the common ~4 characters per token heuristic.
+func estimateTokens(texts []string) int {
+	total := 0
+	for _, text := range texts {
+		total += (utf8.RuneCountInString(text) + 3) / 4
+	}
+	return total
+}
+
 const typ = "Ollama"
 
 func (e *Embedder) GetType() string {
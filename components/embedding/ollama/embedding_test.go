@@ -18,11 +18,14 @@ package ollama
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/bytedance/mockey"
 	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/compose"
 	callbacksHelper "github.com/cloudwego/eino/utils/callbacks"
+	"io"
 	"reflect"
 	"testing"
 	"time"
@@ -120,6 +123,7 @@ func TestEmbedding(t *testing.T) {
 			},
 			OnEnd: func(ctx context.Context, runInfo *callbacks.RunInfo, output *embedding.CallbackOutput) context.Context {
 				assert.Equal(t, len(output.Embeddings[0]), expectedDimensions)
+				assert.Equal(t, &embedding.TokenUsage{PromptTokens: 2, TotalTokens: 2}, output.TokenUsage)
 				if !reflect.DeepEqual(output.Extra, map[string]any{
 					TotalDuration:   mockResponse.TotalDuration,
 					LoadDuration:    mockResponse.LoadDuration,
@@ -153,5 +157,146 @@ func TestEmbedding(t *testing.T) {
 		}
 
 		assert.Equal(t, len(outEmbeddings[0]), expectedDimensions)
+		assert.Equal(t, &embedding.TokenUsage{PromptTokens: 2, TotalTokens: 2}, emb.LastUsage())
+	})
+
+	t.Run("estimated usage", func(t *testing.T) {
+		ctx := context.Background()
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+			Model: model,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Nil(t, emb.LastUsage())
+
+		defer mockey.Mock((*api.Client).Embed).Return(&api.EmbedResponse{
+			Model:      model,
+			Embeddings: [][]float32{{0.1}, {0.2}},
+		}, nil).Build().UnPatch()
+
+		_, err = emb.EmbedStrings(ctx, []string{"hello world", "hi"})
+		assert.Nil(t, err)
+		// "hello world" is 11 characters, about 3 tokens; "hi" is 1 token
+		assert.Equal(t, &embedding.TokenUsage{PromptTokens: 4, TotalTokens: 4}, emb.LastUsage())
+	})
+}
+
+func TestEmbedStreaming(t *testing.T) {
+	ctx := context.Background()
+	emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+		Model:           "nomic-embed-text",
+		StreamBatchSize: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var batches [][]string
+	defer mockey.Mock((*api.Client).Embed).To(func(ctx context.Context, req *api.EmbedRequest) (*api.EmbedResponse, error) {
+		input := req.Input.([]string)
+		batches = append(batches, input)
+		embeddings := make([][]float32, len(input))
+		for i, text := range input {
+			embeddings[i] = []float32{float32(len(text))}
+		}
+		return &api.EmbedResponse{Embeddings: embeddings}, nil
+	}).Build().UnPatch()
+
+	texts := []string{"a", "bb", "ccc", "dddd", "eeeee"}
+	sr, err := emb.EmbedStreaming(ctx, texts)
+	assert.Nil(t, err)
+	defer sr.Close()
+
+	var got []IndexedEmbedding
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.Nil(t, err)
+		got = append(got, chunk)
+	}
+
+	assert.Equal(t, [][]string{{"a", "bb"}, {"ccc", "dddd"}, {"eeeee"}}, batches)
+	assert.Len(t, got, len(texts))
+	for i, chunk := range got {
+		assert.Equal(t, i, chunk.Index)
+		assert.Equal(t, []float64{float64(len(texts[i]))}, chunk.Vector)
+	}
+}
+
+func TestEmbedStringsModelOverride(t *testing.T) {
+	ctx := context.Background()
+	emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+		Model: "nomic-embed-text",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var models []string
+	defer mockey.Mock((*api.Client).Embed).To(func(ctx context.Context, req *api.EmbedRequest) (*api.EmbedResponse, error) {
+		models = append(models, req.Model)
+		return &api.EmbedResponse{Embeddings: [][]float32{{0.1}}}, nil
+	}).Build().UnPatch()
+
+	var callbackModels []string
+	handler := callbacksHelper.NewHandlerHelper().Embedding(&callbacksHelper.EmbeddingCallbackHandler{
+		OnStart: func(ctx context.Context, runInfo *callbacks.RunInfo, input *embedding.CallbackInput) context.Context {
+			callbackModels = append(callbackModels, input.Config.Model)
+			return ctx
+		},
+	}).Handler()
+	ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{Type: typ, Component: components.ComponentOfEmbedding}, handler)
+
+	_, err = emb.EmbedStrings(ctx, []string{"hello"})
+	assert.Nil(t, err)
+	_, err = emb.EmbedStrings(ctx, []string{"hello"}, embedding.WithModel("mxbai-embed-large"))
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"nomic-embed-text", "mxbai-embed-large"}, models)
+	assert.Equal(t, []string{"nomic-embed-text", "mxbai-embed-large"}, callbackModels)
+}
+
+func TestEmbedStringsOverlongInput(t *testing.T) {
+	ctx := context.Background()
+
+	var inputs [][]string
+	defer mockey.Mock((*api.Client).Embed).To(func(ctx context.Context, req *api.EmbedRequest) (*api.EmbedResponse, error) {
+		input := req.Input.([]string)
+		inputs = append(inputs, input)
+		embeddings := make([][]float32, len(input))
+		for i, text := range input {
+			embeddings[i] = []float32{float32(len(text)), 1}
+		}
+		return &api.EmbedResponse{Embeddings: embeddings}, nil
+	}).Build().UnPatch()
+
+	t.Run("error", func(t *testing.T) {
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{Model: "nomic-embed-text", MaxInputChars: 4})
+		assert.Nil(t, err)
+
+		_, err = emb.EmbedStrings(ctx, []string{"ab", "abcdefghij"})
+		assert.ErrorContains(t, err, "text 1 is too long, got=10 chars, max=4")
+		assert.Empty(t, inputs)
+	})
+
+	t.Run("split and average", func(t *testing.T) {
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+			Model:          "nomic-embed-text",
+			MaxInputChars:  4,
+			OverlongPolicy: OverlongPolicySplit,
+		})
+		assert.Nil(t, err)
+
+		vectors, err := emb.EmbedStrings(ctx, []string{"ab", "abcdefghij"})
+		assert.Nil(t, err)
+		assert.Equal(t, [][]string{{"ab", "abcd", "efgh", "ij"}}, inputs)
+		assert.Len(t, vectors, 2)
+		assert.Equal(t, []float64{2, 1}, vectors[0])
+		// chunks of 4, 4 and 2 characters weighted by their length
+		assert.InDelta(t, 3.6, vectors[1][0], 1e-9)
+		assert.InDelta(t, 1, vectors[1][1], 1e-9)
 	})
 }
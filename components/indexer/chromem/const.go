@@ -4,4 +4,11 @@ const (
 	typ                 = "chromem"
 	defaultCollection   = "default"
 	defaultAddBatchSize = 5
+
+	defaultMaxMetadataKeys  = 128
+	defaultMaxMetadataBytes = 64 * 1024
 )
+
+// MetaDataJSONKey is the reserved chromem metadata key holding the JSON encoded document metadata
+// when IndexerConfig.MetadataJSON is enabled.
+const MetaDataJSONKey = "_meta_json"
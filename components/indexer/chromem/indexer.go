@@ -2,7 +2,9 @@ package chromem
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"runtime"
 	"sync"
 
 	"github.com/cloudwego/eino/callbacks"
@@ -23,11 +25,37 @@ type IndexerConfig struct {
 	Compress   bool   `json:"compress"`
 	Collection string `json:"collection"`
 
+	// Embedding embeds the documents. chromem L2-normalizes the embeddings it stores, so embedders
+	// that do not return unit vectors (e.g. Ollama) need no extra step.
 	Embedding embedding.Embedder
+	// ContentToEmbed returns the text embedded for a document, e.g. a summary kept in its metadata,
+	// while the stored content stays document.Content.
+	// Optional. Default: document.Content
+	ContentToEmbed func(doc *schema.Document) string
 
+	// AddBatchSize is the number of documents embedded and added per chunk in Store.
+	// Optional. Default: 5
 	AddBatchSize int `json:"add_batch_size"`
+	// AddConcurrency is the concurrency passed to chromem when adding a chunk of documents.
+	// Optional. Default: runtime.NumCPU()
+	AddConcurrency int `json:"add_concurrency"`
+
+	// MaxMetadataKeys caps the number of metadata entries per document, Store fails when it is exceeded.
+	// Optional. Default: 128
+	MaxMetadataKeys int `json:"max_metadata_keys"`
+	// MaxMetadataBytes caps the total size of keys and values of the converted metadata per document,
+	// Store fails when it is exceeded.
+	// Optional. Default: 64KB
+	MaxMetadataBytes int `json:"max_metadata_bytes"`
+	// MetadataJSON additionally stores the whole document metadata JSON encoded under MetaDataJSONKey,
+	// so that the chromem retriever can restore nested and typed values that the flat string metadata loses.
+	// Optional. Default: false
+	MetadataJSON bool `json:"metadata_json"`
 }
 
+// addDocuments is replaced in tests to observe the arguments passed to chromem.
+var addDocuments = (*chromem.Collection).AddDocuments
+
 type Indexer struct {
 	config      *IndexerConfig
 	collections map[string]*chromem.Collection
@@ -49,6 +77,18 @@ func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
 		config.AddBatchSize = defaultAddBatchSize
 	}
 
+	if config.AddConcurrency == 0 {
+		config.AddConcurrency = runtime.NumCPU()
+	}
+
+	if config.MaxMetadataKeys == 0 {
+		config.MaxMetadataKeys = defaultMaxMetadataKeys
+	}
+
+	if config.MaxMetadataBytes == 0 {
+		config.MaxMetadataBytes = defaultMaxMetadataBytes
+	}
+
 	if config.Client == nil {
 		// 初始化DB
 		if config.Persistent {
@@ -84,12 +124,14 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 	options := indexer.GetCommonOptions(&indexer.Options{
 		Embedding: i.config.Embedding,
 	}, opts...)
+	io := indexer.GetImplSpecificOptions(&ImplOptions{}, opts...)
 
 	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
 
+	collection := i.currentCollection()
 	ids = make([]string, 0, len(docs))
 	for _, sub := range chunk(docs, i.config.AddBatchSize) {
-		documents, err := i.convertDocuments(ctx, sub, options)
+		documents, err := i.convertDocuments(ctx, sub, options, io.EmbeddingOptions)
 		if err != nil {
 			return nil, fmt.Errorf("convertDocuments failed: %w", err)
 		}
@@ -98,7 +140,7 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 		//然后从content内容改为MysqlDocId
 		//同时metadata里面添加isStoredInMysql=true
 		//MetaData里面已经包含了_source就是原始文件名
-		if err = i.collection.AddDocuments(ctx, documents, i.config.AddBatchSize); err != nil {
+		if err = addDocuments(collection, ctx, documents, i.config.AddConcurrency); err != nil {
 			return nil, fmt.Errorf("AddDocuments failed: %w", err)
 		}
 
@@ -110,12 +152,14 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 	return ids, nil
 }
 
-func (i *Indexer) convertDocuments(ctx context.Context, docs []*schema.Document, options *indexer.Options) ([]chromem.Document, error) {
-	queries := iter(docs, func(doc *schema.Document) string {
-		return doc.Content
-	})
+func (i *Indexer) convertDocuments(ctx context.Context, docs []*schema.Document, options *indexer.Options, embOpts []embedding.Option) ([]chromem.Document, error) {
+	contentToEmbed := i.config.ContentToEmbed
+	if contentToEmbed == nil {
+		contentToEmbed = func(doc *schema.Document) string { return doc.Content }
+	}
+	queries := iter(docs, contentToEmbed)
 
-	dense, err := i.customEmbedding(ctx, queries, options)
+	dense, err := i.customEmbedding(ctx, queries, options, embOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -131,13 +175,24 @@ func (i *Indexer) convertDocuments(ctx context.Context, docs []*schema.Document,
 
 		document.ID = doc.ID
 		document.Content = doc.Content
-		document.Embedding = make([]float32, len(dense[idx]))
-		for k, v := range dense[idx] {
+		vector := dense[idx]
+		document.Embedding = make([]float32, len(vector))
+		for k, v := range vector {
 			document.Embedding[k] = float32(v)
 		}
-		document.Metadata = map[string]string{}
-		for k, v := range doc.MetaData {
-			document.Metadata[k] = fmt.Sprintf("%s", v)
+		document.Metadata, err = convertMetadata(doc.MetaData, i.config.MaxMetadataKeys, i.config.MaxMetadataBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata of document %s: %w", doc.ID, err)
+		}
+		if i.config.MetadataJSON && len(doc.MetaData) > 0 {
+			b, err := json.Marshal(doc.MetaData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode metadata of document %s: %w", doc.ID, err)
+			}
+			if i.config.MaxMetadataBytes > 0 && len(b) > i.config.MaxMetadataBytes {
+				return nil, fmt.Errorf("invalid metadata of document %s: encoded metadata exceeds the limit of %d bytes", doc.ID, i.config.MaxMetadataBytes)
+			}
+			document.Metadata[MetaDataJSONKey] = string(b)
 		}
 		documents[idx] = document
 	}
@@ -145,9 +200,9 @@ func (i *Indexer) convertDocuments(ctx context.Context, docs []*schema.Document,
 	return documents, nil
 }
 
-func (i *Indexer) customEmbedding(ctx context.Context, queries []string, options *indexer.Options) (vector [][]float64, err error) {
+func (i *Indexer) customEmbedding(ctx context.Context, queries []string, options *indexer.Options, embOpts ...embedding.Option) (vector [][]float64, err error) {
 	emb := options.Embedding
-	vectors, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), queries)
+	vectors, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), queries, embOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -224,6 +279,41 @@ func (i *Indexer) getOrCreateCollection(name string) (*chromem.Collection, error
 	return coll, nil
 }
 
+// Reset deletes the indexer's collection and recreates it empty, e.g. before a full reindex.
+// A Store running concurrently keeps writing to the collection it started with, which may be
+// the deleted one. Retrievers look the collection up on every query, so they follow the new one.
+func (i *Indexer) Reset(ctx context.Context) error {
+	name := i.config.Collection
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.collections, name)
+	if err := i.config.Client.DeleteCollection(name); err != nil {
+		return fmt.Errorf("failed to delete collection %s: %w", name, err)
+	}
+
+	collection, err := i.config.Client.GetOrCreateCollection(name, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create collection %s: %w", name, err)
+	}
+	i.collections[name] = collection
+	i.collection = collection
+	return nil
+}
+
+// Count returns the number of documents in the indexer's collection.
+func (i *Indexer) Count() int {
+	return i.currentCollection().Count()
+}
+
+func (i *Indexer) currentCollection() *chromem.Collection {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.collection
+}
+
 func (i *Indexer) GetType() string {
 	return typ
 }
@@ -0,0 +1,380 @@
+package chromem
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/philippgille/chromem-go"
+)
+
+type mockEmbedding struct {
+	mu      sync.Mutex
+	vector  []float64
+	model   string
+	batches []int
+	texts   []string
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.batches = append(m.batches, len(texts))
+	m.texts = append(m.texts, texts...)
+	if model := embedding.GetCommonOptions(&embedding.Options{}, opts...).Model; model != nil {
+		m.model = *model
+	}
+	resp := make([][]float64, len(texts))
+	for i := range texts {
+		resp[i] = append([]float64(nil), m.vector...)
+	}
+	return resp, nil
+}
+
+// TestIndexerStoresUnitVectors checks that chromem normalizes the embeddings it stores.
+func TestIndexerStoresUnitVectors(t *testing.T) {
+	ctx := context.Background()
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		Client:    chromem.NewDB(),
+		Embedding: &mockEmbedding{vector: []float64{3, 4}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "hello"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := i.collection.GetByID(ctx, ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []float32{0.6, 0.8}
+	for k, v := range doc.Embedding {
+		if math.Abs(float64(v-expected[k])) > 1e-6 {
+			t.Fatalf("unexpected embedding, got=%v, expected=%v", doc.Embedding, expected)
+		}
+	}
+}
+
+func TestIndexerEmbeddingOptions(t *testing.T) {
+	ctx := context.Background()
+	emb := &mockEmbedding{vector: []float64{1, 0}}
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		Client:    chromem.NewDB(),
+		Embedding: emb,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "hello"}},
+		WithEmbeddingOptions(embedding.WithModel("text-embedding-3-small")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if emb.model != "text-embedding-3-small" {
+		t.Fatalf("embedding options not forwarded, got model=%q", emb.model)
+	}
+}
+
+func TestIndexerMetadata(t *testing.T) {
+	ctx := context.Background()
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		Client:           chromem.NewDB(),
+		Embedding:        &mockEmbedding{vector: []float64{1, 0}},
+		MaxMetadataBytes: 64,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := i.Store(ctx, []*schema.Document{{
+		ID:      "1",
+		Content: "hello",
+		MetaData: map[string]any{
+			"source": "a.txt",
+			"page":   3,
+			"tags":   map[string]any{"lang": "en"},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := i.collection.GetByID(ctx, ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Metadata["source"] != "a.txt" || doc.Metadata["page"] != "3" || doc.Metadata["tags"] != `{"lang":"en"}` {
+		t.Fatalf("unexpected metadata: %v", doc.Metadata)
+	}
+
+	_, err = i.Store(ctx, []*schema.Document{{
+		ID:       "2",
+		Content:  "hello",
+		MetaData: map[string]any{"blob": strings.Repeat("x", 100)},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "exceeds the limit of 64 bytes") {
+		t.Fatalf("expected size limit error, got %v", err)
+	}
+}
+
+func TestConvertMetadata(t *testing.T) {
+	if _, err := convertMetadata(map[string]any{"a": 1, "b": 2}, 1, 0); err == nil {
+		t.Fatal("expected key limit error")
+	}
+
+	if _, err := convertMetadata(map[string]any{"ch": make(chan int)}, 0, 0); err == nil {
+		t.Fatal("expected serialization error")
+	}
+
+	got, err := convertMetadata(map[string]any{"f": 1.5, "b": true, "n": nil, "l": []int{1, 2}}, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["f"] != "1.5" || got["b"] != "true" || got["n"] != "" || got["l"] != "[1,2]" {
+		t.Fatalf("unexpected metadata: %v", got)
+	}
+}
+
+func TestIndexerCount(t *testing.T) {
+	ctx := context.Background()
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		Client:    chromem.NewDB(),
+		Embedding: &mockEmbedding{vector: []float64{1, 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if i.Count() != 0 {
+		t.Fatalf("expected empty collection, got %d", i.Count())
+	}
+
+	docs := make([]*schema.Document, 7)
+	for k := range docs {
+		docs[k] = &schema.Document{Content: "hello"}
+	}
+	if _, err = i.Store(ctx, docs); err != nil {
+		t.Fatal(err)
+	}
+
+	if i.Count() != len(docs) {
+		t.Fatalf("unexpected count, got=%d, expected=%d", i.Count(), len(docs))
+	}
+}
+
+func TestIndexerBatchAndConcurrency(t *testing.T) {
+	ctx := context.Background()
+	emb := &mockEmbedding{vector: []float64{1, 0}}
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		Client:         chromem.NewDB(),
+		Embedding:      emb,
+		AddBatchSize:   3,
+		AddConcurrency: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orig := addDocuments
+	defer func() { addDocuments = orig }()
+
+	var concurrency []int
+	addDocuments = func(c *chromem.Collection, ctx context.Context, documents []chromem.Document, n int) error {
+		concurrency = append(concurrency, n)
+		return orig(c, ctx, documents, n)
+	}
+
+	docs := make([]*schema.Document, 7)
+	for k := range docs {
+		docs[k] = &schema.Document{Content: "hello"}
+	}
+	if _, err = i.Store(ctx, docs); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(emb.batches, []int{3, 3, 1}) {
+		t.Fatalf("unexpected embedding batches: %v", emb.batches)
+	}
+	if !reflect.DeepEqual(concurrency, []int{2, 2, 2}) {
+		t.Fatalf("unexpected add concurrency: %v", concurrency)
+	}
+	if i.Count() != len(docs) {
+		t.Fatalf("unexpected count, got=%d, expected=%d", i.Count(), len(docs))
+	}
+}
+
+func TestIndexerDefaultConcurrency(t *testing.T) {
+	i, err := NewIndexer(context.Background(), &IndexerConfig{
+		Client:    chromem.NewDB(),
+		Embedding: &mockEmbedding{vector: []float64{1, 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if i.config.AddBatchSize != defaultAddBatchSize || i.config.AddConcurrency != runtime.NumCPU() {
+		t.Fatalf("unexpected defaults, batch=%d, concurrency=%d", i.config.AddBatchSize, i.config.AddConcurrency)
+	}
+}
+
+func TestIndexerReset(t *testing.T) {
+	ctx := context.Background()
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		Client:    chromem.NewDB(),
+		Embedding: &mockEmbedding{vector: []float64{1, 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "hello"}, {ID: "2", Content: "world"}}); err != nil {
+		t.Fatal(err)
+	}
+	if i.Count() != 2 {
+		t.Fatalf("unexpected count before reset, got=%d", i.Count())
+	}
+
+	if err = i.Reset(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if i.Count() != 0 {
+		t.Fatalf("unexpected count after reset, got=%d", i.Count())
+	}
+
+	// the recreated collection is usable
+	if _, err = i.Store(ctx, []*schema.Document{{ID: "3", Content: "again"}}); err != nil {
+		t.Fatal(err)
+	}
+	if i.Count() != 1 {
+		t.Fatalf("unexpected count after store, got=%d", i.Count())
+	}
+}
+
+func TestIndexerResetConcurrent(t *testing.T) {
+	ctx := context.Background()
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		Client:    chromem.NewDB(),
+		Embedding: &mockEmbedding{vector: []float64{1, 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// run with -race: Reset swaps the collection while Store and Count read it
+	var wg sync.WaitGroup
+	for n := 0; n < 4; n++ {
+		wg.Add(3)
+		go func(n int) {
+			defer wg.Done()
+			if _, err := i.Store(ctx, []*schema.Document{{ID: strconv.Itoa(n), Content: "hello"}}); err != nil {
+				t.Error(err)
+			}
+		}(n)
+		go func() {
+			defer wg.Done()
+			if err := i.Reset(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_ = i.Count()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestIndexerContentToEmbed(t *testing.T) {
+	ctx := context.Background()
+	emb := &mockEmbedding{vector: []float64{1, 0}}
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		Client:    chromem.NewDB(),
+		Embedding: emb,
+		ContentToEmbed: func(doc *schema.Document) string {
+			return doc.MetaData["summary"].(string)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := i.Store(ctx, []*schema.Document{{
+		ID:       "1",
+		Content:  "the full text of a long document",
+		MetaData: map[string]any{"summary": "a short summary"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(emb.texts, []string{"a short summary"}) {
+		t.Fatalf("unexpected embedded texts: %v", emb.texts)
+	}
+
+	doc, err := i.collection.GetByID(ctx, ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Content != "the full text of a long document" {
+		t.Fatalf("unexpected stored content: %q", doc.Content)
+	}
+}
+
+func TestIndexerMetadataJSON(t *testing.T) {
+	ctx := context.Background()
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		Client:       chromem.NewDB(),
+		Embedding:    &mockEmbedding{vector: []float64{1, 0}},
+		MetadataJSON: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := map[string]any{
+		"source": "a.txt",
+		"page":   3,
+		"tags":   map[string]any{"lang": "en", "labels": []any{"x", "y"}},
+	}
+	ids, err := i.Store(ctx, []*schema.Document{{ID: "1", Content: "hello", MetaData: metadata}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := i.collection.GetByID(ctx, ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Metadata["source"] != "a.txt" {
+		t.Fatalf("flat metadata should be kept: %v", doc.Metadata)
+	}
+
+	var restored map[string]any
+	if err = json.Unmarshal([]byte(doc.Metadata[MetaDataJSONKey]), &restored); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"source": "a.txt",
+		"page":   float64(3),
+		"tags":   map[string]any{"lang": "en", "labels": []any{"x", "y"}},
+	}
+	if !reflect.DeepEqual(restored, expected) {
+		t.Fatalf("unexpected metadata round trip: %v", restored)
+	}
+}
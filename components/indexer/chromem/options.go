@@ -0,0 +1,19 @@
+package chromem
+
+import (
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+)
+
+// ImplOptions contains implementation-specific options for Store.
+type ImplOptions struct {
+	// EmbeddingOptions are passed to the embedder when the documents are vectorized.
+	EmbeddingOptions []embedding.Option
+}
+
+// WithEmbeddingOptions forwards opts to the embedder, e.g. to set a task type or dimension per Store call.
+func WithEmbeddingOptions(opts ...embedding.Option) indexer.Option {
+	return indexer.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.EmbeddingOptions = opts
+	})
+}
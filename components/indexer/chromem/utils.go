@@ -3,6 +3,7 @@ package chromem
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 )
 
 func chunk[T any](slice []T, size int) [][]T {
@@ -85,3 +86,59 @@ func interfaceToSparse(raw interface{}) (map[string]interface{}, error) {
 
 	return sparse, nil
 }
+
+// convertMetadata converts document metadata to the string values chromem stores.
+// Scalars are formatted, other values (maps, slices, structs) are serialized as JSON.
+// Non-positive maxKeys or maxBytes disable the respective limit.
+func convertMetadata(metadata map[string]any, maxKeys, maxBytes int) (map[string]string, error) {
+	if maxKeys > 0 && len(metadata) > maxKeys {
+		return nil, fmt.Errorf("metadata has %d keys, exceeds the limit of %d", len(metadata), maxKeys)
+	}
+
+	resp := make(map[string]string, len(metadata))
+	size := 0
+	for k, v := range metadata {
+		str, err := metadataValueToString(v)
+		if err != nil {
+			return nil, fmt.Errorf("metadata %q: %w", k, err)
+		}
+
+		size += len(k) + len(str)
+		if maxBytes > 0 && size > maxBytes {
+			return nil, fmt.Errorf("metadata size exceeds the limit of %d bytes", maxBytes)
+		}
+
+		resp[k] = str
+	}
+
+	return resp, nil
+}
+
+func metadataValueToString(v any) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	case []byte:
+		return string(t), nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", t), nil
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	case json.Number:
+		return t.String(), nil
+	case fmt.Stringer:
+		return t.String(), nil
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize value of type %T: %w", v, err)
+		}
+		return string(b), nil
+	}
+}
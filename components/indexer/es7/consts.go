@@ -16,8 +16,14 @@
 
 package es7
 
+import "time"
+
 const typ = "ElasticSearch7"
 
 const (
 	defaultBatchSize = 5
+
+	defaultSetupTimeout = 10 * time.Second
+	defaultSetupRetries = 2
+	setupRetryBackoff   = 100 * time.Millisecond
 )
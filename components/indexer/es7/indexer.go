@@ -22,6 +22,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
@@ -46,15 +49,37 @@ type IndexerConfig struct {
 	// BatchSize specifies the maximum number of documents to embed in a single batch.
 	// Default is 5.
 	BatchSize int `json:"batch_size"`
+	// SetupTimeout bounds each attempt of the index existence check and creation made by NewIndexer
+	// when IndexSpec is provided.
+	// Default is 10s.
+	SetupTimeout time.Duration `json:"setup_timeout"`
+	// SetupRetries is the number of times NewIndexer retries the index existence check or creation
+	// after a transport error, a timeout, a 429 or a 5xx response. A negative value disables retries.
+	// Default is 2.
+	SetupRetries int `json:"setup_retries"`
 	// DocumentToFields maps an Eino document to Elasticsearch fields.
 	// It allows customization of how documents are stored and vectored.
 	DocumentToFields func(ctx context.Context, doc *schema.Document) (field2Value map[string]FieldValue, err error)
+	// ValidateMapping runs DocumentToFields on MappingProbe in NewIndexer, so that a mapping Store cannot
+	// write, e.g. duplicate embed keys or a non-string value without Stringify, fails construction instead.
+	// Default is false.
+	ValidateMapping bool `json:"validate_mapping"`
+	// MappingProbe is the document mapped by ValidateMapping. It should look like the documents passed to Store,
+	// including the MetaData read by DocumentToFields.
+	// Default is a document with an ID, Content and empty MetaData.
+	MappingProbe *schema.Document `json:"mapping_probe"`
 	// Embedding is the embedding model used for vectorization.
 	// It is required if any field provided by DocumentToFields requires vectorization (specifically, if FieldValue.EmbedKey is not empty).
 	// This typically applies when:
 	// 1. The document content itself needs to be vectorized and does not have a pre-computed vector (see [schema.Document.Vector]).
 	// 2. Additional fields (other than content) need to be vectorized.
 	Embedding embedding.Embedder
+	// VectorDims declares the expected vector dimension per EmbedKey, e.g. {"content_vector": 1024}.
+	// Dimensions of dense_vector fields declared in IndexSpec.Mappings are added automatically.
+	// The first embedded batch of every Store call is checked against them, so a mismatching embedder
+	// fails with a clear error instead of a mapping error from Elasticsearch.
+	// Optional.
+	VectorDims map[string]int `json:"vector_dims"`
 }
 
 // IndexSpec allows defining detailed index settings for auto-creation.
@@ -87,6 +112,7 @@ type FieldValue struct {
 type Indexer struct {
 	client *elasticsearch.Client
 	config *IndexerConfig
+	dims   map[string]int
 }
 
 // NewIndexer creates a new ES7 indexer with the provided configuration.
@@ -102,50 +128,186 @@ func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
 		conf.BatchSize = defaultBatchSize
 	}
 
+	if conf.ValidateMapping {
+		if err := validateMapping(ctx, conf); err != nil {
+			return nil, err
+		}
+	}
+
+	if conf.SetupTimeout <= 0 {
+		conf.SetupTimeout = defaultSetupTimeout
+	}
+
+	if conf.SetupRetries == 0 {
+		conf.SetupRetries = defaultSetupRetries
+	}
+
 	if conf.IndexSpec != nil {
-		existsReq := esapi.IndicesExistsRequest{
-			Index: []string{conf.Index},
+		if err := ensureIndex(ctx, conf); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Indexer{
+		client: conf.Client,
+		config: conf,
+		dims:   vectorDims(conf),
+	}, nil
+}
+
+// validateMapping maps conf.MappingProbe with DocumentToFields and checks the fields the way Store does.
+func validateMapping(ctx context.Context, conf *IndexerConfig) error {
+	probe := conf.MappingProbe
+	if probe == nil {
+		probe = &schema.Document{
+			ID:       "mapping_probe",
+			Content:  "mapping probe",
+			MetaData: map[string]any{},
 		}
-		existsRes, err := existsReq.Do(ctx, conf.Client)
+	}
+
+	fields, err := conf.DocumentToFields(ctx, probe)
+	if err != nil {
+		return fmt.Errorf("[NewIndexer] validate mapping failed, %w", err)
+	}
+
+	if _, _, err = splitFields(fields, conf.BatchSize); err != nil {
+		return fmt.Errorf("[NewIndexer] validate mapping failed, %w", err)
+	}
+
+	return nil
+}
+
+// ensureIndex creates conf.Index from conf.IndexSpec unless it already exists.
+func ensureIndex(ctx context.Context, conf *IndexerConfig) error {
+	var exists bool
+	err := withSetupRetry(ctx, conf, func(ctx context.Context) (bool, error) {
+		existsRes, err := esapi.IndicesExistsRequest{
+			Index: []string{conf.Index},
+		}.Do(ctx, conf.Client)
 		if err != nil {
-			return nil, fmt.Errorf("[NewIndexer] check index existence failed, %w", err)
+			return true, fmt.Errorf("[NewIndexer] check index existence failed, %w", err)
 		}
 		if existsRes.Body != nil {
 			_ = existsRes.Body.Close()
 		}
 
-		if existsRes.StatusCode == 404 {
-			body, err := json.Marshal(conf.IndexSpec)
-			if err != nil {
-				return nil, fmt.Errorf("[NewIndexer] marshal index spec failed, %w", err)
-			}
+		switch {
+		case existsRes.StatusCode == http.StatusNotFound:
+			exists = false
+		case existsRes.IsError():
+			return retryableStatus(existsRes.StatusCode),
+				fmt.Errorf("[NewIndexer] check index existence failed, response: %s", existsRes.String())
+		default:
+			exists = true
+		}
 
-			createReq := esapi.IndicesCreateRequest{
-				Index: conf.Index,
-				Body:  bytes.NewReader(body),
-			}
-			createRes, err := createReq.Do(ctx, conf.Client)
-			if err != nil {
-				return nil, fmt.Errorf("[NewIndexer] create index failed, %w", err)
-			}
+		return false, nil
+	})
+	if err != nil || exists {
+		return err
+	}
+
+	body, err := json.Marshal(conf.IndexSpec)
+	if err != nil {
+		return fmt.Errorf("[NewIndexer] marshal index spec failed, %w", err)
+	}
+
+	attempts := 0
+	return withSetupRetry(ctx, conf, func(ctx context.Context) (bool, error) {
+		attempts++
+		createRes, err := esapi.IndicesCreateRequest{
+			Index: conf.Index,
+			Body:  bytes.NewReader(body),
+		}.Do(ctx, conf.Client)
+		if err != nil {
+			return true, fmt.Errorf("[NewIndexer] create index failed, %w", err)
+		}
+		defer func() {
 			if createRes.Body != nil {
 				_ = createRes.Body.Close()
 			}
-			if createRes.IsError() {
-				return nil, fmt.Errorf("[NewIndexer] create index failed, response: %s", createRes.String())
+		}()
+
+		if createRes.IsError() {
+			resp := createRes.String()
+			// A previous attempt may have created the index before timing out.
+			if attempts > 1 && strings.Contains(resp, "resource_already_exists_exception") {
+				return false, nil
 			}
-		} else if existsRes.IsError() {
-			return nil, fmt.Errorf("[NewIndexer] check index existence failed, response: %s", existsRes.String())
+			return retryableStatus(createRes.StatusCode), fmt.Errorf("[NewIndexer] create index failed, response: %s", resp)
+		}
+
+		return false, nil
+	})
+}
+
+// withSetupRetry runs op with a timeout of conf.SetupTimeout per attempt, and retries it up to
+// conf.SetupRetries times with a growing backoff while op reports a retryable error and ctx is not done.
+func withSetupRetry(ctx context.Context, conf *IndexerConfig, op func(ctx context.Context) (retry bool, err error)) error {
+	for attempt := 0; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, conf.SetupTimeout)
+		retry, err := op(attemptCtx)
+		cancel()
+		if err == nil || !retry || attempt >= conf.SetupRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt+1) * setupRetryBackoff):
 		}
 	}
+}
 
-	return &Indexer{
-		client: conf.Client,
-		config: conf,
-	}, nil
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// Ping checks that the cluster is reachable with the configured credentials and that the target index exists.
+// It is meant for startup validation, before the first Store call.
+func (i *Indexer) Ping(ctx context.Context) error {
+	infoRes, err := esapi.InfoRequest{}.Do(ctx, i.client)
+	if err != nil {
+		return fmt.Errorf("[Ping] connect to elasticsearch failed, %w", err)
+	}
+	if infoRes.Body != nil {
+		_ = infoRes.Body.Close()
+	}
+
+	switch {
+	case infoRes.StatusCode == http.StatusUnauthorized || infoRes.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("[Ping] elasticsearch authentication failed, status=%d, check the client credentials", infoRes.StatusCode)
+	case infoRes.IsError():
+		return fmt.Errorf("[Ping] elasticsearch unavailable, response: %s", infoRes.String())
+	}
+
+	existsRes, err := esapi.IndicesExistsRequest{
+		Index: []string{i.config.Index},
+	}.Do(ctx, i.client)
+	if err != nil {
+		return fmt.Errorf("[Ping] check index existence failed, %w", err)
+	}
+	if existsRes.Body != nil {
+		_ = existsRes.Body.Close()
+	}
+
+	switch {
+	case existsRes.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("[Ping] index %s does not exist", i.config.Index)
+	case existsRes.IsError():
+		return fmt.Errorf("[Ping] check index existence failed, response: %s", existsRes.String())
+	}
+
+	return nil
 }
 
 // Store adds the provided documents to the Elasticsearch index.
+// The returned ids correspond to docs by position: ids[j] is the id docs[j] was stored under,
+// which is the id assigned by Elasticsearch when docs[j].ID is empty. A document rejected by
+// Elasticsearch is logged and keeps docs[j].ID in its slot, so the order of the result never
+// depends on the order in which the bulk responses arrive.
 func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
 	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
 	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
@@ -158,31 +320,35 @@ func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...in
 	options := indexer.GetCommonOptions(&indexer.Options{
 		Embedding: i.config.Embedding,
 	}, opts...)
+	io := indexer.GetImplSpecificOptions(&ImplOptions{}, opts...)
 
-	if err = i.bulkAdd(ctx, docs, options); err != nil {
+	if ids, err = i.bulkAdd(ctx, docs, options, io.EmbeddingOptions...); err != nil {
 		return nil, err
 	}
 
-	ids = iter(docs, func(t *schema.Document) string { return t.ID })
-
 	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
 
 	return ids, nil
 }
 
-func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options *indexer.Options) error {
+func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options *indexer.Options, embOpts ...embedding.Option) ([]string, error) {
 	emb := options.Embedding
 	bi, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
 		Index:  i.config.Index,
 		Client: i.client,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	// ids is indexed by the position of the document in docs, so bulk workers completing
+	// out of order each write their own slot.
+	ids := iter(docs, func(t *schema.Document) string { return t.ID })
+
 	var (
-		tuples []tuple
-		texts  []string
+		tuples     []tuple
+		texts      []string
+		dimChecked bool
 	)
 
 	embAndAdd := func() error {
@@ -193,7 +359,7 @@ func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options
 				return fmt.Errorf("[bulkAdd] embedding method not provided")
 			}
 
-			vectors, err = emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), texts)
+			vectors, err = emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), texts, embOpts...)
 			if err != nil {
 				return fmt.Errorf("[bulkAdd] embedding failed, %w", err)
 			}
@@ -201,9 +367,17 @@ func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options
 			if len(vectors) != len(texts) {
 				return fmt.Errorf("[bulkAdd] invalid vector length, expected=%d, got=%d", len(texts), len(vectors))
 			}
+
+			if !dimChecked {
+				if err = i.checkDims(tuples, vectors); err != nil {
+					return err
+				}
+				dimChecked = true
+			}
 		}
 
 		for _, t := range tuples {
+			pos := t.pos
 			fields := t.fields
 			for k, idx := range t.key2Idx {
 				fields[k] = vectors[idx]
@@ -219,6 +393,11 @@ func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options
 				Action:     "index",
 				DocumentID: t.id,
 				Body:       bytes.NewReader(b),
+				OnSuccess: func(ctx context.Context, item esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem) {
+					if res.DocumentID != "" {
+						ids[pos] = res.DocumentID
+					}
+				},
 				OnFailure: func(ctx context.Context, item esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem, err error) {
 					if err != nil {
 						log.Printf("ERROR: %s", err)
@@ -241,60 +420,28 @@ func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options
 		doc := docs[idx]
 		fields, err := i.config.DocumentToFields(ctx, doc)
 		if err != nil {
-			return fmt.Errorf("[bulkAdd] FieldMapping failed, %w", err)
-		}
-
-		rawFields := make(map[string]any, len(fields))
-		embSize := 0
-		for k, v := range fields {
-			rawFields[k] = v.Value
-			if v.EmbedKey != "" {
-				embSize++
-			}
+			return nil, fmt.Errorf("[bulkAdd] FieldMapping failed, %w", err)
 		}
 
-		if embSize > i.config.BatchSize {
-			return fmt.Errorf("[bulkAdd] needEmbeddingFields length over batch size, batch size=%d, got size=%d",
-				i.config.BatchSize, embSize)
+		rawFields, embTexts, err := splitFields(fields, i.config.BatchSize)
+		if err != nil {
+			return nil, err
 		}
 
-		if len(texts)+embSize > i.config.BatchSize {
+		if len(texts)+len(embTexts) > i.config.BatchSize {
 			if err = embAndAdd(); err != nil {
-				return err
+				return nil, err
 			}
 		}
 
-		key2Idx := make(map[string]int, embSize)
-		for k, v := range fields {
-			if v.EmbedKey != "" {
-				if _, found := fields[v.EmbedKey]; found {
-					return fmt.Errorf("[bulkAdd] duplicate key for origin key, key=%s", k)
-				}
-
-				if _, found := key2Idx[v.EmbedKey]; found {
-					return fmt.Errorf("[bulkAdd] duplicate key from embed_key, key=%s", v.EmbedKey)
-				}
-
-				var text string
-				if v.Stringify != nil {
-					text, err = v.Stringify(v.Value)
-					if err != nil {
-						return err
-					}
-				} else {
-					var ok bool
-					text, ok = v.Value.(string)
-					if !ok {
-						return fmt.Errorf("[bulkAdd] assert value as string failed, key=%s, emb_key=%s", k, v.EmbedKey)
-					}
-				}
-
-				key2Idx[v.EmbedKey] = len(texts)
-				texts = append(texts, text)
-			}
+		key2Idx := make(map[string]int, len(embTexts))
+		for embKey, text := range embTexts {
+			key2Idx[embKey] = len(texts)
+			texts = append(texts, text)
 		}
 
 		tuples = append(tuples, tuple{
+			pos:     idx,
 			id:      doc.ID,
 			fields:  rawFields,
 			key2Idx: key2Idx,
@@ -303,11 +450,84 @@ func (i *Indexer) bulkAdd(ctx context.Context, docs []*schema.Document, options
 
 	if len(tuples) > 0 {
 		if err = embAndAdd(); err != nil {
-			return err
+			return nil, err
+		}
+	}
+
+	if err = bi.Close(ctx); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// splitFields separates the values of fields from the texts to embed, keyed by EmbedKey,
+// and rejects mappings that cannot be stored.
+func splitFields(fields map[string]FieldValue, batchSize int) (rawFields map[string]any, embTexts map[string]string, err error) {
+	rawFields = make(map[string]any, len(fields))
+	embSize := 0
+	for k, v := range fields {
+		rawFields[k] = v.Value
+		if v.EmbedKey != "" {
+			embSize++
+		}
+	}
+
+	if embSize > batchSize {
+		return nil, nil, fmt.Errorf("[bulkAdd] needEmbeddingFields length over batch size, batch size=%d, got size=%d",
+			batchSize, embSize)
+	}
+
+	embTexts = make(map[string]string, embSize)
+	for k, v := range fields {
+		if v.EmbedKey == "" {
+			continue
+		}
+
+		if _, found := fields[v.EmbedKey]; found {
+			return nil, nil, fmt.Errorf("[bulkAdd] duplicate key for origin key, key=%s", k)
+		}
+
+		if _, found := embTexts[v.EmbedKey]; found {
+			return nil, nil, fmt.Errorf("[bulkAdd] duplicate key from embed_key, key=%s", v.EmbedKey)
+		}
+
+		var text string
+		if v.Stringify != nil {
+			text, err = v.Stringify(v.Value)
+			if err != nil {
+				return nil, nil, err
+			}
+		} else {
+			var ok bool
+			text, ok = v.Value.(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("[bulkAdd] assert value as string failed, key=%s, emb_key=%s", k, v.EmbedKey)
+			}
+		}
+
+		embTexts[v.EmbedKey] = text
+	}
+
+	return rawFields, embTexts, nil
+}
+
+// checkDims compares the vectors of a batch with the declared dimensions of their fields.
+func (i *Indexer) checkDims(tuples []tuple, vectors [][]float64) error {
+	if len(i.dims) == 0 {
+		return nil
+	}
+
+	for _, t := range tuples {
+		for k, idx := range t.key2Idx {
+			if want, ok := i.dims[k]; ok && len(vectors[idx]) != want {
+				return fmt.Errorf("[bulkAdd] embedding dimension mismatch for field %s, expected=%d, got=%d, "+
+					"check that the embedding model matches the index mapping", k, want, len(vectors[idx]))
+			}
 		}
 	}
 
-	return bi.Close(ctx)
+	return nil
 }
 
 func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
@@ -335,6 +555,7 @@ func (i *Indexer) IsCallbacksEnabled() bool {
 }
 
 type tuple struct {
+	pos     int
 	id      string
 	fields  map[string]any
 	key2Idx map[string]int
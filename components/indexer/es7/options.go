@@ -0,0 +1,36 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es7
+
+import (
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+)
+
+// ImplOptions contains implementation-specific options for Store.
+type ImplOptions struct {
+	// EmbeddingOptions are passed to the embedder when the documents are vectorized,
+	// e.g. to choose a model or output dimension per call.
+	EmbeddingOptions []embedding.Option
+}
+
+// WithEmbeddingOptions returns an option that forwards opts to [embedding.Embedder.EmbedStrings].
+func WithEmbeddingOptions(opts ...embedding.Option) indexer.Option {
+	return indexer.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.EmbeddingOptions = opts
+	})
+}
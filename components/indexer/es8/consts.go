@@ -16,8 +16,14 @@
 
 package es8
 
+import "time"
+
 const typ = "ElasticSearch8"
 
 const (
 	defaultBatchSize = 5
+
+	defaultSetupTimeout = 10 * time.Second
+	defaultSetupRetries = 2
+	setupRetryBackoff   = 100 * time.Millisecond
 )
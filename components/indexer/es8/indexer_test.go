@@ -24,6 +24,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	. "github.com/bytedance/mockey"
 	"github.com/cloudwego/eino/components/embedding"
@@ -62,7 +63,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{size: []int{1}, mockVector: []float64{2.1}},
 			})
 			convey.So(err, convey.ShouldBeError, mockErr)
@@ -80,7 +81,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{size: []int{1}, mockVector: []float64{2.1}},
 			})
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] FieldMapping failed, %w", mockErr))
@@ -102,7 +103,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{size: []int{1}, mockVector: []float64{2.1}},
 			})
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] needEmbeddingFields length over batch size, batch size=%d, got size=%d", i.config.BatchSize, 2))
@@ -128,7 +129,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: nil,
 			})
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] embedding method not provided"))
@@ -154,7 +155,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{err: mockErr},
 			})
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] embedding failed, %w", mockErr))
@@ -180,12 +181,35 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{size: []int{1}, mockVector: []float64{2.1}},
 			})
 			convey.So(err, convey.ShouldBeError, fmt.Errorf("[bulkAdd] invalid vector length, expected=%d, got=%d", 2, 1))
 		})
 
+		PatchConvey("test dimension mismatch", func() {
+			mbi := &mockBulkIndexer{}
+			mockRetBI = mbi
+			mockRetErr = nil
+			i := &Indexer{
+				config: &IndexerConfig{
+					Index:     "mock_index",
+					BatchSize: 2,
+					DocumentToFields: func(ctx context.Context, doc *schema.Document) (field2Value map[string]FieldValue, err error) {
+						return map[string]FieldValue{
+							"k1": {Value: doc.Content, EmbedKey: "vk1"},
+						}, nil
+					},
+				},
+				dims: map[string]int{"vk1": 3},
+			}
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
+				Embedding: &mockEmbedding{size: []int{2}, mockVector: []float64{2.1, 2.2}},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "embedding dimension mismatch for field vk1, expected=3, got=2")
+		})
+
 		PatchConvey("test success", func() {
 			mbi := &mockBulkIndexer{}
 			mockRetBI = mbi
@@ -210,7 +234,7 @@ func TestBulkAdd(t *testing.T) {
 					},
 				},
 			}
-			err := i.bulkAdd(ctx, docs, &indexer.Options{
+			_, err := i.bulkAdd(ctx, docs, &indexer.Options{
 				Embedding: &mockEmbedding{size: []int{2, 2}, mockVector: []float64{2.1}},
 			})
 			convey.So(err, convey.ShouldBeNil)
@@ -329,6 +353,74 @@ func (m *mockTransportCreation) RoundTrip(req *http.Request) (*http.Response, er
 	return nil, fmt.Errorf("unexpected request: %s %s", req.Method, req.URL.String())
 }
 
+// mockTransportSetup answers index existence checks and creations with the queued statuses in turn,
+// repeating the last one. A status of 0 blocks the request until it is cancelled, like a slow cluster.
+type mockTransportSetup struct {
+	existsStatuses []int
+	createStatuses []int
+	existsCalls    int
+	createCalls    int
+}
+
+func (m *mockTransportSetup) RoundTrip(req *http.Request) (*http.Response, error) {
+	var status int
+	switch req.Method {
+	case "GET":
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"version":{"number":"8.16.0"}}`))),
+			Header:     http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+		}, nil
+	case "HEAD":
+		status = queued(m.existsStatuses, m.existsCalls)
+		m.existsCalls++
+	case "PUT":
+		status = queued(m.createStatuses, m.createCalls)
+		m.createCalls++
+	default:
+		return nil, fmt.Errorf("unexpected request: %s %s", req.Method, req.URL.String())
+	}
+
+	if status == 0 {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewReader([]byte(`{}`))),
+		Header:     http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+	}, nil
+}
+
+func queued(statuses []int, calls int) int {
+	if calls < len(statuses) {
+		return statuses[calls]
+	}
+	return statuses[len(statuses)-1]
+}
+
+func TestVectorDims(t *testing.T) {
+	convey.Convey("test vectorDims", t, func() {
+		convey.So(vectorDims(&IndexerConfig{}), convey.ShouldBeNil)
+
+		dims := vectorDims(&IndexerConfig{
+			IndexSpec: &IndexSpec{Mappings: map[string]any{
+				"properties": map[string]any{
+					"content":        map[string]any{"type": "text"},
+					"content_vector": map[string]any{"type": "dense_vector", "dims": 1024},
+					"extra_vector":   map[string]any{"type": "dense_vector", "dims": float64(8)},
+				},
+			}},
+			VectorDims: map[string]int{"extra_vector": 16, "other_vector": 4},
+		})
+		convey.So(dims, convey.ShouldResemble, map[string]int{
+			"content_vector": 1024,
+			"extra_vector":   16,
+			"other_vector":   4,
+		})
+	})
+}
+
 func TestNewIndexer(t *testing.T) {
 	PatchConvey("TestNewIndexer", t, func() {
 		ctx := context.Background()
@@ -478,5 +570,284 @@ func TestNewIndexer(t *testing.T) {
 			convey.So(err, convey.ShouldNotBeNil)
 			convey.So(err.Error(), convey.ShouldContainSubstring, "check index existence failed")
 		})
+
+		convey.Convey("IndexSpec - transient failures are retried", func() {
+			// The first existence check times out and the second fails with 500, then the index is
+			// reported missing; the first creation is throttled.
+			mockT := &mockTransportSetup{
+				existsStatuses: []int{0, 500, 404},
+				createStatuses: []int{429, 200},
+			}
+			client, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: mockT})
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:       client,
+				Index:        "test-index",
+				IndexSpec:    &IndexSpec{Settings: map[string]any{"number_of_shards": 1}},
+				SetupTimeout: 50 * time.Millisecond,
+				DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+					return nil, nil
+				},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(mockT.existsCalls, convey.ShouldEqual, 3)
+			convey.So(mockT.createCalls, convey.ShouldEqual, 2)
+		})
+
+		convey.Convey("IndexSpec - retries exhausted", func() {
+			mockT := &mockTransportSetup{existsStatuses: []int{503}}
+			client, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: mockT, DisableRetry: true})
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:       client,
+				Index:        "test-index",
+				IndexSpec:    &IndexSpec{},
+				SetupRetries: 1,
+				DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+					return nil, nil
+				},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "check index existence failed")
+			convey.So(mockT.existsCalls, convey.ShouldEqual, 2)
+		})
+
+		convey.Convey("IndexSpec - client errors are not retried", func() {
+			mockT := &mockTransportSetup{existsStatuses: []int{404}, createStatuses: []int{400}}
+			client, _ := elasticsearch.NewClient(elasticsearch.Config{Transport: mockT})
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:    client,
+				Index:     "test-index",
+				IndexSpec: &IndexSpec{},
+				DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+					return nil, nil
+				},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "create index failed")
+			convey.So(mockT.createCalls, convey.ShouldEqual, 1)
+		})
+
+		convey.Convey("ValidateMapping - bad mapping fails construction", func() {
+			client, _ := elasticsearch.NewClient(elasticsearch.Config{})
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:          client,
+				ValidateMapping: true,
+				DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+					return map[string]FieldValue{
+						"content": {Value: doc.Content, EmbedKey: "content_vector"},
+						"page":    {Value: 1, EmbedKey: "page_vector"},
+					}, nil
+				},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "[NewIndexer] validate mapping failed")
+			convey.So(err.Error(), convey.ShouldContainSubstring, "assert value as string failed, key=page, emb_key=page_vector")
+		})
+
+		convey.Convey("ValidateMapping - probe document", func() {
+			var mapped []*schema.Document
+			docToFields := func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+				mapped = append(mapped, doc)
+				return map[string]FieldValue{
+					"content": {Value: doc.Content, EmbedKey: "content_vector"},
+					"author":  {Value: doc.MetaData["author"]},
+				}, nil
+			}
+			client, _ := elasticsearch.NewClient(elasticsearch.Config{})
+
+			probe := &schema.Document{ID: "1", Content: "probe", MetaData: map[string]any{"author": "a"}}
+			_, err := NewIndexer(ctx, &IndexerConfig{
+				Client:           client,
+				ValidateMapping:  true,
+				MappingProbe:     probe,
+				DocumentToFields: docToFields,
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(mapped, convey.ShouldResemble, []*schema.Document{probe})
+
+			// DocumentToFields is not called without ValidateMapping.
+			_, err = NewIndexer(ctx, &IndexerConfig{
+				Client:           client,
+				DocumentToFields: docToFields,
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(mapped), convey.ShouldEqual, 1)
+		})
+	})
+}
+
+func TestStoreIDOrder(t *testing.T) {
+	PatchConvey("test Store id order with out of order bulk responses", t, func() {
+		ctx := context.Background()
+
+		// Flush every document in its own bulk request, spread over several workers.
+		var origin func(esutil.BulkIndexerConfig) (esutil.BulkIndexer, error)
+		Mock(esutil.NewBulkIndexer).Origin(&origin).To(func(cfg esutil.BulkIndexerConfig) (esutil.BulkIndexer, error) {
+			cfg.NumWorkers = 4
+			cfg.FlushBytes = 1
+			return origin(cfg)
+		}).Build()
+
+		mockT := &mockTransportBulk{
+			failed: map[string]bool{"d2": true},
+			delay: func(content string) time.Duration {
+				// earlier documents are answered later
+				return time.Duration('9'-content[1]) * 5 * time.Millisecond
+			},
+		}
+		client, err := elasticsearch.NewClient(elasticsearch.Config{Transport: mockT})
+		convey.So(err, convey.ShouldBeNil)
+
+		idx, err := NewIndexer(ctx, &IndexerConfig{
+			Client: client,
+			Index:  "test_index",
+			DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+				return map[string]FieldValue{"content": {Value: doc.Content}}, nil
+			},
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		ids, err := idx.Store(ctx, []*schema.Document{
+			{ID: "a", Content: "d0"},
+			{Content: "d1"},
+			{Content: "d2"},
+			{ID: "d", Content: "d3"},
+			{Content: "d4"},
+		})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(ids, convey.ShouldResemble, []string{"a", "es-d1", "", "d", "es-d4"})
+	})
+}
+
+func TestPing(t *testing.T) {
+	PatchConvey("test Ping", t, func() {
+		ctx := context.Background()
+		newIndexer := func(mockT *mockTransportPing) *Indexer {
+			client, err := elasticsearch.NewClient(elasticsearch.Config{Transport: mockT})
+			convey.So(err, convey.ShouldBeNil)
+			return &Indexer{client: client, config: &IndexerConfig{Index: "test-index"}}
+		}
+
+		PatchConvey("success", func() {
+			mockT := &mockTransportPing{infoStatus: 200, existsStatus: 200}
+			convey.So(newIndexer(mockT).Ping(ctx), convey.ShouldBeNil)
+			// the client may send its own product check first, so only the trailing calls are Ping's
+			convey.So(len(mockT.paths), convey.ShouldBeGreaterThanOrEqualTo, 2)
+			convey.So(mockT.paths[len(mockT.paths)-2:], convey.ShouldResemble, []string{"GET /", "HEAD /test-index"})
+		})
+
+		PatchConvey("connection failed", func() {
+			mockT := &mockTransportPing{err: fmt.Errorf("connection refused")}
+			err := newIndexer(mockT).Ping(ctx)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "[Ping] connect to elasticsearch failed")
+		})
+
+		PatchConvey("authentication failed", func() {
+			mockT := &mockTransportPing{infoStatus: 401}
+			err := newIndexer(mockT).Ping(ctx)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "authentication failed, status=401")
+		})
+
+		PatchConvey("index not exists", func() {
+			mockT := &mockTransportPing{infoStatus: 200, existsStatus: 404}
+			err := newIndexer(mockT).Ping(ctx)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "index test-index does not exist")
+		})
 	})
 }
+
+// mockTransportPing answers the cluster info and index exists calls of Ping
+type mockTransportPing struct {
+	infoStatus   int
+	existsStatus int
+	err          error
+	paths        []string
+}
+
+func (m *mockTransportPing) RoundTrip(req *http.Request) (*http.Response, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.paths = append(m.paths, req.Method+" "+req.URL.Path)
+
+	status := m.existsStatus
+	body := ""
+	if req.Method == "GET" && req.URL.Path == "/" {
+		status = m.infoStatus
+		body = `{"version":{"number":"8.11.0"}}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+		Header:     http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+	}, nil
+}
+
+// mockTransportBulk answers bulk requests, assigning "es-<content>" to documents sent without an id
+// and rejecting the documents whose content is in failed. Each response is delayed by delay(content)
+// of its first document.
+type mockTransportBulk struct {
+	failed map[string]bool
+	delay  func(content string) time.Duration
+}
+
+func (m *mockTransportBulk) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == "GET" && req.URL.Path == "/" {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"version":{"number":"8.16.0"}}`))),
+			Header:     http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+		}, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(body), []byte("\n"))
+	items := make([]any, 0, len(lines)/2)
+	hasErrors := false
+	for j := 0; j+1 < len(lines); j += 2 {
+		var action map[string]map[string]any
+		var fields map[string]any
+		if err = json.Unmarshal(lines[j], &action); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(lines[j+1], &fields); err != nil {
+			return nil, err
+		}
+
+		content, _ := fields["content"].(string)
+		if j == 0 && m.delay != nil {
+			time.Sleep(m.delay(content))
+		}
+
+		if m.failed[content] {
+			hasErrors = true
+			items = append(items, map[string]any{"index": map[string]any{
+				"status": 400,
+				"error":  map[string]any{"type": "mapper_parsing_exception", "reason": "failed to parse"},
+			}})
+			continue
+		}
+
+		id, _ := action["index"]["_id"].(string)
+		if id == "" {
+			id = "es-" + content
+		}
+		items = append(items, map[string]any{"index": map[string]any{"_id": id, "status": 201}})
+	}
+
+	b, err := json.Marshal(map[string]any{"errors": hasErrors, "items": items})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewReader(b)),
+		Header:     http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+	}, nil
+}
@@ -29,3 +29,38 @@ func iter[T, D any](src []T, fn func(T) D) []D {
 
 	return resp
 }
+
+// vectorDims merges VectorDims with the dims of dense_vector fields declared in IndexSpec.Mappings.
+// Explicitly configured dimensions take precedence.
+func vectorDims(conf *IndexerConfig) map[string]int {
+	dims := make(map[string]int)
+
+	if conf.IndexSpec != nil {
+		props, _ := conf.IndexSpec.Mappings["properties"].(map[string]any)
+		for field, v := range props {
+			prop, ok := v.(map[string]any)
+			if !ok || prop["type"] != "dense_vector" {
+				continue
+			}
+
+			switch d := prop["dims"].(type) {
+			case int:
+				dims[field] = d
+			case int64:
+				dims[field] = int(d)
+			case float64:
+				dims[field] = int(d)
+			}
+		}
+	}
+
+	for field, d := range conf.VectorDims {
+		dims[field] = d
+	}
+
+	if len(dims) == 0 {
+		return nil
+	}
+
+	return dims
+}
@@ -16,8 +16,17 @@
 
 package es9
 
+import "time"
+
 const typ = "ElasticSearch9"
 
 const (
 	defaultBatchSize = 5
+
+	defaultSetupTimeout = 10 * time.Second
+	defaultSetupRetries = 2
+	setupRetryBackoff   = 100 * time.Millisecond
+
+	defaultReindexBatchSize = 100
+	defaultScrollKeepAlive  = time.Minute
 )
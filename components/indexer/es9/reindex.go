@@ -0,0 +1,250 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es9
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+
+	elasticsearch "github.com/elastic/go-elasticsearch/v9"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// ReindexConfig configures [Indexer.Reindex].
+type ReindexConfig struct {
+	// SourceClient is the client used to read the source index.
+	// Optional. Defaults to the indexer's client.
+	SourceClient *elasticsearch.Client
+	// SourceIndex is the index to read documents from. Required.
+	SourceIndex string
+	// Query restricts the documents read from SourceIndex, e.g. {"term": {"lang": "en"}}.
+	// Optional. Defaults to match_all.
+	Query map[string]any
+	// BatchSize is the number of documents read and written per batch.
+	// Default is 100.
+	BatchSize int
+	// ScrollKeepAlive is how long the scroll context is kept between batches.
+	// Default is 1 minute.
+	ScrollKeepAlive time.Duration
+	// SourceToDocument converts a hit of the source index back to an Eino document. Required.
+	SourceToDocument func(ctx context.Context, id string, source map[string]any) (*schema.Document, error)
+	// Transform, if set, is applied to every document before it is written.
+	// Returning a nil document skips it; returning an error records a failure for it.
+	Transform func(ctx context.Context, doc *schema.Document) (*schema.Document, error)
+	// OnProgress, if set, is called after every batch has been written.
+	OnProgress func(progress ReindexProgress)
+}
+
+// ReindexProgress reports the running totals of a reindex.
+type ReindexProgress struct {
+	// Read is the number of documents read from the source index.
+	Read int
+	// Indexed is the number of documents written to the target index.
+	Indexed int
+	// Skipped is the number of documents dropped by Transform.
+	Skipped int
+	// Failed is the number of documents that could not be converted, transformed or written.
+	Failed int
+}
+
+// ReindexFailure describes a single document that could not be reindexed.
+type ReindexFailure struct {
+	ID  string
+	Err error
+}
+
+// ReindexResult is returned by [Indexer.Reindex].
+type ReindexResult struct {
+	ReindexProgress
+	Failures []ReindexFailure
+}
+
+// Reindex reads documents from conf.SourceIndex and stores them into the indexer's index in batches.
+// Documents are written with the indexer's DocumentToFields, so fields with an EmbedKey are re-embedded
+// with the configured (or option provided) embedder.
+// Per-document failures are collected in the result; an error is only returned when reading the
+// source index, embedding or the bulk request itself fails.
+func (i *Indexer) Reindex(ctx context.Context, conf *ReindexConfig, opts ...indexer.Option) (*ReindexResult, error) {
+	if conf == nil || conf.SourceIndex == "" {
+		return nil, fmt.Errorf("[Reindex] source index not provided")
+	}
+	if conf.SourceToDocument == nil {
+		return nil, fmt.Errorf("[Reindex] SourceToDocument method not provided")
+	}
+
+	client := conf.SourceClient
+	if client == nil {
+		client = i.client
+	}
+	batchSize := conf.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultReindexBatchSize
+	}
+	keepAlive := conf.ScrollKeepAlive
+	if keepAlive <= 0 {
+		keepAlive = defaultScrollKeepAlive
+	}
+	query := conf.Query
+	if query == nil {
+		query = map[string]any{"match_all": map[string]any{}}
+	}
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+	implOptions := indexer.GetImplSpecificOptions(&ImplOptions{}, opts...)
+
+	body, err := json.Marshal(map[string]any{"query": query})
+	if err != nil {
+		return nil, fmt.Errorf("[Reindex] marshal query failed, %w", err)
+	}
+
+	res, err := esapi.SearchRequest{
+		Index:  []string{conf.SourceIndex},
+		Body:   bytes.NewReader(body),
+		Size:   &batchSize,
+		Scroll: keepAlive,
+		Sort:   []string{"_doc"},
+	}.Do(ctx, client)
+	page, err := decodeScrollPage(res, err)
+	if err != nil {
+		return nil, fmt.Errorf("[Reindex] search source index failed, %w", err)
+	}
+
+	var (
+		result = &ReindexResult{}
+		mu     sync.Mutex
+	)
+	// bulk failures are reported from the bulk indexer's worker goroutines
+	onFailure := func(id string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.fail(id, err)
+	}
+
+	scrollID := page.ScrollID
+	defer func() {
+		if scrollID != "" {
+			clearRes, clearErr := esapi.ClearScrollRequest{ScrollID: []string{scrollID}}.Do(context.WithoutCancel(ctx), client)
+			if clearErr == nil && clearRes.Body != nil {
+				_ = clearRes.Body.Close()
+			}
+		}
+	}()
+
+	for len(page.Hits.Hits) > 0 {
+		if err = ctx.Err(); err != nil {
+			return result, err
+		}
+
+		docs := make([]*schema.Document, 0, len(page.Hits.Hits))
+		for _, hit := range page.Hits.Hits {
+			result.Read++
+
+			doc, err := conf.SourceToDocument(ctx, hit.ID, hit.Source)
+			if err == nil && conf.Transform != nil && doc != nil {
+				doc, err = conf.Transform(ctx, doc)
+			}
+			if err != nil {
+				result.fail(hit.ID, err)
+				continue
+			}
+			if doc == nil {
+				result.Skipped++
+				continue
+			}
+
+			docs = append(docs, doc)
+		}
+
+		if len(docs) > 0 {
+			failed := len(result.Failures)
+			if _, err = i.bulkAddTo(ctx, i.config.Index, docs, options, implOptions.EmbeddingOptions, onFailure); err != nil {
+				return result, fmt.Errorf("[Reindex] write batch failed, %w", err)
+			}
+			result.Indexed += len(docs) - (len(result.Failures) - failed)
+		}
+
+		if conf.OnProgress != nil {
+			conf.OnProgress(result.ReindexProgress)
+		}
+
+		res, err = esapi.ScrollRequest{
+			ScrollID: scrollID,
+			Scroll:   keepAlive,
+		}.Do(ctx, client)
+		page, err = decodeScrollPage(res, err)
+		if err != nil {
+			return result, fmt.Errorf("[Reindex] scroll source index failed, %w", err)
+		}
+		if page.ScrollID != "" {
+			scrollID = page.ScrollID
+		}
+	}
+
+	return result, nil
+}
+
+func (r *ReindexResult) fail(id string, err error) {
+	r.Failed++
+	r.Failures = append(r.Failures, ReindexFailure{ID: id, Err: err})
+}
+
+type scrollPage struct {
+	ScrollID string `json:"_scroll_id"`
+	Hits     struct {
+		Hits []struct {
+			ID     string         `json:"_id"`
+			Source map[string]any `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func decodeScrollPage(res *esapi.Response, err error) (*scrollPage, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if res.Body != nil {
+			_ = res.Body.Close()
+		}
+	}()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("response: %s", res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &scrollPage{}
+	if err = json.Unmarshal(b, page); err != nil {
+		return nil, fmt.Errorf("unmarshal response failed, %w", err)
+	}
+
+	return page, nil
+}
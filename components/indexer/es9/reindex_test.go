@@ -0,0 +1,251 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es9
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v9"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestReindex(t *testing.T) {
+	PatchConvey("test Reindex", t, func() {
+		ctx := context.Background()
+
+		PatchConvey("test missing config", func() {
+			idx := &Indexer{config: &IndexerConfig{}}
+			_, err := idx.Reindex(ctx, &ReindexConfig{})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[Reindex] source index not provided"))
+
+			_, err = idx.Reindex(ctx, &ReindexConfig{SourceIndex: "src"})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[Reindex] SourceToDocument method not provided"))
+		})
+
+		PatchConvey("test read transform write", func() {
+			mockT := &mockTransportReindex{
+				pages: []string{
+					`{"_scroll_id":"s1","hits":{"hits":[{"_id":"1","_source":{"text":"asd"}},{"_id":"2","_source":{"text":"qwe"}}]}}`,
+					`{"_scroll_id":"s2","hits":{"hits":[{"_id":"3","_source":{"text":"zxc"}},{"_id":"4","_source":{"text":"skip"}}]}}`,
+					`{"_scroll_id":"s2","hits":{"hits":[]}}`,
+				},
+				failIDs: map[string]bool{"3": true},
+			}
+			client, err := elasticsearch.NewClient(elasticsearch.Config{Transport: mockT})
+			convey.So(err, convey.ShouldBeNil)
+
+			idx, err := NewIndexer(ctx, &IndexerConfig{
+				Client: client,
+				Index:  "dst",
+				DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+					return map[string]FieldValue{
+						"content": {Value: doc.Content, EmbedKey: "content_vector"},
+					}, nil
+				},
+				Embedding: &mockEmbedding{size: []int{2, 1}, mockVector: []float64{2.1}},
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			var progress []ReindexProgress
+			result, err := idx.Reindex(ctx, &ReindexConfig{
+				SourceIndex: "src",
+				BatchSize:   2,
+				SourceToDocument: func(ctx context.Context, id string, source map[string]any) (*schema.Document, error) {
+					return &schema.Document{ID: id, Content: source["text"].(string)}, nil
+				},
+				Transform: func(ctx context.Context, doc *schema.Document) (*schema.Document, error) {
+					if doc.Content == "skip" {
+						return nil, nil
+					}
+					doc.Content = strings.ToUpper(doc.Content)
+					return doc, nil
+				},
+				OnProgress: func(p ReindexProgress) {
+					progress = append(progress, p)
+				},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(result.ReindexProgress, convey.ShouldResemble, ReindexProgress{Read: 4, Indexed: 2, Skipped: 1, Failed: 1})
+			convey.So(len(result.Failures), convey.ShouldEqual, 1)
+			convey.So(result.Failures[0].ID, convey.ShouldEqual, "3")
+			convey.So(result.Failures[0].Err.Error(), convey.ShouldContainSubstring, "mapper_parsing_exception")
+			convey.So(progress, convey.ShouldResemble, []ReindexProgress{
+				{Read: 2, Indexed: 2},
+				{Read: 4, Indexed: 2, Skipped: 1, Failed: 1},
+			})
+
+			convey.So(mockT.searchIndex, convey.ShouldEqual, "src")
+			convey.So(mockT.scrolls, convey.ShouldEqual, 2)
+			convey.So(mockT.clearedID, convey.ShouldEqual, "s2")
+			convey.So(mockT.written, convey.ShouldResemble, map[string]map[string]any{
+				"1": {"content": "ASD", "content_vector": []any{2.1}},
+				"2": {"content": "QWE", "content_vector": []any{2.1}},
+				"3": {"content": "ZXC", "content_vector": []any{2.1}},
+			})
+		})
+
+		PatchConvey("test transform error", func() {
+			mockT := &mockTransportReindex{
+				pages: []string{
+					`{"_scroll_id":"s1","hits":{"hits":[{"_id":"1","_source":{"text":"asd"}}]}}`,
+					`{"_scroll_id":"s1","hits":{"hits":[]}}`,
+				},
+			}
+			client, err := elasticsearch.NewClient(elasticsearch.Config{Transport: mockT})
+			convey.So(err, convey.ShouldBeNil)
+
+			idx, err := NewIndexer(ctx, &IndexerConfig{
+				Client: client,
+				Index:  "dst",
+				DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+					return map[string]FieldValue{"content": {Value: doc.Content}}, nil
+				},
+			})
+			convey.So(err, convey.ShouldBeNil)
+
+			mockErr := fmt.Errorf("test err")
+			result, err := idx.Reindex(ctx, &ReindexConfig{
+				SourceIndex: "src",
+				SourceToDocument: func(ctx context.Context, id string, source map[string]any) (*schema.Document, error) {
+					return &schema.Document{ID: id}, nil
+				},
+				Transform: func(ctx context.Context, doc *schema.Document) (*schema.Document, error) {
+					return nil, mockErr
+				},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(result.ReindexProgress, convey.ShouldResemble, ReindexProgress{Read: 1, Failed: 1})
+			convey.So(result.Failures, convey.ShouldResemble, []ReindexFailure{{ID: "1", Err: mockErr}})
+			convey.So(len(mockT.written), convey.ShouldEqual, 0)
+		})
+
+		PatchConvey("test search error", func() {
+			mockT := &mockTransportReindex{searchStatus: 404}
+			client, err := elasticsearch.NewClient(elasticsearch.Config{Transport: mockT})
+			convey.So(err, convey.ShouldBeNil)
+
+			idx := &Indexer{client: client, config: &IndexerConfig{Index: "dst"}}
+			_, err = idx.Reindex(ctx, &ReindexConfig{
+				SourceIndex: "src",
+				SourceToDocument: func(ctx context.Context, id string, source map[string]any) (*schema.Document, error) {
+					return &schema.Document{ID: id}, nil
+				},
+			})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "[Reindex] search source index failed")
+		})
+	})
+}
+
+// mockTransportReindex serves scroll pages for the source index and records bulk writes
+type mockTransportReindex struct {
+	mu           sync.Mutex
+	pages        []string
+	searchStatus int
+	failIDs      map[string]bool
+
+	searchIndex string
+	scrolls     int
+	clearedID   string
+	written     map[string]map[string]any
+}
+
+func (m *mockTransportReindex) RoundTrip(req *http.Request) (*http.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path := req.URL.Path
+	switch {
+	case strings.HasSuffix(path, "/_search") && path != "/_search":
+		m.searchIndex = strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/_search")
+		if m.searchStatus != 0 {
+			return m.response(m.searchStatus, `{"error":"index_not_found_exception"}`), nil
+		}
+		return m.nextPage(), nil
+	case strings.HasPrefix(path, "/_search/scroll/") && req.Method == http.MethodDelete:
+		m.clearedID = strings.TrimPrefix(path, "/_search/scroll/")
+		return m.response(200, `{"succeeded":true}`), nil
+	case path == "/_search/scroll":
+		m.scrolls++
+		return m.nextPage(), nil
+	case strings.HasSuffix(path, "/_bulk"):
+		return m.bulk(req)
+	}
+
+	return nil, fmt.Errorf("unexpected request: %s %s", req.Method, req.URL.String())
+}
+
+func (m *mockTransportReindex) nextPage() *http.Response {
+	page := `{"hits":{"hits":[]}}`
+	if len(m.pages) > 0 {
+		page, m.pages = m.pages[0], m.pages[1:]
+	}
+	return m.response(200, page)
+}
+
+func (m *mockTransportReindex) bulk(req *http.Request) (*http.Response, error) {
+	if m.written == nil {
+		m.written = make(map[string]map[string]any)
+	}
+
+	var items []string
+	scanner := bufio.NewScanner(req.Body)
+	for scanner.Scan() {
+		var action map[string]struct {
+			ID string `json:"_id"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+			return nil, err
+		}
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("missing bulk item body")
+		}
+		var source map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &source); err != nil {
+			return nil, err
+		}
+
+		id := action["index"].ID
+		m.written[id] = source
+		if m.failIDs[id] {
+			items = append(items, fmt.Sprintf(`{"index":{"_id":%q,"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}`, id))
+		} else {
+			items = append(items, fmt.Sprintf(`{"index":{"_id":%q,"status":201}}`, id))
+		}
+	}
+
+	return m.response(200, fmt.Sprintf(`{"errors":%t,"items":[%s]}`, len(m.failIDs) > 0, strings.Join(items, ","))), nil
+}
+
+func (m *mockTransportReindex) response(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+		Header:     http.Header{"X-Elastic-Product": []string{"Elasticsearch"}, "Content-Type": []string{"application/json"}},
+	}
+}
@@ -40,13 +40,13 @@ func main() {
 		return
 	}
 
-	sql, err := libsql.InitLibSqlDb(ctx, "./vector.db", "")
+	sql, err := libsql.InitLibSqlDb("./vector.db", "")
 	if err != nil {
 		log.Printf("new embedder error: %v\n", err)
 		return
 	}
 
-	err = sql.Init(1024)
+	err = sql.Init(ctx, 1024)
 	if err != nil {
 		log.Printf("embedding error: %v\n", err)
 		return
@@ -55,9 +55,10 @@ func main() {
 	doc := &libsql.Document{
 		ID:        "111",
 		Content:   "水晶宫元宇宙制作平台",
+		Metadata:  map[string]string{"source": "example"},
 		Embedding: embedding[0],
 	}
-	sql.InsertChunk(doc)
+	sql.InsertChunk(ctx, doc)
 
 	log.Printf("embedding:\n")
 }
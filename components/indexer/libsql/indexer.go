@@ -0,0 +1,115 @@
+package libsql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+const typ = "LibSQL"
+
+type IndexerConfig struct {
+	// DB is the table the documents are written to, see InitLibSqlDb.
+	// Required
+	DB *LibSqlDb
+	// Embedding vectorizes the document contents.
+	// Required
+	Embedding embedding.Embedder
+}
+
+// Indexer stores documents into a libsql vector table.
+type Indexer struct {
+	config *IndexerConfig
+}
+
+func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
+	if config == nil || config.DB == nil {
+		return nil, fmt.Errorf("[NewIndexer] libsql db not provided")
+	}
+	if config.Embedding == nil {
+		return nil, fmt.Errorf("[NewIndexer] embedding not provided for libsql indexer")
+	}
+
+	return &Indexer{config: config}, nil
+}
+
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+	io := indexer.GetImplSpecificOptions(&ImplOptions{}, opts...)
+
+	// documents are upserted by id, an empty one would overwrite the other id-less documents
+	texts := make([]string, len(docs))
+	for k, doc := range docs {
+		if doc.ID == "" {
+			return nil, fmt.Errorf("[Store] document %d has no id", k)
+		}
+		texts[k] = doc.Content
+	}
+
+	vectors, err := options.Embedding.EmbedStrings(ctx, texts, io.EmbeddingOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("[Store] embedding failed, %w", err)
+	}
+	if len(vectors) != len(docs) {
+		return nil, fmt.Errorf("[Store] invalid return length of vector, got=%d, expected=%d", len(vectors), len(docs))
+	}
+
+	ids = make([]string, 0, len(docs))
+	chunks := make([]*Document, 0, len(docs))
+	for k, doc := range docs {
+		metadata := make(map[string]string, len(doc.MetaData))
+		for key, v := range doc.MetaData {
+			metadata[key] = fmt.Sprint(v)
+		}
+
+		chunks = append(chunks, &Document{
+			ID:        doc.ID,
+			Metadata:  metadata,
+			Embedding: vectors[k],
+			Content:   doc.Content,
+		})
+		ids = append(ids, doc.ID)
+	}
+
+	// the batch is written atomically, errors.As the error to a *BatchInsertError for the failed ids
+	if err = i.config.DB.InsertChunks(ctx, chunks); err != nil {
+		return nil, fmt.Errorf("[Store] insert documents failed, %w", err)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+// Reset deletes all the documents of the table, e.g. before a full reindex.
+func (i *Indexer) Reset(ctx context.Context) error {
+	return i.config.DB.Reset(ctx)
+}
+
+// Count returns the number of documents in the table.
+func (i *Indexer) Count(ctx context.Context) (int, error) {
+	return i.config.DB.GetVectorCount(ctx)
+}
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,248 @@
+package libsql
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+type mockEmbedding struct {
+	vector []float64
+	model  string
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if model := embedding.GetCommonOptions(&embedding.Options{}, opts...).Model; model != nil {
+		m.model = *model
+	}
+	resp := make([][]float64, len(texts))
+	for i := range texts {
+		resp[i] = append([]float64(nil), m.vector...)
+	}
+	return resp, nil
+}
+
+func TestIndexerReset(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Init(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		DB:        db,
+		Embedding: &mockEmbedding{vector: []float64{1, 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = i.Store(ctx, []*schema.Document{
+		{ID: "1", Content: "hello", MetaData: map[string]any{"source": "a.txt"}},
+		{ID: "2", Content: "world", MetaData: map[string]any{"source": "b.txt"}},
+	})
+	if err != nil && strings.Contains(err.Error(), "vector32") {
+		t.Skipf("vector functions are not supported by the local driver: %v", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := i.Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("unexpected count before reset, got=%d, expected=2", count)
+	}
+
+	if err = i.Reset(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err = i.Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("unexpected count after reset, got=%d, expected=0", count)
+	}
+}
+
+// badContentEmbedding returns a vector of the wrong size for badContent.
+type badContentEmbedding struct {
+	badContent string
+}
+
+func (m *badContentEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	resp := make([][]float64, len(texts))
+	for i, text := range texts {
+		resp[i] = []float64{1, 0}
+		if text == m.badContent {
+			resp[i] = []float64{1, 0, 0}
+		}
+	}
+	return resp, nil
+}
+
+func TestIndexerStoreRollsBackOnFailure(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err = db.Init(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		DB:        db,
+		Embedding: &badContentEmbedding{badContent: "broken"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := i.Store(ctx, []*schema.Document{
+		{ID: "1", Content: "hello", MetaData: map[string]any{"source": "a.txt"}},
+		{ID: "2", Content: "broken", MetaData: map[string]any{"source": "b.txt"}},
+		{ID: "3", Content: "world", MetaData: map[string]any{"source": "c.txt"}},
+	})
+	if err != nil && strings.Contains(err.Error(), "vector32") {
+		t.Skipf("vector functions are not supported by the local driver: %v", err)
+	}
+	if err == nil {
+		t.Fatal("expected an error for the document with a wrong dimension")
+	}
+	if ids != nil {
+		t.Fatalf("expected no ids on failure, got=%v", ids)
+	}
+
+	var batchErr *BatchInsertError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected a *BatchInsertError, got=%v", err)
+	}
+	if len(batchErr.FailedIDs) != 1 || batchErr.FailedIDs[0] != "2" {
+		t.Fatalf("unexpected failed ids: %v", batchErr.FailedIDs)
+	}
+
+	count, err := i.Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("expected nothing committed, got=%d documents", count)
+	}
+}
+
+func TestIndexerStoreReplacesExistingID(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err = db.Init(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	i, err := NewIndexer(ctx, &IndexerConfig{
+		DB:        db,
+		Embedding: &mockEmbedding{vector: []float64{1, 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "hello", MetaData: map[string]any{"source": "a.txt"}}})
+	if err != nil && strings.Contains(err.Error(), "vector32") {
+		t.Skipf("vector functions are not supported by the local driver: %v", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// re-storing an id replaces the stored document instead of being skipped
+	if _, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "hello again", MetaData: map[string]any{"source": "b.txt"}}}); err != nil {
+		t.Fatal(err)
+	}
+	var content, source string
+	row := db.client.QueryRowContext(ctx, "SELECT pageContent, source FROM "+db.tableName+" WHERE id = ?", "1")
+	if err = row.Scan(&content, &source); err != nil {
+		t.Fatal(err)
+	}
+	if content != "hello again" || source != "b.txt" {
+		t.Fatalf("document not replaced, got content=%q source=%q", content, source)
+	}
+
+	// a duplicate content under another id is reported rather than ignored
+	_, err = i.Store(ctx, []*schema.Document{{ID: "2", Content: "hello again", MetaData: map[string]any{"source": "c.txt"}}})
+	var batchErr *BatchInsertError
+	if !errors.As(err, &batchErr) || len(batchErr.FailedIDs) != 1 || batchErr.FailedIDs[0] != "2" {
+		t.Fatalf("expected document 2 to fail, got=%v", err)
+	}
+
+	count, err := i.Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("unexpected count, got=%d, expected=1", count)
+	}
+}
+
+func TestIndexerEmbeddingOptions(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err = db.Init(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	emb := &mockEmbedding{vector: []float64{1, 0}}
+	i, err := NewIndexer(ctx, &IndexerConfig{DB: db, Embedding: emb})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the documents are embedded before the insert, whether or not the local driver supports it
+	_, _ = i.Store(ctx, []*schema.Document{{ID: "1", Content: "hello", MetaData: map[string]any{"source": "a.txt"}}},
+		WithEmbeddingOptions(embedding.WithModel("text-embedding-3-small")))
+	if emb.model != "text-embedding-3-small" {
+		t.Fatalf("embedding options not forwarded, got model=%q", emb.model)
+	}
+}
+
+func TestIndexerStoreRejectsEmptyID(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	i, err := NewIndexer(ctx, &IndexerConfig{DB: db, Embedding: &mockEmbedding{vector: []float64{1, 0}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = i.Store(ctx, []*schema.Document{
+		{ID: "1", Content: "hello", MetaData: map[string]any{"source": "a.txt"}},
+		{Content: "world", MetaData: map[string]any{"source": "b.txt"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "document 1 has no id") {
+		t.Fatalf("expected an error for the document without id, got=%v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package libsql
+
+import (
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+)
+
+// ImplOptions contains implementation-specific options for Store.
+type ImplOptions struct {
+	// EmbeddingOptions are passed to the embedder when the documents are vectorized.
+	EmbeddingOptions []embedding.Option
+}
+
+// WithEmbeddingOptions forwards opts to the embedder, e.g. to set a task type or dimension per Store call.
+func WithEmbeddingOptions(opts ...embedding.Option) indexer.Option {
+	return indexer.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.EmbeddingOptions = opts
+	})
+}
+
+// RetrieverImplOptions contains implementation-specific options for Retrieve.
+type RetrieverImplOptions struct {
+	// EmbeddingOptions are passed to the embedder when the query is vectorized.
+	EmbeddingOptions []embedding.Option
+}
+
+// WithQueryEmbeddingOptions forwards opts to the embedder when the query is vectorized,
+// they should match the ones the documents were stored with.
+func WithQueryEmbeddingOptions(opts ...embedding.Option) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *RetrieverImplOptions) {
+		o.EmbeddingOptions = opts
+	})
+}
@@ -0,0 +1,125 @@
+package libsql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+const defaultTopK = 5
+
+type RetrieverConfig struct {
+	// DB is the table written by the libsql Indexer.
+	// Required
+	DB *LibSqlDb
+	// Embedding vectorizes the query, it should be the one used by the Indexer.
+	// Required
+	Embedding embedding.Embedder
+	// TopK is the number of documents returned.
+	// Optional. Default: 5
+	TopK int
+	// ScoreThreshold drops the documents scored below it, after ScoreTransform is applied.
+	// Optional.
+	ScoreThreshold *float64
+	// ScoreTransform maps the cosine similarity (1 - cosine distance) to the document score,
+	// e.g. to align score ranges across stores before a shared reranker.
+	// Optional. Default: identity
+	ScoreTransform func(similarity float64) float64
+}
+
+// Retriever searches a libsql vector table by cosine distance.
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config == nil || config.DB == nil {
+		return nil, fmt.Errorf("[NewRetriever] libsql db not provided")
+	}
+	if config.Embedding == nil {
+		return nil, fmt.Errorf("[NewRetriever] embedding not provided for libsql retriever")
+	}
+	if config.TopK == 0 {
+		config.TopK = defaultTopK
+	}
+
+	return &Retriever{config: config}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	options := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		Embedding:      r.config.Embedding,
+	}, opts...)
+	io := retriever.GetImplSpecificOptions(&RetrieverImplOptions{}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *options.TopK,
+		ScoreThreshold: options.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	vectors, err := options.Embedding.EmbedStrings(ctx, []string{query}, io.EmbeddingOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] embedding failed, %w", err)
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("[Retrieve] invalid return length of vector, got=%d, expected=1", len(vectors))
+	}
+
+	results, err := r.config.DB.similaritySearch(ctx, vectors[0], int64(*options.TopK))
+	if err != nil {
+		return nil, err
+	}
+
+	docs = make([]*schema.Document, 0, len(results))
+	for _, res := range results {
+		doc := r.toDocument(res)
+		if options.ScoreThreshold != nil && doc.Score() < *options.ScoreThreshold {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) toDocument(res *Result) *schema.Document {
+	doc := &schema.Document{
+		ID:       res.ID,
+		Content:  res.Content,
+		MetaData: make(map[string]any, len(res.Metadata)),
+	}
+	for k, v := range res.Metadata {
+		doc.MetaData[k] = v
+	}
+
+	score := float64(res.Similarity)
+	if r.config.ScoreTransform != nil {
+		score = r.config.ScoreTransform(score)
+	}
+
+	return doc.WithScore(score)
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
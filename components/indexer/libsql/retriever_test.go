@@ -0,0 +1,63 @@
+package libsql
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+func TestRetrieverScoreTransform(t *testing.T) {
+	ctx := context.Background()
+	res := &Result{ID: "1", Content: "hello", Metadata: map[string]string{"source": "a.txt"}, Similarity: 0.5}
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		DB:        &LibSqlDb{},
+		Embedding: &mockEmbedding{vector: []float64{1, 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score := r.toDocument(res).Score(); score != 0.5 {
+		t.Fatalf("default transform should be identity, got %v", score)
+	}
+
+	r, err = NewRetriever(ctx, &RetrieverConfig{
+		DB:             &LibSqlDb{},
+		Embedding:      &mockEmbedding{vector: []float64{1, 0}},
+		ScoreTransform: func(similarity float64) float64 { return similarity * 100 },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := r.toDocument(res)
+	if doc.Score() != 50 {
+		t.Fatalf("score transform not applied, got %v", doc.Score())
+	}
+	if doc.ID != "1" || doc.Content != "hello" || doc.MetaData["source"] != "a.txt" {
+		t.Fatalf("unexpected document: %v", doc)
+	}
+}
+
+func TestRetrieverEmbeddingOptions(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	emb := &mockEmbedding{vector: []float64{1, 0}}
+	r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: emb})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the query is embedded before the search, whether or not the local driver supports it
+	_, _ = r.Retrieve(ctx, "hello", WithQueryEmbeddingOptions(embedding.WithModel("text-embedding-3-small")))
+	if emb.model != "text-embedding-3-small" {
+		t.Fatalf("embedding options not forwarded, got model=%q", emb.model)
+	}
+}
@@ -6,7 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/cloudwego/eino/components/embedding"
 	_ "github.com/tursodatabase/libsql-client-go/libsql"
 	_ "modernc.org/sqlite"
 )
@@ -33,12 +40,43 @@ type Result struct {
 }
 
 type LibSqlDb struct {
-	tableName string
-	client    *sql.DB
-	ctx       context.Context
+	tableName  string
+	client     *sql.DB
+	dimensions int
+	// metadata keys materialized into their own indexed columns, see Init
+	indexedKeys []string
+
+	// prepared statements of the hot paths, created lazily once the table exists
+	mu         sync.Mutex
+	insertStmt *sql.Stmt
+	searchStmt *sql.Stmt
+}
+
+// DbOption tunes the connection pool of the database opened by InitLibSqlDb.
+type DbOption func(db *sql.DB)
+
+// WithMaxOpenConns sets the maximum number of open connections, see sql.DB.SetMaxOpenConns.
+func WithMaxOpenConns(n int) DbOption {
+	return func(db *sql.DB) {
+		db.SetMaxOpenConns(n)
+	}
 }
 
-func InitLibSqlDb(ctx context.Context, path, tableName string) (*LibSqlDb, error) {
+// WithMaxIdleConns sets the maximum number of idle connections, see sql.DB.SetMaxIdleConns.
+func WithMaxIdleConns(n int) DbOption {
+	return func(db *sql.DB) {
+		db.SetMaxIdleConns(n)
+	}
+}
+
+// WithConnMaxLifetime sets how long a connection may be reused, see sql.DB.SetConnMaxLifetime.
+func WithConnMaxLifetime(d time.Duration) DbOption {
+	return func(db *sql.DB) {
+		db.SetConnMaxLifetime(d)
+	}
+}
+
+func InitLibSqlDb(path, tableName string, opts ...DbOption) (*LibSqlDb, error) {
 	if len(tableName) == 0 {
 		tableName = "vectors"
 	}
@@ -48,65 +86,288 @@ func InitLibSqlDb(ctx context.Context, path, tableName string) (*LibSqlDb, error
 		fmt.Fprintf(os.Stderr, "failed to open db %s: %s", path, err)
 		return nil, err
 	}
+	for _, opt := range opts {
+		opt(db)
+	}
 
 	sqldb := &LibSqlDb{
 		tableName: tableName,
 		client:    db,
-		ctx:       ctx,
 	}
 	return sqldb, nil
 }
 
-func (sqldb *LibSqlDb) Init(dimensions int) error {
-	_, err := sqldb.client.ExecContext(sqldb.ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+// Close releases the prepared statements and closes the underlying database.
+func (sqldb *LibSqlDb) Close() error {
+	sqldb.mu.Lock()
+	defer sqldb.mu.Unlock()
+
+	for _, stmt := range []*sql.Stmt{sqldb.insertStmt, sqldb.searchStmt} {
+		if stmt != nil {
+			_ = stmt.Close()
+		}
+	}
+	sqldb.insertStmt, sqldb.searchStmt = nil, nil
+	return sqldb.client.Close()
+}
+
+// prepare returns the cached statement in *cached, preparing it on first use.
+func (sqldb *LibSqlDb) prepare(ctx context.Context, cached **sql.Stmt, query string) (*sql.Stmt, error) {
+	sqldb.mu.Lock()
+	defer sqldb.mu.Unlock()
+
+	if *cached != nil {
+		return *cached, nil
+	}
+	stmt, err := sqldb.client.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement %s: %w", query, err)
+	}
+	*cached = stmt
+	return stmt, nil
+}
+
+// vectorLiteral formats the vector as the text accepted by vector32().
+func vectorLiteral(vector []float64) string {
+	sb := strings.Builder{}
+	sb.WriteByte('[')
+	for i, v := range vector {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(v, 'f', 6, 64))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// metadataColumn is the column an indexed metadata key is materialized into.
+func metadataColumn(key string) string {
+	return "meta_" + key
+}
+
+// Init creates the table for vectors of the given dimensions.
+// Every metadata key of indexedKeys gets its own column, populated on insert and
+// covered by an index, so that filtering by it does not scan json_extract(metadata).
+// The columns are only created with the table, keys added later to an existing
+// table are not materialized.
+func (sqldb *LibSqlDb) Init(ctx context.Context, dimensions int, indexedKeys ...string) error {
+	for _, key := range indexedKeys {
+		if !metadataKeyPattern.MatchString(key) {
+			return fmt.Errorf("invalid indexed metadata key %q, expected letters, digits and underscores", key)
+		}
+	}
+
+	columns := ""
+	for _, key := range indexedKeys {
+		columns += fmt.Sprintf(",\n            %s TEXT", metadataColumn(key))
+	}
+	_, err := sqldb.client.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
             id              TEXT PRIMARY KEY,
             pageContent     TEXT UNIQUE,
             source          TEXT NOT NULL,
             vector          F32_BLOB(%d),
-            metadata        TEXT
-        );`, sqldb.tableName, dimensions))
+            metadata        TEXT%s
+        );`, sqldb.tableName, dimensions, columns))
+	if err != nil {
+		return err
+	}
 
-	return err
+	for _, key := range indexedKeys {
+		column := metadataColumn(key)
+		_, err = sqldb.client.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%s_%s ON %s (%s);`,
+			sqldb.tableName, column, sqldb.tableName, column))
+		if err != nil {
+			return err
+		}
+	}
+
+	sqldb.mu.Lock()
+	defer sqldb.mu.Unlock()
+	sqldb.dimensions = dimensions
+	sqldb.indexedKeys = append([]string(nil), indexedKeys...)
+	// the cached insert no longer matches the columns
+	if sqldb.insertStmt != nil {
+		_ = sqldb.insertStmt.Close()
+		sqldb.insertStmt = nil
+	}
+	return nil
 }
 
-func (sqldb *LibSqlDb) InsertChunk(doc *Document) error {
-	embeddings := ""
-	for _, v := range doc.Embedding {
-		if len(embeddings) > 0 {
-			embeddings = fmt.Sprintf("%s,%f", embeddings, v)
-		} else {
-			embeddings = fmt.Sprintf("%f", v)
+// InitAuto is Init with the dimensions detected by embedding a probe string with emb,
+// so that the table always matches the embedding model in use.
+func (sqldb *LibSqlDb) InitAuto(ctx context.Context, emb embedding.Embedder, indexedKeys ...string) error {
+	if emb == nil {
+		return fmt.Errorf("embedding not provided for dimension detection")
+	}
+
+	vectors, err := emb.EmbedStrings(ctx, []string{"dimension probe"})
+	if err != nil {
+		return fmt.Errorf("failed to embed dimension probe: %w", err)
+	}
+	if len(vectors) != 1 || len(vectors[0]) == 0 {
+		return fmt.Errorf("invalid dimension probe embedding, got %d vector(s)", len(vectors))
+	}
+
+	return sqldb.Init(ctx, len(vectors[0]), indexedKeys...)
+}
+
+func (sqldb *LibSqlDb) InsertChunk(ctx context.Context, doc *Document) error {
+	plan, err := sqldb.insertStatement(ctx)
+	if err != nil {
+		return err
+	}
+	return sqldb.insertWithStmt(ctx, plan.stmt, plan, doc)
+}
+
+// BatchInsertError is returned by InsertChunks when some documents could not be
+// written. The whole batch has been rolled back.
+type BatchInsertError struct {
+	// FailedIDs are the ids of the documents that failed, in input order.
+	FailedIDs []string
+	// Errs holds the error of each failed document, in the order of FailedIDs.
+	Errs []error
+}
+
+func (e *BatchInsertError) Error() string {
+	return fmt.Sprintf("failed to insert %d document(s) %v, batch rolled back: %v", len(e.FailedIDs), e.FailedIDs, e.Errs[0])
+}
+
+func (e *BatchInsertError) Unwrap() []error {
+	return e.Errs
+}
+
+// InsertChunks writes the documents in a single transaction. If any of them
+// fails nothing is committed and a *BatchInsertError lists the failed documents.
+func (sqldb *LibSqlDb) InsertChunks(ctx context.Context, docs []*Document) (err error) {
+	plan, err := sqldb.insertStatement(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := sqldb.client.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
 		}
+	}()
+
+	txStmt := tx.StmtContext(ctx, plan.stmt)
+	defer txStmt.Close()
+
+	var batchErr *BatchInsertError
+	for _, doc := range docs {
+		if insertErr := sqldb.insertWithStmt(ctx, txStmt, plan, doc); insertErr != nil {
+			if batchErr == nil {
+				batchErr = &BatchInsertError{}
+			}
+			batchErr.FailedIDs = append(batchErr.FailedIDs, doc.ID)
+			batchErr.Errs = append(batchErr.Errs, insertErr)
+		}
+	}
+	if batchErr != nil {
+		return batchErr
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// insertPlan is the insert statement along with the table layout it was built for.
+type insertPlan struct {
+	stmt        *sql.Stmt
+	dimensions  int
+	indexedKeys []string
+}
+
+// insertStatement returns the cached insert statement matching the indexed metadata columns.
+// The layout is read under the lock, so that a concurrent Init cannot change it mid-insert.
+// A document whose id is already stored replaces the stored row, any other constraint
+// violation, such as a duplicate pageContent under another id, fails the insert.
+func (sqldb *LibSqlDb) insertStatement(ctx context.Context) (*insertPlan, error) {
+	sqldb.mu.Lock()
+	defer sqldb.mu.Unlock()
+
+	plan := &insertPlan{stmt: sqldb.insertStmt, dimensions: sqldb.dimensions, indexedKeys: sqldb.indexedKeys}
+	if plan.stmt != nil {
+		return plan, nil
+	}
+
+	columns, placeholders, updates := "", "", ""
+	for _, key := range plan.indexedKeys {
+		column := metadataColumn(key)
+		columns += ", " + column
+		placeholders += ", ?"
+		updates += fmt.Sprintf(", %s = excluded.%s", column, column)
+	}
+	query := fmt.Sprintf(`INSERT INTO %s (id, pageContent, source, vector, metadata%s)
+            VALUES (?, ?, ?, vector32(?), ?%s)
+            ON CONFLICT(id) DO UPDATE SET pageContent = excluded.pageContent, source = excluded.source,
+            vector = excluded.vector, metadata = excluded.metadata%s;`, sqldb.tableName, columns, placeholders, updates)
+	stmt, err := sqldb.client.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement %s: %w", query, err)
+	}
+	sqldb.insertStmt = stmt
+	plan.stmt = stmt
+	return plan, nil
+}
+
+func (sqldb *LibSqlDb) insertWithStmt(ctx context.Context, stmt *sql.Stmt, plan *insertPlan, doc *Document) error {
+	// F32_BLOB(n) rejects vectors of another size with an opaque error, check it upfront
+	if plan.dimensions > 0 && len(doc.Embedding) != plan.dimensions {
+		return fmt.Errorf("embedding dimension mismatch for document %s, expected=%d, got=%d",
+			doc.ID, plan.dimensions, len(doc.Embedding))
+	}
+
+	// source is NOT NULL, but a missing key would be inserted as "", reject it explicitly
+	source := doc.Metadata["source"]
+	if source == "" {
+		return fmt.Errorf("document %s has no source metadata", doc.ID)
 	}
 
 	byteMeta, err := json.Marshal(doc.Metadata)
 	if err != nil {
 		return err
 	}
-	stmt := fmt.Sprintf(`INSERT OR IGNORE INTO %s (id, pageContent, vector, metadata)
-            VALUES (?, ?, vector32('[%s]'), ?);`, sqldb.tableName, embeddings)
-	_, err = sqldb.client.ExecContext(sqldb.ctx, stmt, doc.ID, doc.Content, string(byteMeta))
+	args := []any{doc.ID, doc.Content, source, vectorLiteral(doc.Embedding), string(byteMeta)}
+	for _, key := range plan.indexedKeys {
+		if v, ok := doc.Metadata[key]; ok {
+			args = append(args, v)
+		} else {
+			args = append(args, nil)
+		}
+	}
+	_, err = stmt.ExecContext(ctx, args...)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to execute statement %s: %s", stmt, err)
+		fmt.Fprintf(os.Stderr, "failed to insert document %s: %s", doc.ID, err)
 		return err
 	}
 	return nil
 }
 
-func (sqldb *LibSqlDb) DeleteKeys(docId string) error {
-	stmt := fmt.Sprintf(`DELETE FROM %s WHERE
-	   id = '%s';`, sqldb.tableName, docId)
-	_, err := sqldb.client.ExecContext(sqldb.ctx, stmt)
+func (sqldb *LibSqlDb) DeleteKeys(ctx context.Context, docId string) error {
+	stmt := fmt.Sprintf(`DELETE FROM %s WHERE id = ?;`, sqldb.tableName)
+	_, err := sqldb.client.ExecContext(ctx, stmt, docId)
 	return err
 }
 
-func (sqldb *LibSqlDb) GetVectorCount() (int, error) {
+func (sqldb *LibSqlDb) GetVectorCount(ctx context.Context) (int, error) {
 	stmt := fmt.Sprintf(`SELECT count(id) as count FROM %s;`, sqldb.tableName)
-	query, err := sqldb.client.QueryContext(sqldb.ctx, stmt)
+	query, err := sqldb.client.QueryContext(ctx, stmt)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to execute query %s: %s", stmt, err)
-		os.Exit(1)
+		return 0, err
 	}
+	defer query.Close()
+
 	for query.Next() {
 		var v int
 		if err := query.Scan(&v); err != nil {
@@ -124,35 +385,119 @@ func (sqldb *LibSqlDb) GetVectorCount() (int, error) {
 	return 0, nil
 }
 
-func (sqldb *LibSqlDb) Reset() error {
+func (sqldb *LibSqlDb) Reset(ctx context.Context) error {
 	stmt := fmt.Sprintf(`DELETE FROM %s;`, sqldb.tableName)
-	_, err := sqldb.client.ExecContext(sqldb.ctx, stmt)
+	_, err := sqldb.client.ExecContext(ctx, stmt)
 	return err
 }
 
-func (sqldb *LibSqlDb) similaritySearch(queryEmbedding []float64, TopK int64) ([]*Result, error) {
-	const statement = `SELECT id, pageContent, metadata,
-	vector_distance_cos(vector, vector32('[%s]')) as distance
+const searchStatement = `SELECT id, pageContent, metadata,
+	vector_distance_cos(vector, vector32(?)) as distance
 FROM %s
-ORDER BY vector_distance_cos(vector, vector32('[%s]')) ASC
-LIMIT %d;`
+ORDER BY distance ASC
+LIMIT ?;`
 
-	embeddings := ""
-	for _, v := range queryEmbedding {
-		if len(embeddings) > 0 {
-			embeddings = fmt.Sprintf("%s,%f", embeddings, v)
-		} else {
-			embeddings = fmt.Sprintf("%f", v)
+func (sqldb *LibSqlDb) similaritySearch(ctx context.Context, queryEmbedding []float64, TopK int64) ([]*Result, error) {
+	stmt, err := sqldb.prepare(ctx, &sqldb.searchStmt, fmt.Sprintf(searchStatement, sqldb.tableName))
+	if err != nil {
+		return nil, err
+	}
+	return searchWithStmt(ctx, stmt, queryEmbedding, TopK)
+}
+
+// BatchSimilaritySearch runs one similarity search per query vector and returns
+// the results in the order of queries. The searches share a single read
+// transaction and the cached prepared statement to save round-trips.
+func (sqldb *LibSqlDb) BatchSimilaritySearch(ctx context.Context, queries [][]float64, topK int) ([][]*Result, error) {
+	if len(queries) == 0 {
+		return [][]*Result{}, nil
+	}
+
+	stmt, err := sqldb.prepare(ctx, &sqldb.searchStmt, fmt.Sprintf(searchStatement, sqldb.tableName))
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := sqldb.client.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	txStmt := tx.StmtContext(ctx, stmt)
+	defer txStmt.Close()
+
+	results := make([][]*Result, len(queries))
+	for i, query := range queries {
+		if results[i], err = searchWithStmt(ctx, txStmt, query, int64(topK)); err != nil {
+			return nil, fmt.Errorf("similarity search of query %d failed: %w", i, err)
 		}
 	}
+	return results, tx.Commit()
+}
 
-	stmt := fmt.Sprintf(statement, embeddings, sqldb.tableName, embeddings, TopK)
+// FilteredSimilaritySearch is a similarity search restricted to the documents
+// whose metadata equals every entry of filter. Keys declared as indexed at Init
+// are matched on their own column, the others fall back to json_extract.
+func (sqldb *LibSqlDb) FilteredSimilaritySearch(ctx context.Context, queryEmbedding []float64, topK int, filter map[string]string) ([]*Result, error) {
+	where, args := sqldb.filterClause(filter)
+	stmt, err := sqldb.client.PrepareContext(ctx, fmt.Sprintf(`SELECT id, pageContent, metadata,
+	vector_distance_cos(vector, vector32(?)) as distance
+FROM %s
+WHERE %s
+ORDER BY distance ASC
+LIMIT ?;`, sqldb.tableName, where))
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare filtered search: %w", err)
+	}
+	defer stmt.Close()
+
+	return searchWithStmt(ctx, stmt, queryEmbedding, int64(topK), args...)
+}
+
+// filterClause builds the WHERE clause matching filter, with keys in a stable order.
+func (sqldb *LibSqlDb) filterClause(filter map[string]string) (string, []any) {
+	if len(filter) == 0 {
+		return "1 = 1", nil
+	}
+
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-	query, err := sqldb.client.QueryContext(sqldb.ctx, stmt)
+	sqldb.mu.Lock()
+	indexed := make(map[string]bool, len(sqldb.indexedKeys))
+	for _, key := range sqldb.indexedKeys {
+		indexed[key] = true
+	}
+	sqldb.mu.Unlock()
+
+	conditions := make([]string, 0, len(keys))
+	args := make([]any, 0, len(keys)*2)
+	for _, key := range keys {
+		if indexed[key] {
+			conditions = append(conditions, metadataColumn(key)+" = ?")
+			args = append(args, filter[key])
+			continue
+		}
+		conditions = append(conditions, "json_extract(metadata, ?) = ?")
+		args = append(args, fmt.Sprintf("$.%q", key), filter[key])
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// searchWithStmt runs a search statement taking the query vector, the
+// filter arguments if any, then the limit.
+func searchWithStmt(ctx context.Context, stmt *sql.Stmt, queryEmbedding []float64, TopK int64, filterArgs ...any) ([]*Result, error) {
+	args := append([]any{vectorLiteral(queryEmbedding)}, filterArgs...)
+	query, err := stmt.QueryContext(ctx, append(args, TopK)...)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to execute query %s: %s", stmt, err)
+		fmt.Fprintf(os.Stderr, "failed to execute similarity search: %s", err)
 		return nil, err
 	}
+	defer query.Close()
 
 	datas := make([]*Result, 0)
 	for query.Next() {
@@ -162,9 +507,9 @@ LIMIT %d;`
 			fmt.Fprintf(os.Stderr, "failed to scan row: %s", err)
 			return nil, err
 		}
-		err = json.Unmarshal([]byte(meta), result.Metadata)
+		err = json.Unmarshal([]byte(meta), &result.Metadata)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "failed to execute query %s: %s", stmt, err)
+			fmt.Fprintf(os.Stderr, "failed to decode metadata of %s: %s", result.ID, err)
 			return nil, err
 		}
 		result.Similarity = 1 - result.Similarity
@@ -176,5 +521,4 @@ LIMIT %d;`
 		return datas, err
 	}
 	return datas, nil
-
 }
@@ -0,0 +1,343 @@
+package libsql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLibSqlDbCancelledContext(t *testing.T) {
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Init(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err = db.Reset(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled from Reset, got=%v", err)
+	}
+	if err = db.DeleteKeys(ctx, "1"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled from DeleteKeys, got=%v", err)
+	}
+
+	// the table is still usable with a live context
+	if err = db.Reset(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetVectorCountQueryError(t *testing.T) {
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the table was never created, so the count query fails
+	count, err := db.GetVectorCount(context.Background())
+	if err == nil {
+		t.Fatal("expected an error when the table does not exist")
+	}
+	if count != 0 {
+		t.Fatalf("unexpected count on error, got=%d", count)
+	}
+}
+
+func newBenchDb(b *testing.B) *LibSqlDb {
+	db, err := InitLibSqlDb(filepath.Join(b.TempDir(), "vector.db"), "",
+		WithMaxOpenConns(4), WithMaxIdleConns(4), WithConnMaxLifetime(time.Minute))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { _ = db.Close() })
+	if err = db.Init(context.Background(), 8); err != nil {
+		b.Fatal(err)
+	}
+	return db
+}
+
+func benchDocument(i int) *Document {
+	vector := make([]float64, 8)
+	for j := range vector {
+		vector[j] = float64((i+j)%10) / 10
+	}
+	id := strconv.Itoa(i)
+	return &Document{ID: id, Content: "content " + id, Metadata: map[string]string{"source": "bench"}, Embedding: vector}
+}
+
+// BenchmarkInsertChunk measures inserts through the cached prepared statement.
+func BenchmarkInsertChunk(b *testing.B) {
+	ctx := context.Background()
+	db := newBenchDb(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := db.InsertChunk(ctx, benchDocument(i)); err != nil {
+			if strings.Contains(err.Error(), "vector32") {
+				b.Skipf("vector functions are not supported by the local driver: %v", err)
+			}
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkInsertChunkUnprepared is the baseline formatting and parsing a fresh statement per insert.
+func BenchmarkInsertChunkUnprepared(b *testing.B) {
+	ctx := context.Background()
+	db := newBenchDb(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		doc := benchDocument(i)
+		stmt := fmt.Sprintf(`INSERT INTO %s (id, pageContent, source, vector, metadata)
+            VALUES (?, ?, ?, vector32('%s'), ?)
+            ON CONFLICT(id) DO UPDATE SET pageContent = excluded.pageContent, source = excluded.source,
+            vector = excluded.vector, metadata = excluded.metadata;`, db.tableName, vectorLiteral(doc.Embedding))
+		if _, err := db.client.ExecContext(ctx, stmt, doc.ID, doc.Content, "bench", `{"source":"bench"}`); err != nil {
+			if strings.Contains(err.Error(), "vector32") {
+				b.Skipf("vector functions are not supported by the local driver: %v", err)
+			}
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSimilaritySearch(b *testing.B) {
+	ctx := context.Background()
+	db := newBenchDb(b)
+	for i := 0; i < 100; i++ {
+		if err := db.InsertChunk(ctx, benchDocument(i)); err != nil {
+			b.Skipf("vector functions are not supported by the local driver: %v", err)
+		}
+	}
+
+	query := benchDocument(0).Embedding
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := db.similaritySearch(ctx, query, 5); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBatchSimilaritySearch(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err = db.Init(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, doc := range []*Document{
+		{ID: "x", Content: "along x", Metadata: map[string]string{"source": "a.txt"}, Embedding: []float64{1, 0}},
+		{ID: "y", Content: "along y", Metadata: map[string]string{"source": "b.txt"}, Embedding: []float64{0, 1}},
+	} {
+		if err = db.InsertChunk(ctx, doc); err != nil {
+			if strings.Contains(err.Error(), "vector32") {
+				t.Skipf("vector functions are not supported by the local driver: %v", err)
+			}
+			t.Fatal(err)
+		}
+	}
+
+	results, err := db.BatchSimilaritySearch(ctx, [][]float64{{0, 1}, {1, 0}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("unexpected number of result sets, got=%d, expected=2", len(results))
+	}
+	for i, expected := range []string{"y", "x"} {
+		if len(results[i]) != 1 || results[i][0].ID != expected {
+			t.Fatalf("unexpected results for query %d: %v", i, results[i])
+		}
+	}
+}
+
+func TestFilteredSearchUsesIndexedColumn(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err = db.Init(ctx, 2, "tenant"); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Init(ctx, 2, "tenant-id"); err == nil {
+		t.Fatal("expected an error for an invalid metadata key")
+	}
+
+	where, args := db.filterClause(map[string]string{"tenant": "t1", "lang": "en"})
+	if where != `json_extract(metadata, ?) = ? AND meta_tenant = ?` {
+		t.Fatalf("unexpected where clause: %s", where)
+	}
+	if len(args) != 3 || args[0] != `$."lang"` || args[1] != "en" || args[2] != "t1" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+
+	where, args = db.filterClause(map[string]string{"tenant": "t1"})
+	rows, err := db.client.QueryContext(ctx, fmt.Sprintf("EXPLAIN QUERY PLAN SELECT id FROM %s WHERE %s", db.tableName, where), args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	plan := ""
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err = rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			t.Fatal(err)
+		}
+		plan += detail + "\n"
+	}
+	if !strings.Contains(plan, "idx_vectors_meta_tenant") {
+		t.Fatalf("filtered query does not use the metadata index, plan:\n%s", plan)
+	}
+
+	err = db.InsertChunk(ctx, &Document{ID: "1", Content: "hello", Metadata: map[string]string{"source": "a.txt", "tenant": "t1"}, Embedding: []float64{1, 0}})
+	if err != nil && strings.Contains(err.Error(), "vector32") {
+		t.Skipf("vector functions are not supported by the local driver: %v", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := db.FilteredSimilaritySearch(ctx, []float64{1, 0}, 5, map[string]string{"tenant": "t2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected no results for another tenant, got=%v", results)
+	}
+	results, err = db.FilteredSimilaritySearch(ctx, []float64{1, 0}, 5, map[string]string{"tenant": "t1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].ID != "1" {
+		t.Fatalf("unexpected results: %v", results)
+	}
+}
+
+func TestInitAuto(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err = db.InitAuto(ctx, &mockEmbedding{vector: []float64{0.1, 0.2, 0.3}}); err != nil {
+		t.Fatal(err)
+	}
+	if db.dimensions != 3 {
+		t.Fatalf("unexpected detected dimensions, got=%d, expected=3", db.dimensions)
+	}
+
+	err = db.InsertChunk(ctx, &Document{ID: "1", Content: "hello", Metadata: map[string]string{"source": "a.txt"}, Embedding: []float64{1, 0}})
+	if err == nil || !strings.Contains(err.Error(), "dimension mismatch") {
+		t.Fatalf("expected a dimension mismatch error, got=%v", err)
+	}
+
+	if err = db.InitAuto(ctx, &mockEmbedding{}); err == nil {
+		t.Fatal("expected an error for an empty probe embedding")
+	}
+}
+
+func TestInsertChunkRequiresSource(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err = db.Init(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.InsertChunk(ctx, &Document{ID: "1", Content: "hello", Metadata: map[string]string{"page": "1"}, Embedding: []float64{1, 0}})
+	if err == nil || !strings.Contains(err.Error(), "has no source metadata") {
+		t.Fatalf("expected a missing source error, got=%v", err)
+	}
+}
+
+func TestDeleteKeysBindsID(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err = db.Init(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1", "2"} {
+		doc := &Document{ID: id, Content: "hello", Metadata: map[string]string{"source": "a.txt"}, Embedding: []float64{1, 0}}
+		if err = db.InsertChunk(ctx, doc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a quote in the id is data, it must not turn the condition into one matching every row
+	if err = db.DeleteKeys(ctx, "x' OR '1'='1"); err != nil {
+		t.Fatal(err)
+	}
+	if count, err := db.GetVectorCount(ctx); err != nil || count != 2 {
+		t.Fatalf("expected both rows to survive, got count=%d err=%v", count, err)
+	}
+
+	if err = db.DeleteKeys(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if count, err := db.GetVectorCount(ctx); err != nil || count != 1 {
+		t.Fatalf("expected one row left, got count=%d err=%v", count, err)
+	}
+}
+
+// run with -race: Init rewrites the table layout while inserts read it
+func TestInitConcurrentWithInsert(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitLibSqlDb(filepath.Join(t.TempDir(), "vector.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err = db.Init(ctx, 2, "tenant"); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			_ = db.Init(ctx, 2, "tenant")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			// the insert itself may fail, e.g. when the local driver lacks vector32 or Init closed the statement
+			_ = db.InsertChunks(ctx, []*Document{{
+				ID:        strconv.Itoa(i),
+				Content:   "hello " + strconv.Itoa(i),
+				Metadata:  map[string]string{"source": "a.txt", "tenant": "t1"},
+				Embedding: []float64{1, 0},
+			}})
+		}
+	}()
+	wg.Wait()
+}
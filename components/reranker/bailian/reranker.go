@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 
 	"github.com/cloudwego/eino/schema"
 )
@@ -17,6 +18,7 @@ const (
 
 type ReRanker struct {
 	config *ReRankerConfig
+	cache  *lruCache
 }
 
 type ReRankerConfig struct {
@@ -24,6 +26,9 @@ type ReRankerConfig struct {
 	ReturnDocuments bool   //是否返回documents
 	ApiKey          string //平台ApiKey
 	ApiURL          string
+	BatchSize       int          //单次请求的最大文档数，超出时分批请求后按分数合并排序，0表示不分批
+	CacheSize       int          //LRU缓存的最大条目数，按模型、query和文档集合缓存排序结果，0表示不缓存
+	HttpClient      *http.Client //为空时使用http.DefaultClient
 }
 
 func NewReRanker(ctx context.Context, opt *ReRankerConfig) (*ReRanker, error) {
@@ -36,8 +41,17 @@ func NewReRanker(ctx context.Context, opt *ReRankerConfig) (*ReRanker, error) {
 		config.Model = opt.Model
 		config.ApiKey = opt.ApiKey
 		config.ApiURL = opt.ApiURL
+		config.BatchSize = opt.BatchSize
+		config.CacheSize = opt.CacheSize
+		config.HttpClient = opt.HttpClient
+	}
+	if config.HttpClient == nil {
+		config.HttpClient = http.DefaultClient
 	}
 	reRanker := &ReRanker{config: config}
+	if config.CacheSize > 0 {
+		reRanker.cache = newLRUCache(config.CacheSize)
+	}
 	return reRanker, nil
 }
 
@@ -46,34 +60,76 @@ func (impl *ReRanker) ReRankDocuments(ctx context.Context, src []*schema.Documen
 	if len(src) <= 1 {
 		return src, nil
 	}
-	config := &RequestConfig{
-		Model:  impl.config.Model,
-		ApiKey: impl.config.ApiKey,
-		ApiUrl: impl.config.ApiURL,
-		Input: &RequestConfigInput{
-			Query:     query,
-			Documents: make([]string, 0),
-		},
-		Parameters: &RequestConfigParams{
-			ReturnDocuments: impl.config.ReturnDocuments,
-			TopK:            len(src),
-		},
-	}
-	for _, v := range src {
-		config.Input.Documents = append(config.Input.Documents, v.Content)
-	}
-	reRankData, err := doAliRerank(config)
+	var key string
+	if impl.cache != nil {
+		key = cacheKey(impl.config.Model, query, src)
+		if results, ok := impl.cache.get(key); ok {
+			return applyResults(src, results), nil
+		}
+	}
+	results, err := impl.rerank(query, src)
 	if err != nil {
 		return src, nil
 	}
-	dst := make([]*schema.Document, 0)
-	for i := 0; i < len(reRankData.Output.Results); i++ {
-		res := reRankData.Output.Results[i]
+	if impl.cache != nil {
+		impl.cache.add(key, results)
+	}
+
+	return applyResults(src, results), nil
+}
+
+// rerank 按BatchSize分批请求，合并各批结果并按分数从高到低排序
+func (impl *ReRanker) rerank(query string, src []*schema.Document) ([]*ReposeDataOutputResult, error) {
+	batchSize := impl.config.BatchSize
+	if batchSize <= 0 || batchSize > len(src) {
+		batchSize = len(src)
+	}
+	results := make([]*ReposeDataOutputResult, 0, len(src))
+	for offset := 0; offset < len(src); offset += batchSize {
+		batch := src[offset:min(offset+batchSize, len(src))]
+		config := &RequestConfig{
+			Model:  impl.config.Model,
+			ApiKey: impl.config.ApiKey,
+			ApiUrl: impl.config.ApiURL,
+			Input: &RequestConfigInput{
+				Query:     query,
+				Documents: make([]string, 0, len(batch)),
+			},
+			Parameters: &RequestConfigParams{
+				ReturnDocuments: impl.config.ReturnDocuments,
+				TopK:            len(batch),
+			},
+		}
+		for _, v := range batch {
+			config.Input.Documents = append(config.Input.Documents, v.Content)
+		}
+		reRankData, err := doAliRerank(impl.config.HttpClient, config)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range reRankData.Output.Results {
+			if res == nil || res.Index < 0 || res.Index >= len(batch) {
+				continue
+			}
+			results = append(results, &ReposeDataOutputResult{Index: offset + res.Index, Score: res.Score})
+		}
+	}
+	if batchSize < len(src) {
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Score > results[j].Score
+		})
+	}
+	return results, nil
+}
+
+// applyResults 按排序结果设置分数并返回重排后的文档
+func applyResults(src []*schema.Document, results []*ReposeDataOutputResult) []*schema.Document {
+	dst := make([]*schema.Document, 0, len(results))
+	for _, res := range results {
 		src[res.Index].WithScore(res.Score)
 		dst = append(dst, src[res.Index])
 	}
-
-	return dst, nil
+	return dst
 }
 
 // 请求参数
@@ -115,7 +171,7 @@ type ReposeData struct {
 	RequestId string            `json:"request_id"`
 }
 
-func doAliRerank(config *RequestConfig) (*ReposeData, error) {
+func doAliRerank(client *http.Client, config *RequestConfig) (*ReposeData, error) {
 	param, err := json.Marshal(config)
 	if err != nil {
 		return nil, err
@@ -133,7 +189,7 @@ func doAliRerank(config *RequestConfig) (*ReposeData, error) {
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", config.ApiKey))
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
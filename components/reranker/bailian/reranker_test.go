@@ -0,0 +1,122 @@
+package bailian
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// newMockServer 按文档内容中的数字打分，模拟百炼排序接口
+func newMockServer(t *testing.T, calls *int32, batchSizes *[]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		req := &RequestConfig{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		*batchSizes = append(*batchSizes, len(req.Input.Documents))
+		if req.Parameters.TopK != len(req.Input.Documents) {
+			t.Errorf("top_n = %d, want %d", req.Parameters.TopK, len(req.Input.Documents))
+		}
+		results := make([]*ReposeDataOutputResult, 0, len(req.Input.Documents))
+		for i, doc := range req.Input.Documents {
+			var score float64
+			_, _ = fmt.Sscanf(strings.TrimPrefix(doc, "doc"), "%f", &score)
+			results = append(results, &ReposeDataOutputResult{Index: i, Score: score / 10})
+		}
+		// 模拟接口按分数降序返回
+		sort.Slice(results, func(i, j int) bool {
+			return results[i].Score > results[j].Score
+		})
+		_ = json.NewEncoder(w).Encode(&ReposeData{Output: &ReposeDataOutput{Results: results}})
+	}))
+}
+
+func newDocs(scores ...int) []*schema.Document {
+	docs := make([]*schema.Document, 0, len(scores))
+	for _, score := range scores {
+		docs = append(docs, &schema.Document{Content: fmt.Sprintf("doc%d", score)})
+	}
+	return docs
+}
+
+func contents(docs []*schema.Document) []string {
+	res := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		res = append(res, doc.Content)
+	}
+	return res
+}
+
+func TestReRankDocuments_Batching(t *testing.T) {
+	var calls int32
+	var batchSizes []int
+	server := newMockServer(t, &calls, &batchSizes)
+	defer server.Close()
+
+	r, err := NewReRanker(context.Background(), &ReRankerConfig{Model: "gte-rerank", ApiURL: server.URL, BatchSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := r.ReRankDocuments(context.Background(), newDocs(3, 9, 1, 7, 5), "query")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(contents(docs)), "[doc9 doc7 doc5 doc3 doc1]"; got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+	if docs[0].Score() != 0.9 {
+		t.Errorf("score = %v, want 0.9", docs[0].Score())
+	}
+	if got, want := fmt.Sprint(batchSizes), "[2 2 1]"; got != want {
+		t.Errorf("batch sizes = %s, want %s", got, want)
+	}
+}
+
+func TestReRankDocuments_Cache(t *testing.T) {
+	var calls int32
+	var batchSizes []int
+	server := newMockServer(t, &calls, &batchSizes)
+	defer server.Close()
+
+	r, err := NewReRanker(context.Background(), &ReRankerConfig{Model: "gte-rerank", ApiURL: server.URL, CacheSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		docs, err := r.ReRankDocuments(context.Background(), newDocs(1, 3, 2), "query")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := fmt.Sprint(contents(docs)), "[doc3 doc2 doc1]"; got != want {
+			t.Errorf("order = %s, want %s", got, want)
+		}
+		if docs[0].Score() != 0.3 {
+			t.Errorf("score = %v, want 0.3", docs[0].Score())
+		}
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+
+	// 不同的query未命中缓存，并淘汰容量为1的缓存中的旧条目
+	if _, err = r.ReRankDocuments(context.Background(), newDocs(1, 3, 2), "other"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.ReRankDocuments(context.Background(), newDocs(1, 3, 2), "query"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
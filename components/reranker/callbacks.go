@@ -0,0 +1,76 @@
+package reranker
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/schema"
+)
+
+// ComponentOfReRanker is the component kind reported to callbacks.
+const ComponentOfReRanker components.Component = "ReRanker"
+
+// CallbackInput is passed to the OnStart callbacks of a ReRanker wrapped by WithCallbacks.
+type CallbackInput struct {
+	Query     string
+	Documents []*schema.Document
+	// DocumentCount is the number of documents to rerank.
+	DocumentCount int
+}
+
+// CallbackOutput is passed to the OnEnd callbacks of a ReRanker wrapped by WithCallbacks.
+type CallbackOutput struct {
+	Documents []*schema.Document
+	// DocumentCount is the number of documents returned by the reranker.
+	DocumentCount int
+}
+
+type callbackReRanker struct {
+	ReRanker
+	typ string
+}
+
+// WithCallbacks wraps r so that every ReRankDocuments call fires callbacks.OnStart, OnEnd and OnError,
+// reported with the given type and ComponentOfReRanker. A reranker that already fires its own callbacks,
+// i.e. implements components.Checker and enables them, is returned as is to avoid reporting it twice.
+func WithCallbacks(r ReRanker, typ string) ReRanker {
+	if components.IsCallbacksEnabled(r) {
+		return r
+	}
+	return &callbackReRanker{ReRanker: r, typ: typ}
+}
+
+func (c *callbackReRanker) ReRankDocuments(ctx context.Context, texts []*schema.Document, query string) (dst []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, c.typ, ComponentOfReRanker)
+	ctx = callbacks.OnStart(ctx, &CallbackInput{
+		Query:         query,
+		Documents:     texts,
+		DocumentCount: len(texts),
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	dst, err = c.ReRanker.ReRankDocuments(ctx, texts, query)
+	if err != nil {
+		return nil, err
+	}
+
+	callbacks.OnEnd(ctx, &CallbackOutput{
+		Documents:     dst,
+		DocumentCount: len(dst),
+	})
+
+	return dst, nil
+}
+
+func (c *callbackReRanker) GetType() string {
+	return c.typ
+}
+
+func (c *callbackReRanker) IsCallbacksEnabled() bool {
+	return true
+}
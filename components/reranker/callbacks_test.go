@@ -0,0 +1,96 @@
+package reranker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/schema"
+)
+
+type funcReRanker func(ctx context.Context, texts []*schema.Document, query string) ([]*schema.Document, error)
+
+func (f funcReRanker) ReRankDocuments(ctx context.Context, texts []*schema.Document, query string) ([]*schema.Document, error) {
+	return f(ctx, texts, query)
+}
+
+type enabledReRanker struct {
+	funcReRanker
+}
+
+func (enabledReRanker) IsCallbacksEnabled() bool {
+	return true
+}
+
+func TestWithCallbacks(t *testing.T) {
+	var input *CallbackInput
+	var output *CallbackOutput
+	var runErr error
+	var info *callbacks.RunInfo
+	handler := callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, ri *callbacks.RunInfo, in callbacks.CallbackInput) context.Context {
+			info = ri
+			input = in.(*CallbackInput)
+			return ctx
+		}).
+		OnEndFn(func(ctx context.Context, ri *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+			output = out.(*CallbackOutput)
+			return ctx
+		}).
+		OnErrorFn(func(ctx context.Context, ri *callbacks.RunInfo, err error) context.Context {
+			runErr = err
+			return ctx
+		}).
+		Build()
+	ctx := callbacks.InitCallbacks(context.Background(), nil, handler)
+
+	docs := []*schema.Document{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	r := WithCallbacks(funcReRanker(func(ctx context.Context, texts []*schema.Document, query string) ([]*schema.Document, error) {
+		return texts[:2], nil
+	}), "Test")
+
+	res, err := r.ReRankDocuments(ctx, docs, "query")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("unexpected result %v", res)
+	}
+	if info == nil || info.Type != "Test" || info.Component != ComponentOfReRanker {
+		t.Errorf("unexpected run info %+v", info)
+	}
+	if input == nil || input.Query != "query" || input.DocumentCount != 3 {
+		t.Errorf("unexpected callback input %+v", input)
+	}
+	if output == nil || output.DocumentCount != 2 || len(output.Documents) != 2 {
+		t.Errorf("unexpected callback output %+v", output)
+	}
+	if runErr != nil {
+		t.Errorf("unexpected callback error %v", runErr)
+	}
+
+	output = nil
+	failure := errors.New("rerank failed")
+	r = WithCallbacks(funcReRanker(func(ctx context.Context, texts []*schema.Document, query string) ([]*schema.Document, error) {
+		return nil, failure
+	}), "Test")
+	if _, err = r.ReRankDocuments(ctx, docs, "query"); !errors.Is(err, failure) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !errors.Is(runErr, failure) {
+		t.Errorf("OnError not fired, got %v", runErr)
+	}
+	if output != nil {
+		t.Errorf("OnEnd fired on error: %+v", output)
+	}
+}
+
+func TestWithCallbacks_AlreadyEnabled(t *testing.T) {
+	inner := enabledReRanker{funcReRanker(func(ctx context.Context, texts []*schema.Document, query string) ([]*schema.Document, error) {
+		return texts, nil
+	})}
+	if _, ok := WithCallbacks(inner, "Test").(enabledReRanker); !ok {
+		t.Errorf("expected a reranker with callbacks enabled to be returned as is")
+	}
+}
@@ -0,0 +1,207 @@
+package cohere
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultBaseURL = "https://api.cohere.com/v2/rerank"
+	defaultModel   = "rerank-v3.5"
+
+	typ = "Cohere"
+
+	// ComponentOfReRanker is the component kind reported to callbacks.
+	ComponentOfReRanker components.Component = "ReRanker"
+)
+
+type ReRankerConfig struct {
+	// APIKey is the Cohere API key. Required.
+	APIKey string
+	// Model is the rerank model. Default: "rerank-v3.5".
+	Model string
+	// BaseURL is the URL of the rerank endpoint. Default: "https://api.cohere.com/v2/rerank".
+	BaseURL string
+	// TopN keeps only the TopN best documents. Default: 0, which keeps all of them.
+	TopN int
+	// ScoreThreshold drops the documents scored below it. Default: nil, which keeps all of them.
+	ScoreThreshold *float64
+	// HTTPClient sends the requests. Default: http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// CallbackInput is passed to the OnStart callbacks of ReRankDocuments.
+type CallbackInput struct {
+	Query     string
+	Documents []*schema.Document
+	Model     string
+	TopN      int
+}
+
+// CallbackOutput is passed to the OnEnd callbacks of ReRankDocuments.
+type CallbackOutput struct {
+	Documents []*schema.Document
+	// SearchUnits is the number of search units billed for the request.
+	SearchUnits float64
+}
+
+type ReRanker struct {
+	config *ReRankerConfig
+}
+
+func NewReRanker(ctx context.Context, config *ReRankerConfig) (*ReRanker, error) {
+	if config == nil {
+		return nil, errors.New("reranker config is required")
+	}
+	if config.APIKey == "" {
+		return nil, errors.New("api key is required")
+	}
+
+	conf := *config
+	if conf.Model == "" {
+		conf.Model = defaultModel
+	}
+	if conf.BaseURL == "" {
+		conf.BaseURL = defaultBaseURL
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = http.DefaultClient
+	}
+
+	return &ReRanker{config: &conf}, nil
+}
+
+type rerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n,omitempty"`
+}
+
+type rerankResult struct {
+	Index          int     `json:"index"`
+	RelevanceScore float64 `json:"relevance_score"`
+}
+
+type rerankResponse struct {
+	ID      string          `json:"id"`
+	Results []*rerankResult `json:"results"`
+	Meta    *struct {
+		BilledUnits *struct {
+			SearchUnits float64 `json:"search_units"`
+		} `json:"billed_units"`
+	} `json:"meta"`
+	Message string `json:"message"`
+}
+
+// ReRankDocuments scores src against query with the Cohere rerank API, sets the
+// score of every returned document and returns them sorted by descending score.
+func (r *ReRanker) ReRankDocuments(ctx context.Context, src []*schema.Document, query string) (dst []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), ComponentOfReRanker)
+	ctx = callbacks.OnStart(ctx, &CallbackInput{
+		Query:     query,
+		Documents: src,
+		Model:     r.config.Model,
+		TopN:      r.config.TopN,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(src) == 0 {
+		callbacks.OnEnd(ctx, &CallbackOutput{Documents: src})
+		return src, nil
+	}
+
+	req := &rerankRequest{
+		Model:     r.config.Model,
+		Query:     query,
+		Documents: make([]string, 0, len(src)),
+		TopN:      r.config.TopN,
+	}
+	for _, doc := range src {
+		req.Documents = append(req.Documents, doc.Content)
+	}
+
+	resp, err := r.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	dst = make([]*schema.Document, 0, len(resp.Results))
+	for _, res := range resp.Results {
+		if res == nil || res.Index < 0 || res.Index >= len(src) {
+			return nil, fmt.Errorf("[Cohere] invalid result index in rerank response")
+		}
+		if r.config.ScoreThreshold != nil && res.RelevanceScore < *r.config.ScoreThreshold {
+			continue
+		}
+		dst = append(dst, src[res.Index].WithScore(res.RelevanceScore))
+	}
+
+	output := &CallbackOutput{Documents: dst}
+	if resp.Meta != nil && resp.Meta.BilledUnits != nil {
+		output.SearchUnits = resp.Meta.BilledUnits.SearchUnits
+	}
+	callbacks.OnEnd(ctx, output)
+
+	return dst, nil
+}
+
+func (r *ReRanker) do(ctx context.Context, req *rerankRequest) (*rerankResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("[Cohere] failed to marshal request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, r.config.BaseURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("[Cohere] failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+r.config.APIKey)
+
+	httpResp, err := r.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("[Cohere] failed to send request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("[Cohere] failed to read response: %w", err)
+	}
+
+	resp := &rerankResponse{}
+	if err = json.Unmarshal(respBody, resp); err != nil && httpResp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("[Cohere] failed to unmarshal response: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		msg := resp.Message
+		if msg == "" {
+			msg = string(respBody)
+		}
+		return nil, fmt.Errorf("[Cohere] rerank failed with status %d: %s", httpResp.StatusCode, msg)
+	}
+
+	return resp, nil
+}
+
+func (r *ReRanker) GetType() string {
+	return typ
+}
+
+func (r *ReRanker) IsCallbacksEnabled() bool {
+	return true
+}
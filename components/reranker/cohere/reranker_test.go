@@ -0,0 +1,144 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/schema"
+)
+
+type mockTransport struct {
+	RoundTripFunc func(*http.Request) (*http.Response, error)
+}
+
+func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return m.RoundTripFunc(req)
+}
+
+func newClient(status int, body string, check func(*http.Request)) *http.Client {
+	return &http.Client{Transport: &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if check != nil {
+				check(req)
+			}
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}}
+}
+
+func TestReRankDocuments(t *testing.T) {
+	var got rerankRequest
+	client := newClient(http.StatusOK,
+		`{"id":"1","results":[{"index":2,"relevance_score":0.9},{"index":0,"relevance_score":0.5},{"index":1,"relevance_score":0.1}],"meta":{"billed_units":{"search_units":1}}}`,
+		func(req *http.Request) {
+			if req.URL.String() != defaultBaseURL {
+				t.Errorf("url = %s", req.URL)
+			}
+			if auth := req.Header.Get("Authorization"); auth != "Bearer key" {
+				t.Errorf("authorization = %q", auth)
+			}
+			if err := json.NewDecoder(req.Body).Decode(&got); err != nil {
+				t.Errorf("decode request: %v", err)
+			}
+		})
+
+	threshold := 0.3
+	r, err := NewReRanker(context.Background(), &ReRankerConfig{
+		APIKey:         "key",
+		TopN:           3,
+		ScoreThreshold: &threshold,
+		HTTPClient:     client,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var input *CallbackInput
+	var output *CallbackOutput
+	handler := callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, in callbacks.CallbackInput) context.Context {
+			input = in.(*CallbackInput)
+			if info.Type != "Cohere" || info.Component != ComponentOfReRanker {
+				t.Errorf("unexpected run info %+v", info)
+			}
+			return ctx
+		}).
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+			output = out.(*CallbackOutput)
+			return ctx
+		}).
+		Build()
+	ctx := callbacks.InitCallbacks(context.Background(), nil, handler)
+
+	docs := []*schema.Document{{ID: "a", Content: "alpha"}, {ID: "b", Content: "beta"}, {ID: "c", Content: "gamma"}}
+	res, err := r.ReRankDocuments(ctx, docs, "query")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Model != defaultModel || got.Query != "query" || got.TopN != 3 || strings.Join(got.Documents, ",") != "alpha,beta,gamma" {
+		t.Errorf("unexpected request %+v", got)
+	}
+	if len(res) != 2 || res[0].ID != "c" || res[1].ID != "a" {
+		t.Fatalf("unexpected result %v", res)
+	}
+	if res[0].Score() != 0.9 {
+		t.Errorf("score = %v, want 0.9", res[0].Score())
+	}
+	if input == nil || input.Query != "query" || len(input.Documents) != 3 {
+		t.Errorf("unexpected callback input %+v", input)
+	}
+	if output == nil || len(output.Documents) != 2 || output.SearchUnits != 1 {
+		t.Errorf("unexpected callback output %+v", output)
+	}
+}
+
+func TestReRankDocuments_Error(t *testing.T) {
+	r, err := NewReRanker(context.Background(), &ReRankerConfig{
+		APIKey:     "key",
+		HTTPClient: newClient(http.StatusUnauthorized, `{"message":"invalid api token"}`, nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cbErr error
+	handler := callbacks.NewHandlerBuilder().
+		OnErrorFn(func(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
+			cbErr = err
+			return ctx
+		}).
+		Build()
+	ctx := callbacks.InitCallbacks(context.Background(), nil, handler)
+
+	_, err = r.ReRankDocuments(ctx, []*schema.Document{{Content: "alpha"}}, "query")
+	if err == nil || !strings.Contains(err.Error(), "status 401: invalid api token") {
+		t.Errorf("err = %v", err)
+	}
+	if !errors.Is(cbErr, err) {
+		t.Errorf("callback error = %v", cbErr)
+	}
+}
+
+func TestNewReRanker(t *testing.T) {
+	if _, err := NewReRanker(context.Background(), &ReRankerConfig{}); err == nil {
+		t.Error("expected an error without api key")
+	}
+	r, err := NewReRanker(context.Background(), &ReRankerConfig{APIKey: "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.GetType() != "Cohere" || r.config.Model != defaultModel {
+		t.Errorf("unexpected reranker %+v", r.config)
+	}
+}
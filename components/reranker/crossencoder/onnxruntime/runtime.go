@@ -0,0 +1,178 @@
+package onnxruntime
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	ort "github.com/yalue/onnxruntime_go"
+
+	"github.com/cloudwego/eino-ext/components/reranker/crossencoder"
+)
+
+// Names of the model inputs filled from a crossencoder.Batch.
+const (
+	InputIDs      = "input_ids"
+	AttentionMask = "attention_mask"
+	TokenTypeIDs  = "token_type_ids"
+)
+
+const defaultOutputName = "logits"
+
+// envMu guards the initialization of the onnxruntime environment, which is
+// global to the process.
+var envMu sync.Mutex
+
+type Config struct {
+	// SharedLibraryPath is the path of the onnxruntime shared library, e.g.
+	// "/usr/lib/libonnxruntime.so". Required. It is ignored when the
+	// environment was already initialized, by this package or by the caller.
+	SharedLibraryPath string
+	// InputNames are the inputs fed to the model, among InputIDs, AttentionMask
+	// and TokenTypeIDs. Default: all three. Drop TokenTypeIDs for models that do
+	// not take it, such as XLM-RoBERTa based rerankers.
+	InputNames []string
+	// OutputName is the output holding one logit per pair. Default: "logits".
+	OutputName string
+	// IntraOpThreads is the number of threads used to run a model. Default: 0,
+	// which lets onnxruntime decide.
+	IntraOpThreads int
+}
+
+// Runtime runs cross-encoder ONNX models with onnxruntime. It implements
+// crossencoder.Runtime.
+type Runtime struct {
+	config *Config
+}
+
+// NewRuntime loads the onnxruntime shared library and initializes its
+// environment. The environment is shared by every Runtime and stays
+// initialized until the process exits.
+func NewRuntime(config *Config) (*Runtime, error) {
+	if config == nil {
+		return nil, errors.New("runtime config is required")
+	}
+	if config.SharedLibraryPath == "" {
+		return nil, errors.New("shared library path is required")
+	}
+
+	conf := *config
+	if len(conf.InputNames) == 0 {
+		conf.InputNames = []string{InputIDs, AttentionMask, TokenTypeIDs}
+	}
+	for _, name := range conf.InputNames {
+		if name != InputIDs && name != AttentionMask && name != TokenTypeIDs {
+			return nil, fmt.Errorf("unsupported input name %q", name)
+		}
+	}
+	if conf.OutputName == "" {
+		conf.OutputName = defaultOutputName
+	}
+
+	envMu.Lock()
+	defer envMu.Unlock()
+	if !ort.IsInitialized() {
+		ort.SetSharedLibraryPath(conf.SharedLibraryPath)
+		if err := ort.InitializeEnvironment(); err != nil {
+			return nil, fmt.Errorf("failed to initialize onnxruntime: %w", err)
+		}
+	}
+
+	return &Runtime{config: &conf}, nil
+}
+
+// Load opens an onnxruntime session for the model at modelPath.
+func (r *Runtime) Load(modelPath string) (crossencoder.Session, error) {
+	options, err := ort.NewSessionOptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session options: %w", err)
+	}
+	defer options.Destroy()
+	if r.config.IntraOpThreads > 0 {
+		if err := options.SetIntraOpNumThreads(r.config.IntraOpThreads); err != nil {
+			return nil, fmt.Errorf("failed to set intra-op threads: %w", err)
+		}
+	}
+
+	s, err := ort.NewDynamicAdvancedSession(modelPath, r.config.InputNames, []string{r.config.OutputName}, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return &session{session: s, inputNames: r.config.InputNames}, nil
+}
+
+type session struct {
+	session    *ort.DynamicAdvancedSession
+	inputNames []string
+}
+
+// Run feeds the batch to the model and returns its logits.
+func (s *session) Run(batch *crossencoder.Batch) ([]float32, error) {
+	rows := len(batch.InputIDs)
+	if rows == 0 {
+		return nil, nil
+	}
+	cols := len(batch.InputIDs[0])
+
+	inputs := make([]ort.Value, 0, len(s.inputNames))
+	defer func() {
+		for _, input := range inputs {
+			input.Destroy()
+		}
+	}()
+	for _, name := range s.inputNames {
+		data, err := flatten(inputOf(batch, name), cols)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		tensor, err := ort.NewTensor(ort.NewShape(int64(rows), int64(cols)), data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s tensor: %w", name, err)
+		}
+		inputs = append(inputs, tensor)
+	}
+
+	outputs := []ort.Value{nil}
+	if err := s.session.Run(inputs, outputs); err != nil {
+		return nil, err
+	}
+	defer outputs[0].Destroy()
+
+	tensor, ok := outputs[0].(*ort.Tensor[float32])
+	if !ok {
+		return nil, fmt.Errorf("unexpected output type %T, want a float32 tensor", outputs[0])
+	}
+	logits := tensor.GetData()
+	if len(logits) != rows {
+		return nil, fmt.Errorf("model output has %d values for %d pairs, want one logit per pair", len(logits), rows)
+	}
+	return append([]float32(nil), logits...), nil
+}
+
+// Close destroys the onnxruntime session.
+func (s *session) Close() error {
+	return s.session.Destroy()
+}
+
+func inputOf(batch *crossencoder.Batch, name string) [][]int64 {
+	switch name {
+	case AttentionMask:
+		return batch.AttentionMask
+	case TokenTypeIDs:
+		return batch.TokenTypeIDs
+	default:
+		return batch.InputIDs
+	}
+}
+
+// flatten lays out rows in row-major order, checking they all have cols columns.
+func flatten(rows [][]int64, cols int) ([]int64, error) {
+	data := make([]int64, 0, len(rows)*cols)
+	for i, row := range rows {
+		if len(row) != cols {
+			return nil, fmt.Errorf("row %d has %d columns, want %d", i, len(row), cols)
+		}
+		data = append(data, row...)
+	}
+	return data, nil
+}
@@ -0,0 +1,71 @@
+package onnxruntime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino-ext/components/reranker/crossencoder"
+)
+
+func TestNewRuntime_Errors(t *testing.T) {
+	if _, err := NewRuntime(&Config{}); err == nil || !strings.Contains(err.Error(), "shared library path is required") {
+		t.Errorf("err = %v, want a missing shared library error", err)
+	}
+
+	_, err := NewRuntime(&Config{SharedLibraryPath: "libonnxruntime.so", InputNames: []string{InputIDs, "pixel_values"}})
+	if err == nil || !strings.Contains(err.Error(), `unsupported input name "pixel_values"`) {
+		t.Errorf("err = %v, want an unsupported input error", err)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	data, err := flatten([][]int64{{101, 7, 102}, {101, 102, 0}}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[101 7 102 101 102 0]"; fmt.Sprint(data) != want {
+		t.Errorf("data = %v, want %s", data, want)
+	}
+
+	if _, err := flatten([][]int64{{101, 102}, {101}}, 2); err == nil {
+		t.Error("expected an error for a ragged batch")
+	}
+}
+
+// TestSession_Run runs testdata/tiny_cross_encoder.onnx, whose logit for each pair is
+// sum(input_ids*attention_mask + token_type_ids) over the sequence. It needs the onnxruntime
+// shared library, found through ONNXRUNTIME_SHARED_LIBRARY_PATH, and is skipped without it.
+func TestSession_Run(t *testing.T) {
+	libPath := os.Getenv("ONNXRUNTIME_SHARED_LIBRARY_PATH")
+	if libPath == "" {
+		t.Skip("ONNXRUNTIME_SHARED_LIBRARY_PATH is not set")
+	}
+	if _, err := os.Stat(libPath); err != nil {
+		t.Skipf("onnxruntime shared library not found: %v", err)
+	}
+
+	r, err := NewRuntime(&Config{SharedLibraryPath: libPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := r.Load(filepath.Join("testdata", "tiny_cross_encoder.onnx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	logits, err := s.Run(&crossencoder.Batch{
+		InputIDs:      [][]int64{{101, 1, 102}, {101, 2, 102}},
+		AttentionMask: [][]int64{{1, 1, 1}, {1, 1, 0}},
+		TokenTypeIDs:  [][]int64{{0, 0, 0}, {0, 1, 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[204 105]"; fmt.Sprint(logits) != want {
+		t.Errorf("logits = %v, want %s", logits, want)
+	}
+}
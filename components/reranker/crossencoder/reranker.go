@@ -0,0 +1,218 @@
+package crossencoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultBatchSize = 16
+	defaultMaxLength = 512
+
+	typ = "CrossEncoder"
+
+	// ComponentOfReRanker is the component kind reported to callbacks.
+	ComponentOfReRanker components.Component = "ReRanker"
+)
+
+type ReRankerConfig struct {
+	// ModelPath is the path of the cross-encoder model, e.g. an ONNX file,
+	// passed to Runtime.Load. Required.
+	ModelPath string
+	// Runtime loads and runs the model. Required, NewReRanker fails with
+	// ErrRuntimeUnavailable without it.
+	Runtime Runtime
+	// Tokenizer encodes the (query, document) pairs. When nil, a WordPiece
+	// tokenizer is loaded from VocabPath.
+	Tokenizer Tokenizer
+	// VocabPath is the path of the vocab.txt file of the model, used when
+	// Tokenizer is nil.
+	VocabPath string
+	// BatchSize is the number of pairs scored per model run. Default: 16.
+	BatchSize int
+	// MaxLength caps the number of tokens of a pair. Default: 512.
+	MaxLength int
+	// Sigmoid maps the logits of the model to scores between 0 and 1.
+	Sigmoid bool
+	// TopN keeps only the TopN best documents. Default: 0, which keeps all of them.
+	TopN int
+}
+
+// CallbackInput is passed to the OnStart callbacks of ReRankDocuments.
+type CallbackInput struct {
+	Query     string
+	Documents []*schema.Document
+	ModelPath string
+	TopN      int
+}
+
+// CallbackOutput is passed to the OnEnd callbacks of ReRankDocuments.
+type CallbackOutput struct {
+	Documents []*schema.Document
+}
+
+// ReRanker scores (query, document) pairs locally with a cross-encoder model,
+// without calling a remote API. It tokenizes the pairs itself and delegates
+// running the model to the configured Runtime.
+type ReRanker struct {
+	config    *ReRankerConfig
+	session   Session
+	tokenizer Tokenizer
+}
+
+func NewReRanker(ctx context.Context, config *ReRankerConfig) (*ReRanker, error) {
+	if config == nil {
+		return nil, errors.New("reranker config is required")
+	}
+	if config.Runtime == nil {
+		return nil, ErrRuntimeUnavailable
+	}
+	if config.ModelPath == "" {
+		return nil, errors.New("model path is required")
+	}
+	if _, err := os.Stat(config.ModelPath); err != nil {
+		return nil, fmt.Errorf("failed to find model: %w", err)
+	}
+
+	tokenizer := config.Tokenizer
+	if tokenizer == nil {
+		if config.VocabPath == "" {
+			return nil, errors.New("either tokenizer or vocab path is required")
+		}
+		wp, err := NewWordPiece(config.VocabPath)
+		if err != nil {
+			return nil, err
+		}
+		tokenizer = wp
+	}
+
+	session, err := config.Runtime.Load(config.ModelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load model %s: %w", config.ModelPath, err)
+	}
+
+	conf := *config
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultBatchSize
+	}
+	if conf.MaxLength <= 0 {
+		conf.MaxLength = defaultMaxLength
+	}
+
+	return &ReRanker{config: &conf, session: session, tokenizer: tokenizer}, nil
+}
+
+// ReRankDocuments scores every document against query, sets its score and
+// returns the documents sorted by descending score.
+func (r *ReRanker) ReRankDocuments(ctx context.Context, src []*schema.Document, query string) (dst []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), ComponentOfReRanker)
+	ctx = callbacks.OnStart(ctx, &CallbackInput{
+		Query:     query,
+		Documents: src,
+		ModelPath: r.config.ModelPath,
+		TopN:      r.config.TopN,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	scores := make([]float64, 0, len(src))
+	for start := 0; start < len(src); start += r.config.BatchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		docs := src[start:min(start+r.config.BatchSize, len(src))]
+		batch, err := r.encode(query, docs)
+		if err != nil {
+			return nil, err
+		}
+		logits, err := r.session.Run(batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to run model: %w", err)
+		}
+		if len(logits) != len(docs) {
+			return nil, fmt.Errorf("model returned %d scores for %d documents", len(logits), len(docs))
+		}
+		for _, logit := range logits {
+			score := float64(logit)
+			if r.config.Sigmoid {
+				score = 1 / (1 + math.Exp(-score))
+			}
+			scores = append(scores, score)
+		}
+	}
+
+	order := make([]int, len(src))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+	if r.config.TopN > 0 && r.config.TopN < len(order) {
+		order = order[:r.config.TopN]
+	}
+
+	dst = make([]*schema.Document, 0, len(order))
+	for _, i := range order {
+		dst = append(dst, src[i].WithScore(scores[i]))
+	}
+
+	callbacks.OnEnd(ctx, &CallbackOutput{Documents: dst})
+
+	return dst, nil
+}
+
+// Close releases the model session.
+func (r *ReRanker) Close() error {
+	return r.session.Close()
+}
+
+func (r *ReRanker) GetType() string {
+	return typ
+}
+
+func (r *ReRanker) IsCallbacksEnabled() bool {
+	return true
+}
+
+// encode tokenizes the pairs of docs and pads them to the longest one.
+func (r *ReRanker) encode(query string, docs []*schema.Document) (*Batch, error) {
+	batch := &Batch{
+		InputIDs:      make([][]int64, 0, len(docs)),
+		AttentionMask: make([][]int64, 0, len(docs)),
+		TokenTypeIDs:  make([][]int64, 0, len(docs)),
+	}
+	maxLen := 0
+	for _, doc := range docs {
+		ids, typeIDs, err := r.tokenizer.EncodePair(query, doc.Content, r.config.MaxLength)
+		if err != nil {
+			return nil, fmt.Errorf("failed to tokenize document: %w", err)
+		}
+		batch.InputIDs = append(batch.InputIDs, ids)
+		batch.TokenTypeIDs = append(batch.TokenTypeIDs, typeIDs)
+		maxLen = max(maxLen, len(ids))
+	}
+	for i := range batch.InputIDs {
+		n := len(batch.InputIDs[i])
+		mask := make([]int64, maxLen)
+		for j := 0; j < n; j++ {
+			mask[j] = 1
+		}
+		batch.AttentionMask = append(batch.AttentionMask, mask)
+		batch.InputIDs[i] = append(batch.InputIDs[i], make([]int64, maxLen-n)...)
+		batch.TokenTypeIDs[i] = append(batch.TokenTypeIDs[i], make([]int64, maxLen-n)...)
+	}
+	return batch, nil
+}
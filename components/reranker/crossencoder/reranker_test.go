@@ -0,0 +1,226 @@
+package crossencoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/schema"
+)
+
+// stubRuntime loads a stub model that scores a pair by the number of document
+// tokens that also appear in the query.
+type stubRuntime struct {
+	batchSizes []int
+}
+
+func (s *stubRuntime) Load(modelPath string) (Session, error) {
+	data, err := os.ReadFile(modelPath)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) != "stub-model" {
+		return nil, errors.New("unknown model")
+	}
+	return s, nil
+}
+
+func (s *stubRuntime) Run(batch *Batch) ([]float32, error) {
+	s.batchSizes = append(s.batchSizes, len(batch.InputIDs))
+	scores := make([]float32, 0, len(batch.InputIDs))
+	for i, ids := range batch.InputIDs {
+		if len(ids) != len(batch.AttentionMask[i]) || len(ids) != len(batch.TokenTypeIDs[i]) {
+			return nil, errors.New("inputs are not padded")
+		}
+		query := map[int64]bool{}
+		var score float32
+		for j, id := range ids {
+			if batch.AttentionMask[i][j] == 0 || id == 101 || id == 102 {
+				continue
+			}
+			if batch.TokenTypeIDs[i][j] == 0 {
+				query[id] = true
+			} else if query[id] {
+				score++
+			}
+		}
+		scores = append(scores, score)
+	}
+	return scores, nil
+}
+
+func (s *stubRuntime) Close() error { return nil }
+
+func writeModel(t *testing.T) (modelPath, vocabPath string) {
+	dir := t.TempDir()
+	modelPath = filepath.Join(dir, "model.onnx")
+	vocabPath = filepath.Join(dir, "vocab.txt")
+	if err := os.WriteFile(modelPath, []byte("stub-model"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	vocab := make([]string, 0, 110)
+	for i := 0; i < 100; i++ {
+		vocab = append(vocab, fmt.Sprintf("[unused%d]", i))
+	}
+	vocab[0] = "[PAD]"
+	vocab = append(vocab, "[UNK]", "[CLS]", "[SEP]", "red", "green", "apple", "pear", "##s", "a", "is")
+	if err := os.WriteFile(vocabPath, []byte(strings.Join(vocab, "\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return modelPath, vocabPath
+}
+
+func TestReRankDocuments(t *testing.T) {
+	modelPath, vocabPath := writeModel(t)
+	runtime := &stubRuntime{}
+	r, err := NewReRanker(context.Background(), &ReRankerConfig{
+		ModelPath: modelPath,
+		VocabPath: vocabPath,
+		Runtime:   runtime,
+		BatchSize: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	docs := []*schema.Document{
+		{ID: "1", Content: "a pear"},
+		{ID: "2", Content: "red apples, green apples"},
+		{ID: "3", Content: "green pears"},
+		{ID: "4", Content: "nothing relevant"},
+		{ID: "5", Content: "a red apple"},
+	}
+	res, err := r.ReRankDocuments(context.Background(), docs, "red apple")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, doc := range res {
+		got = append(got, fmt.Sprintf("%s:%v", doc.ID, doc.Score()))
+	}
+	if want := "[2:3 5:2 1:0 3:0 4:0]"; fmt.Sprint(got) != want {
+		t.Errorf("ranking = %v, want %s", got, want)
+	}
+	if want := "[2 2 1]"; fmt.Sprint(runtime.batchSizes) != want {
+		t.Errorf("batch sizes = %v, want %s", runtime.batchSizes, want)
+	}
+}
+
+func TestReRankDocuments_TopNSigmoid(t *testing.T) {
+	modelPath, vocabPath := writeModel(t)
+	r, err := NewReRanker(context.Background(), &ReRankerConfig{
+		ModelPath: modelPath,
+		VocabPath: vocabPath,
+		Runtime:   &stubRuntime{},
+		TopN:      1,
+		Sigmoid:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := r.ReRankDocuments(context.Background(), []*schema.Document{{Content: "pear"}, {Content: "apple"}}, "apple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || res[0].Content != "apple" {
+		t.Fatalf("unexpected result %v", res)
+	}
+	if score := res[0].Score(); score < 0.73 || score > 0.74 {
+		t.Errorf("score = %v, want sigmoid(1)", score)
+	}
+}
+
+func TestReRankDocuments_Callbacks(t *testing.T) {
+	modelPath, vocabPath := writeModel(t)
+	r, err := NewReRanker(context.Background(), &ReRankerConfig{
+		ModelPath: modelPath,
+		VocabPath: vocabPath,
+		Runtime:   &stubRuntime{},
+		TopN:      1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var input *CallbackInput
+	var output *CallbackOutput
+	handler := callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, in callbacks.CallbackInput) context.Context {
+			input = in.(*CallbackInput)
+			if info.Type != "CrossEncoder" || info.Component != ComponentOfReRanker {
+				t.Errorf("unexpected run info %+v", info)
+			}
+			return ctx
+		}).
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+			output = out.(*CallbackOutput)
+			return ctx
+		}).
+		Build()
+	ctx := callbacks.InitCallbacks(context.Background(), nil, handler)
+
+	_, err = r.ReRankDocuments(ctx, []*schema.Document{{Content: "pear"}, {Content: "apple"}}, "apple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input == nil || input.Query != "apple" || len(input.Documents) != 2 || input.ModelPath != modelPath || input.TopN != 1 {
+		t.Errorf("unexpected callback input %+v", input)
+	}
+	if output == nil || len(output.Documents) != 1 || output.Documents[0].Content != "apple" {
+		t.Errorf("unexpected callback output %+v", output)
+	}
+}
+
+func TestNewReRanker_Errors(t *testing.T) {
+	modelPath, vocabPath := writeModel(t)
+
+	_, err := NewReRanker(context.Background(), &ReRankerConfig{ModelPath: modelPath, VocabPath: vocabPath})
+	if !errors.Is(err, ErrRuntimeUnavailable) {
+		t.Errorf("err = %v, want ErrRuntimeUnavailable", err)
+	}
+
+	_, err = NewReRanker(context.Background(), &ReRankerConfig{ModelPath: modelPath + ".missing", VocabPath: vocabPath, Runtime: &stubRuntime{}})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("err = %v, want a missing model error", err)
+	}
+
+	_, err = NewReRanker(context.Background(), &ReRankerConfig{ModelPath: vocabPath, VocabPath: vocabPath, Runtime: &stubRuntime{}})
+	if err == nil || !strings.Contains(err.Error(), "failed to load model") {
+		t.Errorf("err = %v, want a load error", err)
+	}
+}
+
+func TestWordPiece_EncodePair(t *testing.T) {
+	_, vocabPath := writeModel(t)
+	wp, err := NewWordPiece(vocabPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids, typeIDs, err := wp.EncodePair("Red apples?", "a kiwi", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[101 103 105 107 100 102 108 100 102]"; fmt.Sprint(ids) != want {
+		t.Errorf("ids = %v, want %s", ids, want)
+	}
+	if want := "[0 0 0 0 0 0 1 1 1]"; fmt.Sprint(typeIDs) != want {
+		t.Errorf("type ids = %v, want %s", typeIDs, want)
+	}
+
+	ids, _, err = wp.EncodePair("red apple", "a red green pear", 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[101 103 105 102 108 102]"; fmt.Sprint(ids) != want {
+		t.Errorf("truncated ids = %v, want %s", ids, want)
+	}
+}
@@ -0,0 +1,33 @@
+package crossencoder
+
+import (
+	"errors"
+)
+
+// ErrRuntimeUnavailable is returned by NewReRanker when no Runtime is configured
+// to run the model.
+var ErrRuntimeUnavailable = errors.New("model runtime is unavailable: set ReRankerConfig.Runtime")
+
+// Batch holds the padded inputs of a batch of (query, document) pairs, in the
+// layout expected by BERT-style cross-encoders. Every row has the same length.
+type Batch struct {
+	InputIDs      [][]int64
+	AttentionMask [][]int64
+	TokenTypeIDs  [][]int64
+}
+
+// Session runs a loaded cross-encoder model.
+type Session interface {
+	// Run returns the relevance logit of every pair of the batch, in order.
+	Run(batch *Batch) ([]float32, error)
+	// Close releases the resources held by the session.
+	Close() error
+}
+
+// Runtime loads cross-encoder models. This package only tokenizes the pairs and
+// ranks the documents by the scores the Runtime returns; the onnxruntime
+// sub-package implements it for ONNX models, in its own module so that this one
+// does not link against the native library.
+type Runtime interface {
+	Load(modelPath string) (Session, error)
+}
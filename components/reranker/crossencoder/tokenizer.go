@@ -0,0 +1,150 @@
+package crossencoder
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Tokenizer encodes a (query, document) pair into model inputs.
+type Tokenizer interface {
+	// EncodePair returns the token ids and token type ids of the pair, truncated
+	// to at most maxLength tokens.
+	EncodePair(query, document string, maxLength int) (ids, typeIDs []int64, err error)
+}
+
+// WordPiece is the uncased BERT WordPiece tokenizer used by most cross-encoders,
+// such as the ms-marco MiniLM models.
+type WordPiece struct {
+	vocab map[string]int64
+	cls   int64
+	sep   int64
+	unk   int64
+}
+
+// NewWordPiece loads a WordPiece tokenizer from a vocab.txt file holding one
+// token per line, the line number being the token id.
+func NewWordPiece(vocabPath string) (*WordPiece, error) {
+	f, err := os.Open(vocabPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open vocab: %w", err)
+	}
+	defer f.Close()
+
+	vocab := make(map[string]int64)
+	scanner := bufio.NewScanner(f)
+	for id := int64(0); scanner.Scan(); id++ {
+		vocab[strings.TrimRight(scanner.Text(), "\r")] = id
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read vocab: %w", err)
+	}
+
+	return newWordPiece(vocab)
+}
+
+func newWordPiece(vocab map[string]int64) (*WordPiece, error) {
+	w := &WordPiece{vocab: vocab}
+	for token, id := range map[string]*int64{"[CLS]": &w.cls, "[SEP]": &w.sep, "[UNK]": &w.unk} {
+		v, ok := vocab[token]
+		if !ok {
+			return nil, fmt.Errorf("vocab is missing the %s token", token)
+		}
+		*id = v
+	}
+	return w, nil
+}
+
+// EncodePair encodes the pair as "[CLS] query [SEP] document [SEP]", dropping
+// tokens from the end of the longer text until it fits in maxLength.
+func (w *WordPiece) EncodePair(query, document string, maxLength int) (ids, typeIDs []int64, err error) {
+	q, d := w.tokenize(query), w.tokenize(document)
+	if maxLength > 0 {
+		budget := maxLength - 3
+		if budget < 0 {
+			return nil, nil, fmt.Errorf("max length %d is too small for a pair", maxLength)
+		}
+		for len(q)+len(d) > budget {
+			if len(q) > len(d) {
+				q = q[:len(q)-1]
+			} else {
+				d = d[:len(d)-1]
+			}
+		}
+	}
+
+	ids = make([]int64, 0, len(q)+len(d)+3)
+	ids = append(ids, w.cls)
+	ids = append(ids, q...)
+	ids = append(ids, w.sep)
+	typeIDs = make([]int64, len(ids), cap(ids))
+	ids = append(ids, d...)
+	ids = append(ids, w.sep)
+	for len(typeIDs) < len(ids) {
+		typeIDs = append(typeIDs, 1)
+	}
+	return ids, typeIDs, nil
+}
+
+func (w *WordPiece) tokenize(text string) []int64 {
+	var ids []int64
+	for _, word := range splitWords(strings.ToLower(text)) {
+		ids = append(ids, w.wordPieces(word)...)
+	}
+	return ids
+}
+
+// wordPieces splits word greedily into the longest tokens of the vocab, the
+// tokens after the first one being prefixed with "##".
+func (w *WordPiece) wordPieces(word string) []int64 {
+	runes := []rune(word)
+	var ids []int64
+	for start := 0; start < len(runes); {
+		end := len(runes)
+		var id int64
+		found := false
+		for ; end > start; end-- {
+			piece := string(runes[start:end])
+			if start > 0 {
+				piece = "##" + piece
+			}
+			if id, found = w.vocab[piece]; found {
+				break
+			}
+		}
+		if !found {
+			return []int64{w.unk}
+		}
+		ids = append(ids, id)
+		start = end
+	}
+	return ids
+}
+
+// splitWords splits text on whitespace and makes every punctuation character a
+// word of its own, as the BERT basic tokenizer does.
+func splitWords(text string) []string {
+	var words []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, current.String())
+			current.Reset()
+		}
+	}
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			flush()
+			words = append(words, string(r))
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return words
+}
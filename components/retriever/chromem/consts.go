@@ -5,3 +5,8 @@ const (
 	defaultCollection = "default"
 	defaultTopK       = 5
 )
+
+// MetaDataJSONKey is the reserved chromem metadata key in which the chromem indexer stores the
+// JSON encoded document metadata (IndexerConfig.MetadataJSON). When present, it is decoded into
+// the document metadata instead of the flat string values.
+const MetaDataJSONKey = "_meta_json"
@@ -0,0 +1,17 @@
+package chromem
+
+import "github.com/cloudwego/eino/components/retriever"
+
+// ImplOptions contains chromem-specific options.
+type ImplOptions struct {
+	// ReturnVectors populates the dense vector of the returned documents with the stored embedding.
+	ReturnVectors bool
+}
+
+// WithReturnVectors sets whether the stored embeddings are returned as the documents' dense vectors.
+// Default: false, to keep the result small.
+func WithReturnVectors(returnVectors bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.ReturnVectors = returnVectors
+	})
+}
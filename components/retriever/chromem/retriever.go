@@ -2,9 +2,9 @@ package chromem
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
-	"sync"
 
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
@@ -24,15 +24,19 @@ type RetrieverConfig struct {
 	TopK           int     `json:"top_k,omitempty"`
 	ScoreThreshold float64 `json:"score_threshold,omitempty"`
 
+	// Embedding embeds the query. chromem L2-normalizes the query embedding like the stored ones,
+	// so embedders that do not return unit vectors (e.g. Ollama) need no extra step.
 	Embedding embedding.Embedder
 	ReRanker  reranker.ReRanker
+
+	// ScoreTransform maps chromem's cosine similarity to the document score, e.g. to align
+	// score ranges across stores before a shared reranker.
+	// Optional. Default: identity
+	ScoreTransform func(similarity float64) float64
 }
 
 type Retriever struct {
-	config      *RetrieverConfig
-	collections map[string]*chromem.Collection
-	mu          sync.RWMutex
-	collection  *chromem.Collection
+	config *RetrieverConfig
 }
 
 func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, error) {
@@ -56,36 +60,19 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 	}
 
 	r := &Retriever{
-		config:      config,
-		collections: make(map[string]*chromem.Collection),
-		mu:          sync.RWMutex{},
+		config: config,
 	}
 
-	collection, err := r.getCollection(r.config.Collection)
-
-	if err != nil {
+	if _, err = r.getCollection(r.config.Collection); err != nil {
 		return nil, fmt.Errorf("failed to get collection: %w", err)
 	}
-	r.collection = collection
 	return r, nil
 }
 
-// 获取已存在的集合
+// getCollection looks the collection up in the database on every call instead of caching it,
+// so that a collection recreated by the indexer's Reset is queried rather than the deleted one.
 func (r *Retriever) getCollection(name string) (*chromem.Collection, error) {
-	r.mu.RLock()
-	collection, exists := r.collections[name]
-	r.mu.RUnlock()
-
-	if exists {
-		return collection, nil
-	}
-
-	// 尝试从数据库加载
-	collections := r.config.Client.ListCollections()
-	if coll, exists := collections[name]; exists {
-		r.mu.Lock()
-		r.collections[name] = coll
-		r.mu.Unlock()
+	if coll, exists := r.config.Client.ListCollections()[name]; exists {
 		return coll, nil
 	}
 
@@ -104,6 +91,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		ScoreThreshold: &r.config.ScoreThreshold,
 		Embedding:      r.config.Embedding,
 	}, opts...)
+	io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
 
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
 		Query:          query,
@@ -121,14 +109,19 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		queryEmbedding[k] = float32(v)
 	}
 
-	result, err := r.collection.QueryEmbedding(ctx, queryEmbedding, int(math.Min(float64(r.collection.Count()), float64(*options.TopK))), nil, nil)
+	collection, err := r.getCollection(r.config.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := collection.QueryEmbedding(ctx, queryEmbedding, int(math.Min(float64(collection.Count()), float64(*options.TopK))), nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	docs = make([]*schema.Document, 0)
 	for _, data := range result {
-		doc, err := r.data2Document(data)
+		doc, err := r.data2Document(data, io.ReturnVectors)
 		if err != nil {
 			return nil, err
 		}
@@ -193,20 +186,51 @@ func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder
 	return callbacks.ReuseHandlers(ctx, runInfo)
 }
 
-func (r *Retriever) data2Document(data chromem.Result) (*schema.Document, error) {
+func (r *Retriever) data2Document(data chromem.Result, returnVectors bool) (*schema.Document, error) {
 	doc := &schema.Document{
 		ID:       data.ID,
 		Content:  data.Content,
 		MetaData: map[string]any{},
 	}
 
-	doc.WithScore(float64(data.Similarity))
-	for k, v := range data.Metadata {
-		doc.MetaData[k] = v
+	score := float64(data.Similarity)
+	if r.config.ScoreTransform != nil {
+		score = r.config.ScoreTransform(score)
+	}
+	doc.WithScore(score)
+	if raw, ok := data.Metadata[MetaDataJSONKey]; ok {
+		metadata := map[string]any{}
+		if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+			return nil, fmt.Errorf("failed to decode metadata of document %s: %w", data.ID, err)
+		}
+		for k, v := range metadata {
+			doc.MetaData[k] = v
+		}
+	} else {
+		for k, v := range data.Metadata {
+			doc.MetaData[k] = v
+		}
+	}
+
+	if returnVectors && len(data.Embedding) > 0 {
+		vector := make([]float64, len(data.Embedding))
+		for k, v := range data.Embedding {
+			vector[k] = float64(v)
+		}
+		doc.WithDenseVector(vector)
 	}
 	return doc, nil
 }
 
+// Count returns the number of documents in the retriever's collection, 0 when it does not exist.
+func (r *Retriever) Count() int {
+	collection, err := r.getCollection(r.config.Collection)
+	if err != nil {
+		return 0
+	}
+	return collection.Count()
+}
+
 func (r *Retriever) GetType() string {
 	return typ
 }
@@ -0,0 +1,240 @@
+package chromem
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/philippgille/chromem-go"
+)
+
+type mockEmbedding struct {
+	vector []float64
+}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	resp := make([][]float64, len(texts))
+	for i := range texts {
+		resp[i] = append([]float64(nil), m.vector...)
+	}
+	return resp, nil
+}
+
+// TestRetrieverUnitQuery checks that chromem normalizes the query embedding.
+func TestRetrieverUnitQuery(t *testing.T) {
+	ctx := context.Background()
+	db := chromem.NewDB()
+	coll, err := db.GetOrCreateCollection(defaultCollection, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = coll.AddDocument(ctx, chromem.Document{ID: "1", Content: "hello", Embedding: []float32{0.6, 0.8}}); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Client:    db,
+		Embedding: &mockEmbedding{vector: []float64{30, 40}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := r.Retrieve(ctx, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || math.Abs(docs[0].Score()-1) > 1e-6 {
+		t.Fatalf("unexpected docs: %v", docs)
+	}
+}
+
+func TestRetrieverReturnVectors(t *testing.T) {
+	ctx := context.Background()
+	db := chromem.NewDB()
+	coll, err := db.GetOrCreateCollection(defaultCollection, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = coll.AddDocument(ctx, chromem.Document{ID: "1", Content: "hello", Embedding: []float32{0.6, 0.8}}); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Client:    db,
+		Embedding: &mockEmbedding{vector: []float64{0.6, 0.8}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := r.Retrieve(ctx, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].DenseVector() != nil {
+		t.Fatalf("vectors should not be returned by default: %v", docs)
+	}
+
+	docs, err = r.Retrieve(ctx, "hello", WithReturnVectors(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("unexpected docs: %v", docs)
+	}
+	vector := docs[0].DenseVector()
+	if len(vector) != 2 || math.Abs(vector[0]-0.6) > 1e-6 || math.Abs(vector[1]-0.8) > 1e-6 {
+		t.Fatalf("unexpected vector: %v", vector)
+	}
+}
+
+func TestRetrieverCount(t *testing.T) {
+	ctx := context.Background()
+	db := chromem.NewDB()
+	coll, err := db.GetOrCreateCollection(defaultCollection, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		if err = coll.AddDocument(ctx, chromem.Document{ID: id, Content: "hello", Embedding: []float32{1, 0}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Client:    db,
+		Embedding: &mockEmbedding{vector: []float64{1, 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Count() != 3 {
+		t.Fatalf("unexpected count, got=%d, expected=3", r.Count())
+	}
+}
+
+func TestRetrieverRecreatedCollection(t *testing.T) {
+	ctx := context.Background()
+	db := chromem.NewDB()
+	coll, err := db.GetOrCreateCollection(defaultCollection, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = coll.AddDocument(ctx, chromem.Document{ID: "old", Content: "old", Embedding: []float32{1, 0}}); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Client:    db,
+		Embedding: &mockEmbedding{vector: []float64{1, 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// what the indexer's Reset does: the retriever must query the new collection, not the deleted one
+	if err = db.DeleteCollection(defaultCollection); err != nil {
+		t.Fatal(err)
+	}
+	coll, err = db.GetOrCreateCollection(defaultCollection, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = coll.AddDocument(ctx, chromem.Document{ID: "new", Content: "new", Embedding: []float32{1, 0}}); err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := r.Retrieve(ctx, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || docs[0].ID != "new" {
+		t.Fatalf("unexpected docs: %v", docs)
+	}
+	if r.Count() != 1 {
+		t.Fatalf("unexpected count, got=%d, expected=1", r.Count())
+	}
+}
+
+func TestRetrieverMetadataJSON(t *testing.T) {
+	ctx := context.Background()
+	db := chromem.NewDB()
+	coll, err := db.GetOrCreateCollection(defaultCollection, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = coll.AddDocument(ctx, chromem.Document{
+		ID:        "1",
+		Content:   "hello",
+		Embedding: []float32{1, 0},
+		Metadata: map[string]string{
+			"source":        "a.txt",
+			"tags":          `{"labels":["x","y"],"lang":"en"}`,
+			MetaDataJSONKey: `{"source":"a.txt","page":3,"tags":{"lang":"en","labels":["x","y"]}}`,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Client:    db,
+		Embedding: &mockEmbedding{vector: []float64{1, 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := r.Retrieve(ctx, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("unexpected docs: %v", docs)
+	}
+
+	tags, ok := docs[0].MetaData["tags"].(map[string]any)
+	if !ok || tags["lang"] != "en" || !reflect.DeepEqual(tags["labels"], []any{"x", "y"}) {
+		t.Fatalf("nested metadata not restored: %v", docs[0].MetaData)
+	}
+	if docs[0].MetaData["page"] != float64(3) || docs[0].MetaData["source"] != "a.txt" {
+		t.Fatalf("unexpected metadata: %v", docs[0].MetaData)
+	}
+	if _, ok = docs[0].MetaData[MetaDataJSONKey]; ok {
+		t.Fatalf("reserved key should not be exposed: %v", docs[0].MetaData)
+	}
+}
+
+func TestRetrieverScoreTransform(t *testing.T) {
+	ctx := context.Background()
+	db := chromem.NewDB()
+	coll, err := db.GetOrCreateCollection(defaultCollection, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = coll.AddDocument(ctx, chromem.Document{ID: "1", Content: "hello", Embedding: []float32{1, 0}}); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Client:    db,
+		Embedding: &mockEmbedding{vector: []float64{1, 0}},
+		// map cosine similarity from [-1, 1] to [0, 100]
+		ScoreTransform: func(similarity float64) float64 { return (similarity + 1) * 50 },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := r.Retrieve(ctx, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 1 || math.Abs(docs[0].Score()-100) > 1e-4 {
+		t.Fatalf("score transform not applied: %v", docs)
+	}
+}
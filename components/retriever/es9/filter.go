@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es9
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
+)
+
+// MetadataFilter matches the documents whose metadata field equals Value, is one of
+// Values, or lies between Gte and Lte. Exactly one of these conditions must be set.
+// Values and bounds must be strings, numbers or booleans.
+type MetadataFilter struct {
+	Field  string `json:"field"`
+	Value  any    `json:"value,omitempty"`
+	Values []any  `json:"values,omitempty"`
+	Gte    any    `json:"gte,omitempty"`
+	Lte    any    `json:"lte,omitempty"`
+}
+
+// ResolveFilters returns the filter clauses of a retrieve query: the ones set with
+// WithFilters followed by the ones built from WithMetadataFilters, which fail
+// validation when they use a field missing from conf.FilterFields or a malformed condition.
+func ResolveFilters(conf *RetrieverConfig, opts *ImplOptions) ([]types.Query, error) {
+	if opts == nil {
+		return nil, nil
+	}
+	if len(opts.MetadataFilters) == 0 {
+		return opts.Filters, nil
+	}
+
+	filters := make([]types.Query, 0, len(opts.Filters)+len(opts.MetadataFilters))
+	filters = append(filters, opts.Filters...)
+	for _, f := range opts.MetadataFilters {
+		q, err := f.toQuery(conf.FilterFields)
+		if err != nil {
+			return nil, fmt.Errorf("[ResolveFilters] invalid metadata filter on %q: %w", f.Field, err)
+		}
+		filters = append(filters, q)
+	}
+
+	return filters, nil
+}
+
+func (f MetadataFilter) toQuery(allowed []string) (types.Query, error) {
+	if f.Field == "" || !slices.Contains(allowed, f.Field) {
+		return types.Query{}, fmt.Errorf("field is not allowed")
+	}
+
+	conditions := 0
+	if f.Value != nil {
+		conditions++
+	}
+	if len(f.Values) > 0 {
+		conditions++
+	}
+	if f.Gte != nil || f.Lte != nil {
+		conditions++
+	}
+	if conditions != 1 {
+		return types.Query{}, fmt.Errorf("exactly one of value, values or range must be set")
+	}
+
+	for _, v := range append([]any{f.Value, f.Gte, f.Lte}, f.Values...) {
+		if !isScalar(v) {
+			return types.Query{}, fmt.Errorf("unsupported value type %T", v)
+		}
+	}
+
+	switch {
+	case f.Value != nil:
+		return types.Query{Term: map[string]types.TermQuery{f.Field: {Value: f.Value}}}, nil
+	case len(f.Values) > 0:
+		values := make([]types.FieldValue, 0, len(f.Values))
+		for _, v := range f.Values {
+			values = append(values, v)
+		}
+		return types.Query{Terms: &types.TermsQuery{TermsQuery: map[string]types.TermsQueryField{f.Field: values}}}, nil
+	default:
+		r := types.UntypedRangeQuery{}
+		var err error
+		if f.Gte != nil {
+			if r.Gte, err = json.Marshal(f.Gte); err != nil {
+				return types.Query{}, err
+			}
+		}
+		if f.Lte != nil {
+			if r.Lte, err = json.Marshal(f.Lte); err != nil {
+				return types.Query{}, err
+			}
+		}
+		return types.Query{Range: map[string]types.RangeQuery{f.Field: r}}, nil
+	}
+}
+
+func isScalar(v any) bool {
+	switch v.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	default:
+		return false
+	}
+}
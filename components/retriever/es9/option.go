@@ -26,6 +26,24 @@ import (
 type ImplOptions struct {
 	Filters      []types.Query      `json:"filters,omitempty"`
 	SparseVector map[string]float32 `json:"sparse_vector,omitempty"`
+	Hybrid       *HybridOptions     `json:"hybrid,omitempty"`
+	// MetadataFilters are validated against RetrieverConfig.FilterFields and added to Filters
+	// by ResolveFilters.
+	MetadataFilters []MetadataFilter `json:"metadata_filters,omitempty"`
+	// ReturnVectors returns the RetrieverConfig.VectorField of the hits as the documents' dense vectors.
+	ReturnVectors bool `json:"return_vectors,omitempty"`
+}
+
+// HybridOptions overrides the hybrid search settings of a search mode for a single call.
+type HybridOptions struct {
+	// Enabled combines a BM25 query on the text field with the kNN search on the vector field.
+	Enabled bool `json:"enabled"`
+	// RRF combines the two result sets with Reciprocal Rank Fusion instead of summing their weighted scores.
+	RRF bool `json:"rrf"`
+	// TextWeight weights the BM25 score when RRF is off. Nil keeps the configured weight.
+	TextWeight *float32 `json:"text_weight,omitempty"`
+	// VectorWeight weights the kNN score when RRF is off. Nil keeps the configured weight.
+	VectorWeight *float32 `json:"vector_weight,omitempty"`
 }
 
 // WithFilters sets filters for the retrieve query.
@@ -45,3 +63,30 @@ func WithSparseVector(sparse map[string]float32) retriever.Option {
 		o.SparseVector = sparse
 	})
 }
+
+// WithHybrid sets the hybrid search settings for the retrieve query,
+// overriding the ones of the search mode.
+// This may take effect in search modes.
+func WithHybrid(hybrid *HybridOptions) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.Hybrid = hybrid
+	})
+}
+
+// WithMetadataFilters restricts the retrieve query to the documents whose metadata
+// match all the given filters. The filtered fields must be listed in RetrieverConfig.FilterFields.
+// This may take effect in search modes.
+func WithMetadataFilters(filters ...MetadataFilter) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.MetadataFilters = append(o.MetadataFilters, filters...)
+	})
+}
+
+// WithReturnVectors sets whether the stored embeddings in RetrieverConfig.VectorField are returned
+// as the documents' dense vectors. Default: false, the field is excluded from _source to keep
+// the response small.
+func WithReturnVectors(returnVectors bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.ReturnVectors = returnVectors
+	})
+}
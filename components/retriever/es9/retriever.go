@@ -58,6 +58,14 @@ type RetrieverConfig struct {
 	// Embedding is the embedding model used for vectorization.
 	// It is required when SearchMode needs it.
 	Embedding embedding.Embedder
+	// FilterFields lists the metadata fields that may be filtered on with WithMetadataFilters.
+	// Metadata filters on any other field are rejected, so that callers cannot inject
+	// conditions on arbitrary fields. Filters set with WithFilters are not checked.
+	FilterFields []string `json:"filter_fields"`
+	// VectorField is the dense_vector field holding the document embeddings.
+	// When set, the field is excluded from _source unless WithReturnVectors(true) is given,
+	// in which case it is returned as the document's dense vector instead of metadata.
+	VectorField string `json:"vector_field"`
 }
 
 // SearchMode defines the interface for building Elasticsearch search requests.
@@ -123,6 +131,11 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		return nil, err
 	}
 
+	io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	if r.config.VectorField != "" && !io.ReturnVectors && req.Source_ == nil {
+		req.Source_ = &types.SourceFilter{Excludes: []string{r.config.VectorField}}
+	}
+
 	resp, err := search.NewSearchFunc(r.client)().
 		Index(r.config.Index).
 		Request(req).
@@ -131,7 +144,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		return nil, err
 	}
 
-	docs, err = r.parseSearchResult(ctx, resp)
+	docs, err = r.parseSearchResult(ctx, resp, io.ReturnVectors)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +154,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	return docs, nil
 }
 
-func (r *Retriever) parseSearchResult(ctx context.Context, resp *search.Response) (docs []*schema.Document, err error) {
+func (r *Retriever) parseSearchResult(ctx context.Context, resp *search.Response, returnVectors bool) (docs []*schema.Document, err error) {
 	if len(resp.Hits.Hits) == 0 {
 		return []*schema.Document{}, nil
 	}
@@ -153,6 +166,12 @@ func (r *Retriever) parseSearchResult(ctx context.Context, resp *search.Response
 			return nil, err
 		}
 
+		if returnVectors && r.config.VectorField != "" {
+			if err = attachVector(doc, hit, r.config.VectorField); err != nil {
+				return nil, err
+			}
+		}
+
 		docs = append(docs, doc)
 	}
 
@@ -169,6 +188,34 @@ func (r *Retriever) IsCallbacksEnabled() bool {
 	return true
 }
 
+// attachVector sets the dense vector of doc from the given _source field of hit,
+// and drops the raw field from the metadata filled by the result parser.
+func attachVector(doc *schema.Document, hit types.Hit, field string) error {
+	if hit.Source_ == nil {
+		return nil
+	}
+
+	var source map[string]json.RawMessage
+	if err := json.Unmarshal(hit.Source_, &source); err != nil {
+		return fmt.Errorf("[attachVector] unmarshal document source failed: %w", err)
+	}
+
+	raw, ok := source[field]
+	if !ok {
+		return nil
+	}
+
+	var vector []float64
+	if err := json.Unmarshal(raw, &vector); err != nil {
+		return fmt.Errorf("[attachVector] field '%s' in document %s is not a dense vector: %w", field, doc.ID, err)
+	}
+
+	delete(doc.MetaData, field)
+	doc.WithDenseVector(vector)
+
+	return nil
+}
+
 func defaultResultParser(ctx context.Context, hit types.Hit) (*schema.Document, error) {
 	if hit.Id_ == nil {
 		return nil, fmt.Errorf("defaultResultParser: field '_id' not found in hit")
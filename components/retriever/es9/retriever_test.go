@@ -248,3 +248,101 @@ type mockSearchMode struct{}
 func (m *mockSearchMode) BuildRequest(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (*search.Request, error) {
 	return &search.Request{}, nil
 }
+
+func TestReturnVectors(t *testing.T) {
+	ctx := context.Background()
+
+	sm := &recordSearchMode{}
+	r, err := NewRetriever(ctx, &RetrieverConfig{
+		Client:      &elasticsearch.Client{},
+		Index:       "eino_ut",
+		SearchMode:  sm,
+		VectorField: "content_vector",
+	})
+	assert.NoError(t, err)
+
+	mockSearch := search.NewSearchFunc(r.client)()
+
+	defer mockey.Mock(mockey.GetMethod(mockSearch, "Index")).
+		Return(mockSearch).Build().Patch().UnPatch()
+
+	defer mockey.Mock(mockey.GetMethod(mockSearch, "Request")).
+		Return(mockSearch).Build().Patch().UnPatch()
+
+	defer mockey.Mock(mockey.GetMethod(mockSearch, "Do")).Return(&search.Response{
+		Hits: types.HitsMetadata{
+			Hits: []types.Hit{
+				{
+					Id_:     func() *string { s := "doc_1"; return &s }(),
+					Source_: json.RawMessage(`{"content": "hello", "content_vector": [0.1, 0.2, 0.3]}`),
+				},
+			},
+		},
+	}, nil).Build().Patch().UnPatch()
+
+	t.Run("default_off", func(t *testing.T) {
+		_, err := r.Retrieve(ctx, "hello")
+		assert.NoError(t, err)
+		assert.Equal(t, &types.SourceFilter{Excludes: []string{"content_vector"}}, sm.req.Source_)
+	})
+
+	t.Run("return_vectors", func(t *testing.T) {
+		docs, err := r.Retrieve(ctx, "hello", WithReturnVectors(true))
+		assert.NoError(t, err)
+		assert.Nil(t, sm.req.Source_)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, []float64{0.1, 0.2, 0.3}, docs[0].DenseVector())
+		assert.NotContains(t, docs[0].MetaData, "content_vector")
+	})
+}
+
+type recordSearchMode struct {
+	req *search.Request
+}
+
+func (m *recordSearchMode) BuildRequest(ctx context.Context, conf *RetrieverConfig, query string, opts ...retriever.Option) (*search.Request, error) {
+	m.req = &search.Request{}
+	return m.req, nil
+}
+
+func TestResolveFilters(t *testing.T) {
+	conf := &RetrieverConfig{FilterFields: []string{"tenant", "created_at", "source"}}
+	raw := types.Query{Exists: &types.ExistsQuery{Field: "content"}}
+
+	t.Run("term filter", func(t *testing.T) {
+		io := retriever.GetImplSpecificOptions[ImplOptions](nil,
+			WithFilters([]types.Query{raw}),
+			WithMetadataFilters(MetadataFilter{Field: "tenant", Value: "acme"}))
+		filters, err := ResolveFilters(conf, io)
+		assert.NoError(t, err)
+		assert.Len(t, filters, 2)
+		assert.Equal(t, raw, filters[0])
+		assert.Equal(t, "acme", filters[1].Term["tenant"].Value)
+	})
+
+	t.Run("terms and range filters", func(t *testing.T) {
+		io := retriever.GetImplSpecificOptions[ImplOptions](nil, WithMetadataFilters(
+			MetadataFilter{Field: "source", Values: []any{"wiki", "docs"}},
+			MetadataFilter{Field: "created_at", Gte: "2025-01-01", Lte: "2025-12-31"}))
+		filters, err := ResolveFilters(conf, io)
+		assert.NoError(t, err)
+		assert.Len(t, filters, 2)
+
+		b, err := json.Marshal(filters)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"terms":{"source":["wiki","docs"]}},{"range":{"created_at":{"gte":"2025-01-01","lte":"2025-12-31"}}}]`, string(b))
+	})
+
+	t.Run("rejected filters", func(t *testing.T) {
+		for _, f := range []MetadataFilter{
+			{Field: "password", Value: "x"},
+			{Field: "tenant"},
+			{Field: "tenant", Value: "a", Values: []any{"b"}},
+			{Field: "tenant", Value: map[string]any{"script": "1"}},
+		} {
+			io := retriever.GetImplSpecificOptions[ImplOptions](nil, WithMetadataFilters(f))
+			_, err := ResolveFilters(conf, io)
+			assert.Error(t, err, "%+v", f)
+		}
+	})
+}
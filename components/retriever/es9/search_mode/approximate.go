@@ -45,6 +45,7 @@ type ApproximateConfig struct {
 	// This field is required.
 	VectorFieldName string
 	// Hybrid, if true, adds filters and RRF to the KNN query.
+	// It can be overridden per call with es9.WithHybrid.
 	Hybrid bool
 	// RRF (Reciprocal Rank Fusion) is a method for combining multiple result sets.
 	// It is used to balance the score from the KNN query and the text query.
@@ -69,6 +70,9 @@ type ApproximateConfig struct {
 	NumCandidates *int
 	// Similarity is the minimum similarity for a vector to be considered a match.
 	Similarity *float32
+	// TextBoost weights the score of the text query in Hybrid search without RRF,
+	// the final score being TextBoost * BM25 score + Boost * KNN score.
+	TextBoost *float32
 }
 
 type approximate struct {
@@ -85,11 +89,30 @@ func (a *approximate) BuildRequest(ctx context.Context, conf *es9.RetrieverConfi
 	}, opts...)
 
 	io := retriever.GetImplSpecificOptions[es9.ImplOptions](nil, opts...)
+	filters, err := es9.ResolveFilters(conf, io)
+	if err != nil {
+		return nil, err
+	}
+
+	hybrid, rrf := a.config.Hybrid, a.config.RRF
+	textBoost, vectorBoost := a.config.TextBoost, a.config.Boost
+	if h := io.Hybrid; h != nil {
+		hybrid, rrf = h.Enabled, h.RRF
+		if h.TextWeight != nil {
+			textBoost = h.TextWeight
+		}
+		if h.VectorWeight != nil {
+			vectorBoost = h.VectorWeight
+		}
+	}
+	if hybrid && a.config.QueryFieldName == "" {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeApproximate] query field name not provided for hybrid search")
+	}
 
 	knn := types.KnnSearch{
-		Boost:              a.config.Boost,
+		Boost:              vectorBoost,
 		Field:              a.config.VectorFieldName,
-		Filter:             io.Filters,
+		Filter:             filters,
 		K:                  a.config.K,
 		NumCandidates:      a.config.NumCandidates,
 		QueryVector:        nil,
@@ -122,21 +145,21 @@ func (a *approximate) BuildRequest(ctx context.Context, conf *es9.RetrieverConfi
 
 	req := &search.Request{Knn: []types.KnnSearch{knn}, Size: co.TopK}
 
-	if a.config.Hybrid {
+	if hybrid {
 		req.Query = &types.Query{
 			Bool: &types.BoolQuery{
-				Filter: io.Filters,
+				Filter: filters,
 				Must: []types.Query{
 					{
 						Match: map[string]types.MatchQuery{
-							a.config.QueryFieldName: {Query: query},
+							a.config.QueryFieldName: {Query: query, Boost: textBoost},
 						},
 					},
 				},
 			},
 		}
 
-		if a.config.RRF {
+		if rrf {
 			req.Rank = &types.RankContainer{Rrf: &types.RrfRank{
 				RankConstant:   a.config.RRFRankConstant,
 				RankWindowSize: a.config.RRFWindowSize,
@@ -48,6 +48,10 @@ func (d *denseVectorSimilarity) BuildRequest(ctx context.Context, conf *es9.Retr
 	}, opts...)
 
 	io := retriever.GetImplSpecificOptions[es9.ImplOptions](nil, opts...)
+	filters, err := es9.ResolveFilters(conf, io)
+	if err != nil {
+		return nil, err
+	}
 
 	emb := co.Embedding
 	if emb == nil {
@@ -77,9 +81,9 @@ func (d *denseVectorSimilarity) BuildRequest(ctx context.Context, conf *es9.Retr
 		},
 	}
 
-	if len(io.Filters) > 0 {
+	if len(filters) > 0 {
 		q.ScriptScore.Query = types.Query{
-			Bool: &types.BoolQuery{Filter: io.Filters},
+			Bool: &types.BoolQuery{Filter: filters},
 		}
 	} else {
 		q.ScriptScore.Query = types.Query{
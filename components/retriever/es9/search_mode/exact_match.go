@@ -25,7 +25,8 @@ import (
 	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
 )
 
-// SearchModeExactMatch creates an exact match query for the specified field.
+// SearchModeExactMatch creates an exact match query for the specified field,
+// restricted to the documents matching the filters of WithFilters and WithMetadataFilters.
 func SearchModeExactMatch(queryFieldName string) es9.SearchMode {
 	return &exactMatch{queryFieldName}
 }
@@ -44,11 +45,25 @@ func (e exactMatch) BuildRequest(ctx context.Context, conf *es9.RetrieverConfig,
 		Embedding:      conf.Embedding,
 	}, opts...)
 
+	io := retriever.GetImplSpecificOptions[es9.ImplOptions](nil, opts...)
+	filters, err := es9.ResolveFilters(conf, io)
+	if err != nil {
+		return nil, err
+	}
+
 	q := &types.Query{
 		Match: map[string]types.MatchQuery{
 			e.name: {Query: query},
 		},
 	}
+	if len(filters) > 0 {
+		q = &types.Query{
+			Bool: &types.BoolQuery{
+				Must:   []types.Query{*q},
+				Filter: filters,
+			},
+		}
+	}
 
 	req := &search.Request{Query: q, Size: options.TopK}
 	if options.ScoreThreshold != nil {
@@ -18,6 +18,7 @@ package search_mode
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/cloudwego/eino-ext/components/retriever/es9"
 	"github.com/cloudwego/eino/components/retriever"
@@ -25,6 +26,8 @@ import (
 )
 
 // SearchModeRawStringRequest uses the query string as the JSON request body directly.
+// It cannot apply WithMetadataFilters, so BuildRequest fails when they are set:
+// put the filters in the request body instead.
 func SearchModeRawStringRequest() es9.SearchMode {
 	return &rawString{}
 }
@@ -34,6 +37,11 @@ type rawString struct{}
 func (r rawString) BuildRequest(ctx context.Context, conf *es9.RetrieverConfig, query string,
 	opts ...retriever.Option) (*search.Request, error) {
 
+	io := retriever.GetImplSpecificOptions[es9.ImplOptions](nil, opts...)
+	if len(io.MetadataFilters) > 0 {
+		return nil, fmt.Errorf("[BuildRequest][SearchModeRawStringRequest] metadata filters are not supported, add them to the request body")
+	}
+
 	req, err := search.NewRequest().FromJSON(query)
 	if err != nil {
 		return nil, err
@@ -17,12 +17,19 @@
 package search_mode
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"testing"
 
 	"github.com/cloudwego/eino-ext/components/retriever/es9"
 	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/elastic/go-elasticsearch/v9"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
 	"github.com/smartystreets/goconvey/convey"
 )
 
@@ -38,6 +45,22 @@ func TestSearchModeExactMatch(t *testing.T) {
 		convey.So(req.Query, convey.ShouldNotBeNil)
 		convey.So(req.Query.Match, convey.ShouldContainKey, "test_field")
 		convey.So(req.Query.Match["test_field"].Query, convey.ShouldEqual, "test_query")
+
+		convey.Convey("test metadata filters", func() {
+			conf := &es9.RetrieverConfig{FilterFields: []string{"tenant"}}
+			req, err := searchMode.BuildRequest(ctx, conf, "test_query",
+				es9.WithMetadataFilters(es9.MetadataFilter{Field: "tenant", Value: "acme"}))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.Query.Bool, convey.ShouldNotBeNil)
+			convey.So(req.Query.Bool.Must, convey.ShouldHaveLength, 1)
+			convey.So(req.Query.Bool.Must[0].Match["test_field"].Query, convey.ShouldEqual, "test_query")
+			convey.So(req.Query.Bool.Filter, convey.ShouldHaveLength, 1)
+			convey.So(req.Query.Bool.Filter[0].Term["tenant"].Value, convey.ShouldEqual, "acme")
+
+			_, err = searchMode.BuildRequest(ctx, conf, "test_query",
+				es9.WithMetadataFilters(es9.MetadataFilter{Field: "owner", Value: "acme"}))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
 	})
 }
 
@@ -64,6 +87,15 @@ func TestSearchModeRawStringRequest(t *testing.T) {
 			// However, FromJSON usually populates internal fields or the struct itself.
 			// For typedapi, we assume if no error, it's good.
 		})
+
+		convey.Convey("test metadata filters rejected", func() {
+			q := `{"query":{"match":{"test_field":{"query":"test_query"}}}}`
+			r, err := searchMode.BuildRequest(ctx, conf, q,
+				es9.WithMetadataFilters(es9.MetadataFilter{Field: "tenant", Value: "acme"}))
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "metadata filters are not supported")
+			convey.So(r, convey.ShouldBeNil)
+		})
 	})
 }
 
@@ -142,6 +174,62 @@ func TestApproximate(t *testing.T) {
 			convey.So(req.Rank.Rrf, convey.ShouldNotBeNil)
 		})
 
+		convey.Convey("test per-call hybrid", func() {
+			searchMode = SearchModeApproximate(&ApproximateConfig{
+				VectorFieldName: "vector_field",
+				QueryFieldName:  "text_field",
+			})
+			req, err := searchMode.BuildRequest(ctx, conf, "test_query", es9.WithHybrid(&es9.HybridOptions{
+				Enabled:      true,
+				TextWeight:   of(float32(0.3)),
+				VectorWeight: of(float32(0.7)),
+			}))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.Knn[0].Field, convey.ShouldEqual, "vector_field")
+			convey.So(*req.Knn[0].Boost, convey.ShouldEqual, float32(0.7))
+			convey.So(req.Query, convey.ShouldNotBeNil)
+			match := req.Query.Bool.Must[0].Match["text_field"]
+			convey.So(match.Query, convey.ShouldEqual, "test_query")
+			convey.So(*match.Boost, convey.ShouldEqual, float32(0.3))
+			convey.So(req.Rank, convey.ShouldBeNil)
+
+			req, err = searchMode.BuildRequest(ctx, conf, "test_query", es9.WithHybrid(&es9.HybridOptions{Enabled: true, RRF: true}))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.Query.Bool.Must, convey.ShouldHaveLength, 1)
+			convey.So(req.Rank.Rrf, convey.ShouldNotBeNil)
+
+			req, err = SearchModeApproximate(&ApproximateConfig{
+				VectorFieldName: "vector_field",
+				QueryFieldName:  "text_field",
+				Hybrid:          true,
+			}).BuildRequest(ctx, conf, "test_query", es9.WithHybrid(&es9.HybridOptions{Enabled: false}))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.Query, convey.ShouldBeNil)
+
+			_, err = SearchModeApproximate(&ApproximateConfig{VectorFieldName: "vector_field"}).
+				BuildRequest(ctx, conf, "test_query", es9.WithHybrid(&es9.HybridOptions{Enabled: true}))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
+		convey.Convey("test metadata filter", func() {
+			filterConf := &es9.RetrieverConfig{Embedding: &MockEmbedder{}, FilterFields: []string{"tenant"}}
+			searchMode = SearchModeApproximate(&ApproximateConfig{
+				VectorFieldName: "vector_field",
+				QueryFieldName:  "text_field",
+				Hybrid:          true,
+			})
+			req, err := searchMode.BuildRequest(ctx, filterConf, "test_query",
+				es9.WithMetadataFilters(es9.MetadataFilter{Field: "tenant", Value: "acme"}))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(req.Knn[0].Filter, convey.ShouldHaveLength, 1)
+			convey.So(req.Knn[0].Filter[0].Term["tenant"].Value, convey.ShouldEqual, "acme")
+			convey.So(req.Query.Bool.Filter, convey.ShouldHaveLength, 1)
+
+			_, err = searchMode.BuildRequest(ctx, filterConf, "test_query",
+				es9.WithMetadataFilters(es9.MetadataFilter{Field: "owner", Value: "acme"}))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+
 		convey.Convey("test query vector builder", func() {
 			modelID := "test_model"
 			searchMode = SearchModeApproximate(&ApproximateConfig{
@@ -157,6 +245,76 @@ func TestApproximate(t *testing.T) {
 	})
 }
 
+func TestApproximateRetrieve(t *testing.T) {
+	convey.Convey("test Approximate retrieve through a mocked transport", t, func() {
+		ctx := context.Background()
+		mockT := &mockSearchTransport{
+			response: `{"took":1,"timed_out":false,"_shards":{"total":1,"successful":1,"skipped":0,"failed":0},
+"hits":{"total":{"value":2,"relation":"eq"},"max_score":0.9,"hits":[
+{"_index":"eino_ut","_id":"doc_1","_score":0.9,"_source":{"content":"first","tenant":"a"}},
+{"_index":"eino_ut","_id":"doc_2","_score":0.4,"_source":{"content":"second","tenant":"a"}}]}}`,
+		}
+		client, err := elasticsearch.NewClient(elasticsearch.Config{Transport: mockT})
+		convey.So(err, convey.ShouldBeNil)
+
+		r, err := es9.NewRetriever(ctx, &es9.RetrieverConfig{
+			Client:    client,
+			Index:     "eino_ut",
+			Embedding: &MockEmbedder{},
+			SearchMode: SearchModeApproximate(&ApproximateConfig{
+				VectorFieldName: "vector_field",
+				QueryFieldName:  "content",
+				Hybrid:          true,
+			}),
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		docs, err := r.Retrieve(ctx, "test_query",
+			retriever.WithTopK(2),
+			es9.WithFilters([]types.Query{{Term: map[string]types.TermQuery{"tenant": {Value: "a"}}}}))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(docs), convey.ShouldEqual, 2)
+		convey.So(docs[0].ID, convey.ShouldEqual, "doc_1")
+		convey.So(docs[0].Content, convey.ShouldEqual, "first")
+		convey.So(docs[0].Score(), convey.ShouldEqual, 0.9)
+		convey.So(docs[1].MetaData["tenant"], convey.ShouldEqual, "a")
+
+		convey.So(mockT.path, convey.ShouldEqual, "/eino_ut/_search")
+		var body map[string]any
+		convey.So(json.Unmarshal(mockT.body, &body), convey.ShouldBeNil)
+		convey.So(body["size"], convey.ShouldEqual, float64(2))
+		knn := body["knn"].([]any)[0].(map[string]any)
+		convey.So(knn["field"], convey.ShouldEqual, "vector_field")
+		convey.So(knn["query_vector"], convey.ShouldResemble, []any{0.1, 0.2})
+		convey.So(knn["filter"], convey.ShouldNotBeNil)
+		boolQuery := body["query"].(map[string]any)["bool"].(map[string]any)
+		convey.So(boolQuery["must"], convey.ShouldNotBeNil)
+		convey.So(boolQuery["filter"], convey.ShouldNotBeNil)
+	})
+}
+
+// mockSearchTransport records the search request and replies with a canned response.
+type mockSearchTransport struct {
+	response string
+	path     string
+	body     []byte
+}
+
+func (m *mockSearchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	m.path = req.URL.Path
+	if req.Body != nil {
+		m.body, _ = io.ReadAll(req.Body)
+	}
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewReader([]byte(m.response))),
+		Header: http.Header{
+			"X-Elastic-Product": []string{"Elasticsearch"},
+			"Content-Type":      []string{"application/json"},
+		},
+	}, nil
+}
+
 func TestSparseVectorQuery(t *testing.T) {
 	convey.Convey("test SparseVectorQuery", t, func() {
 		ctx := context.Background()
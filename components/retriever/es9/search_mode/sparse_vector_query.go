@@ -60,6 +60,10 @@ func (s *sparseVectorQuery) BuildRequest(ctx context.Context, conf *es9.Retrieve
 	}, opts...)
 
 	io := retriever.GetImplSpecificOptions[es9.ImplOptions](nil, opts...)
+	filters, err := es9.ResolveFilters(conf, io)
+	if err != nil {
+		return nil, err
+	}
 
 	svq := &types.SparseVectorQuery{
 		Boost: s.config.Boost,
@@ -82,7 +86,7 @@ func (s *sparseVectorQuery) BuildRequest(ctx context.Context, conf *es9.Retrieve
 					SparseVector: svq,
 				},
 			},
-			Filter: io.Filters,
+			Filter: filters,
 		},
 	}
 
@@ -49,6 +49,10 @@ func (s sparseVectorTextExpansion) BuildRequest(ctx context.Context, conf *es9.R
 	}, opts...)
 
 	io := retriever.GetImplSpecificOptions[es9.ImplOptions](nil, opts...)
+	filters, err := es9.ResolveFilters(conf, io)
+	if err != nil {
+		return nil, err
+	}
 
 	name := fmt.Sprintf("%s.tokens", s.vectorFieldName)
 	teq := types.TextExpansionQuery{
@@ -61,7 +65,7 @@ func (s sparseVectorTextExpansion) BuildRequest(ctx context.Context, conf *es9.R
 			Must: []types.Query{
 				{TextExpansion: map[string]types.TextExpansionQuery{name: teq}},
 			},
-			Filter: io.Filters,
+			Filter: filters,
 		},
 	}
 
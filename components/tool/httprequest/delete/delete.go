@@ -19,8 +19,9 @@ package delete
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/internal"
 )
 
 type DeleteRequest struct {
@@ -34,9 +35,11 @@ func (r *DeleteRequestTool) Delete(ctx context.Context, req *DeleteRequest) (str
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
+	internal.SetDefaultHeaders(httpReq, r.config.UserAgent)
 	for key, value := range r.config.Headers {
 		httpReq.Header.Set(key, value)
 	}
+	internal.SetAuth(httpReq, r.config.BearerToken, r.config.BasicAuth)
 
 	resp, err := r.client.Do(httpReq)
 	if err != nil {
@@ -44,10 +47,20 @@ func (r *DeleteRequestTool) Delete(ctx context.Context, req *DeleteRequest) (str
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, truncated, err := internal.ReadBody(resp, r.config.MaxResponseBytes)
 	if err != nil {
 		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return string(body), nil
+	output := &internal.Response{
+		Status:    resp.StatusCode,
+		Headers:   internal.SelectHeaders(resp.Header, r.config.ResponseHeaders),
+		Body:      string(body),
+		Truncated: truncated,
+	}
+	if r.config.ParseJSON {
+		output.FormatJSON()
+	}
+
+	return internal.FormatOutput(output, len(r.config.ResponseHeaders) > 0)
 }
@@ -19,24 +19,35 @@ package get
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/internal"
+
+	"github.com/cloudwego/eino/schema"
 )
 
 type GetRequest struct {
-	URL string `json:"url" jsonschema_description:"The URL to make the GET request"`
+	URL          string            `json:"url" jsonschema_description:"The URL to make the GET request"`
+	Params       map[string]string `json:"params,omitempty" jsonschema_description:"The query parameters to append to the URL, they will be URL-encoded"`
+	ResponsePath string            `json:"response_path,omitempty" jsonschema_description:"A dot-path such as data.items[0].name selecting the part of a JSON response to return, the full body is returned when it does not match"`
 }
 
 func (r *GetRequestTool) Get(ctx context.Context, req *GetRequest) (string, error) {
+	reqURL, err := internal.BuildURL(req.URL, req.Params)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request url: %w", err)
+	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.URL, nil)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
+	internal.SetDefaultHeaders(httpReq, r.config.UserAgent)
 	for key, value := range r.config.Headers {
 		httpReq.Header.Set(key, value)
 	}
+	internal.SetAuth(httpReq, r.config.BearerToken, r.config.BasicAuth)
 
 	resp, err := r.client.Do(httpReq)
 	if err != nil {
@@ -44,10 +55,61 @@ func (r *GetRequestTool) Get(ctx context.Context, req *GetRequest) (string, erro
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, truncated, err := internal.ReadBody(resp, r.config.MaxResponseBytes)
 	if err != nil {
 		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return string(body), nil
+	output := &internal.Response{
+		Status:    resp.StatusCode,
+		Headers:   internal.SelectHeaders(resp.Header, r.config.ResponseHeaders),
+		Body:      string(body),
+		Truncated: truncated,
+	}
+	output.ExtractPath(req.ResponsePath)
+	if r.config.ParseJSON {
+		output.FormatJSON()
+	}
+
+	return internal.FormatOutput(output, len(r.config.ResponseHeaders) > 0)
+}
+
+type GetStreamRequest struct {
+	URL    string            `json:"url" jsonschema_description:"The URL to make the GET request"`
+	Params map[string]string `json:"params,omitempty" jsonschema_description:"The query parameters to append to the URL, they will be URL-encoded"`
+}
+
+// GetStream sends the GET request and returns the response body as a stream of
+// chunks instead of buffering it, or as a stream of event data when SSE is set.
+// Closing the stream or cancelling ctx stops the download. Response headers are
+// not reported and ParseJSON does not apply.
+func (r *GetRequestTool) GetStream(ctx context.Context, req *GetStreamRequest) (*schema.StreamReader[string], error) {
+	reqURL, err := internal.BuildURL(req.URL, req.Params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request url: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	internal.SetDefaultHeaders(httpReq, r.config.UserAgent)
+	if r.config.SSE {
+		internal.SetAcceptEventStream(httpReq)
+	}
+	for key, value := range r.config.Headers {
+		httpReq.Header.Set(key, value)
+	}
+	internal.SetAuth(httpReq, r.config.BearerToken, r.config.BasicAuth)
+
+	resp, err := r.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	if r.config.SSE {
+		return internal.StreamSSE(ctx, resp)
+	}
+	return internal.StreamBody(ctx, resp, r.config.StreamChunkSize, r.config.MaxResponseBytes)
 }
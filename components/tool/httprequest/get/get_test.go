@@ -17,15 +17,20 @@
 package get
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/bytedance/mockey"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/internal"
 )
 
 type mockTransport struct {
@@ -176,7 +181,7 @@ func TestNewTool_Config(t *testing.T) {
 
 		doc, err := info.ParamsOneOf.ToJSONSchema()
 		assert.Nil(t, err)
-		assert.Equal(t, 1, doc.Properties.Len())
+		assert.Equal(t, 3, doc.Properties.Len())
 		for pair := doc.Properties.Oldest(); pair != nil; pair = pair.Next() {
 			assert.NotEqual(t, "", pair.Value.Description)
 		}
@@ -211,3 +216,413 @@ func TestGet_WithHeaders(t *testing.T) {
 	assert.Equal(t, "Bearer token", receivedHeaders.Get("Authorization"))
 	assert.Equal(t, "test-agent", receivedHeaders.Get("User-Agent"))
 }
+
+func TestGet_WithParams(t *testing.T) {
+	var receivedURL string
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			receivedURL = req.URL.String()
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &GetRequestTool{
+		config: &Config{
+			Headers: make(map[string]string),
+		},
+		client: client,
+	}
+
+	req := &GetRequest{
+		URL: "https://example.com/resource?keep=1",
+		Params: map[string]string{
+			"q":    "hello world&more",
+			"lang": "zh/cn",
+		},
+	}
+	_, err := tool.Get(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/resource?keep=1&lang=zh%2Fcn&q=hello+world%26more", receivedURL)
+}
+
+func TestGet_MaxResponseBytes(t *testing.T) {
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(strings.Repeat("a", 100))),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &GetRequestTool{
+		config: &Config{
+			Headers:          make(map[string]string),
+			MaxResponseBytes: 10,
+		},
+		client: client,
+	}
+
+	req := &GetRequest{URL: "https://example.com/resource"}
+	result, err := tool.Get(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", 10)+"\n[response truncated to 10 bytes]", result)
+}
+
+func TestGet_ResponseHeaders(t *testing.T) {
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Header: http.Header{
+					"Location":     []string{"https://example.com/resource/1"},
+					"Link":         []string{"<https://example.com/resource?page=2>; rel=\"next\""},
+					"Content-Type": []string{"application/json"},
+				},
+				Body: io.NopCloser(strings.NewReader(`{"id":1}`)),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &GetRequestTool{
+		config: &Config{
+			Headers:         make(map[string]string),
+			ResponseHeaders: []string{"location", "Link", "X-Missing"},
+		},
+		client: client,
+	}
+
+	req := &GetRequest{URL: "https://example.com/resource"}
+	result, err := tool.Get(context.Background(), req)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"status": 201,
+		"headers": {
+			"Location": "https://example.com/resource/1",
+			"Link": "<https://example.com/resource?page=2>; rel=\"next\""
+		},
+		"body": "{\"id\":1}"
+	}`, result)
+}
+
+func TestGet_Auth(t *testing.T) {
+	var authorization string
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			authorization = req.Header.Get("Authorization")
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &GetRequestTool{
+		config: &Config{
+			Headers:   make(map[string]string),
+			BasicAuth: &BasicAuth{User: "user", Pass: "pass"},
+		},
+		client: client,
+	}
+
+	req := &GetRequest{URL: "https://example.com/resource"}
+	_, err := tool.Get(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", authorization)
+
+	tool.config.BearerToken = "token"
+	_, err = tool.Get(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token", authorization)
+}
+
+func TestGet_ResponsePath(t *testing.T) {
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{"data": {"user": {"name": "eino", "roles": ["admin"]}}, "meta": {}}`)),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &GetRequestTool{
+		config: &Config{
+			Headers: make(map[string]string),
+		},
+		client: client,
+	}
+
+	result, err := tool.Get(context.Background(), &GetRequest{URL: "https://example.com", ResponsePath: "data.user.name"})
+	assert.NoError(t, err)
+	assert.Equal(t, "eino", result)
+
+	result, err = tool.Get(context.Background(), &GetRequest{URL: "https://example.com", ResponsePath: "$.data.user.roles"})
+	assert.NoError(t, err)
+	assert.Equal(t, `["admin"]`, result)
+
+	result, err = tool.Get(context.Background(), &GetRequest{URL: "https://example.com", ResponsePath: "data.missing"})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(result, `[response_path "data.missing" did not match the response, the full body is returned]`))
+	assert.Contains(t, result, `{"data": {"user"`)
+}
+
+func TestGet_FollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+			return
+		}
+		_, _ = io.WriteString(w, "moved here")
+	}))
+	defer server.Close()
+
+	follow := false
+	tool, err := newRequestTool(&Config{
+		FollowRedirects: &follow,
+		ResponseHeaders: []string{"Location"},
+	})
+	assert.NoError(t, err)
+
+	result, err := tool.Get(context.Background(), &GetRequest{URL: server.URL + "/old"})
+	assert.NoError(t, err)
+	assert.Contains(t, result, `"status":301`)
+	assert.Contains(t, result, `"Location":"/new"`)
+
+	follow = true
+	tool, err = newRequestTool(&Config{FollowRedirects: &follow})
+	assert.NoError(t, err)
+
+	result, err = tool.Get(context.Background(), &GetRequest{URL: server.URL + "/old"})
+	assert.NoError(t, err)
+	assert.Equal(t, "moved here", result)
+}
+
+func TestGet_DeniedHost(t *testing.T) {
+	called := false
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			called = true
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("secret")),
+			}, nil
+		},
+	}
+	tool, err := newRequestTool(&Config{
+		HttpClient:  &http.Client{Transport: mockTransport},
+		DeniedCIDRs: []string{"198.51.100.0/24"},
+	})
+	assert.NoError(t, err)
+
+	_, err = tool.Get(context.Background(), &GetRequest{URL: "http://169.254.169.254/latest/meta-data"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "resolves to denied address 169.254.169.254")
+	assert.False(t, called)
+
+	tool, err = newRequestTool(&Config{
+		HttpClient:   &http.Client{Transport: mockTransport},
+		AllowedHosts: []string{"api.example.com"},
+	})
+	assert.NoError(t, err)
+
+	_, err = tool.Get(context.Background(), &GetRequest{URL: "https://example.com"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `host "example.com" is not in the allowed hosts`)
+	assert.False(t, called)
+
+	result, err := tool.Get(context.Background(), &GetRequest{URL: "https://api.example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", result)
+	assert.True(t, called)
+}
+
+func TestGet_GzipResponse(t *testing.T) {
+	mockResponse := `{"message": "Hello, World!"}`
+	var compressed bytes.Buffer
+	gw := gzip.NewWriter(&compressed)
+	_, _ = gw.Write([]byte(mockResponse))
+	_ = gw.Close()
+
+	var acceptEncoding string
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			acceptEncoding = req.Header.Get("Accept-Encoding")
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+				Body:       io.NopCloser(&compressed),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &GetRequestTool{
+		config: &Config{
+			Headers: make(map[string]string),
+		},
+		client: client,
+	}
+
+	result, err := tool.Get(context.Background(), &GetRequest{URL: "https://example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "gzip, deflate", acceptEncoding)
+	assert.Equal(t, mockResponse, result)
+}
+
+func TestGet_ParseJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"data": [1, 2`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"data":{"user":{"name":"eino","roles":["admin"]}}}`)
+	}))
+	defer server.Close()
+
+	tool, err := newRequestTool(&Config{ParseJSON: true})
+	assert.NoError(t, err)
+
+	result, err := tool.Get(context.Background(), &GetRequest{URL: server.URL + "/user"})
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"data\": {\n    \"user\": {\n      \"name\": \"eino\",\n      \"roles\": [\n        \"admin\"\n      ]\n    }\n  }\n}", result)
+
+	result, err = tool.Get(context.Background(), &GetRequest{URL: server.URL + "/user", ResponsePath: "data.user.roles"})
+	assert.NoError(t, err)
+	assert.Equal(t, "[\n  \"admin\"\n]", result)
+
+	result, err = tool.Get(context.Background(), &GetRequest{URL: server.URL + "/user", ResponsePath: "data.user.name"})
+	assert.NoError(t, err)
+	assert.Equal(t, "eino", result)
+
+	result, err = tool.Get(context.Background(), &GetRequest{URL: server.URL + "/broken"})
+	assert.NoError(t, err)
+	assert.Equal(t, "[response is not valid JSON: unexpected end of JSON input]\n{\"data\": [1, 2", result)
+}
+
+func TestGet_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		if r.URL.Path == "/slow" {
+			_, _ = io.WriteString(w, "first")
+			flusher.Flush()
+			<-r.Context().Done()
+			return
+		}
+		for i := 0; i < 3; i++ {
+			_, _ = fmt.Fprintf(w, "line %d\n", i)
+			flusher.Flush()
+		}
+	}))
+	defer server.Close()
+
+	tool, err := newRequestTool(&Config{StreamChunkSize: 4})
+	assert.NoError(t, err)
+
+	t.Run("chunked delivery", func(t *testing.T) {
+		sr, err := tool.GetStream(context.Background(), &GetStreamRequest{URL: server.URL})
+		assert.NoError(t, err)
+		defer sr.Close()
+
+		var chunks []string
+		for {
+			chunk, err := sr.Recv()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, len(chunk), 4)
+			chunks = append(chunks, chunk)
+		}
+		assert.Greater(t, len(chunks), 1)
+		assert.Equal(t, "line 0\nline 1\nline 2\n", strings.Join(chunks, ""))
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		sr, err := tool.GetStream(ctx, &GetStreamRequest{URL: server.URL + "/slow"})
+		assert.NoError(t, err)
+		defer sr.Close()
+
+		var received string
+		for received != "first" {
+			chunk, err := sr.Recv()
+			assert.NoError(t, err)
+			received += chunk
+		}
+
+		cancel()
+		_, err = sr.Recv()
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("stream tool", func(t *testing.T) {
+		st, err := NewStreamTool(context.Background(), &Config{})
+		assert.NoError(t, err)
+		info, err := st.Info(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "request_get_stream", info.Name)
+	})
+}
+
+func TestGet_StreamSSE(t *testing.T) {
+	var accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		for _, frame := range []string{": ping\n\n", "data: hello\n\n", "data: multi\ndata: line\n\n", "data: [DONE]\n\n"} {
+			_, _ = io.WriteString(w, frame)
+			flusher.Flush()
+		}
+	}))
+	defer server.Close()
+
+	tool, err := newRequestTool(&Config{SSE: true})
+	assert.NoError(t, err)
+
+	sr, err := tool.GetStream(context.Background(), &GetStreamRequest{URL: server.URL})
+	assert.NoError(t, err)
+	defer sr.Close()
+
+	var events []string
+	for {
+		event, err := sr.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		events = append(events, event)
+	}
+	assert.Equal(t, "text/event-stream", accept)
+	assert.Equal(t, []string{"hello", "multi\nline"}, events)
+}
+
+func TestGet_DefaultHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%s|%s", r.UserAgent(), r.Header.Get("Accept"))
+	}))
+	defer server.Close()
+
+	tool, err := newRequestTool(&Config{})
+	assert.NoError(t, err)
+	result, err := tool.Get(context.Background(), &GetRequest{URL: server.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, internal.DefaultUserAgent+"|application/json", result)
+
+	tool, err = newRequestTool(&Config{UserAgent: "my-agent/2.0"})
+	assert.NoError(t, err)
+	result, err = tool.Get(context.Background(), &GetRequest{URL: server.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-agent/2.0|application/json", result)
+
+	tool, err = newRequestTool(&Config{Headers: map[string]string{"User-Agent": "header-agent", "Accept": "text/html"}})
+	assert.NoError(t, err)
+	result, err = tool.Get(context.Background(), &GetRequest{URL: server.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, "header-agent|text/html", result)
+}
@@ -20,15 +20,23 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/cloudwego/eino-ext/components/tool/httprequest/delete"
 	"github.com/cloudwego/eino-ext/components/tool/httprequest/get"
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/internal"
 	"github.com/cloudwego/eino-ext/components/tool/httprequest/post"
 	"github.com/cloudwego/eino-ext/components/tool/httprequest/put"
 
 	"github.com/cloudwego/eino/components/tool"
 )
 
+// BasicAuth holds the credentials of HTTP basic authentication.
+type BasicAuth = internal.BasicAuth
+
+// BearerToken is a token of bearer authentication, hidden when printed.
+type BearerToken = internal.BearerToken
+
 type Config struct {
 	// Optional.
 	// Headers is a map of HTTP header names to their corresponding values.
@@ -40,6 +48,76 @@ type Config struct {
 	// If not provided, a default client with a 30-second timeout and a standard transport
 	// will be initialized and used.
 	HttpClient *http.Client
+
+	// Optional. Default: nil, which keeps the redirect policy of HttpClient.
+	// FollowRedirects controls whether redirects are followed. When false, the
+	// redirect response itself is returned. When following redirects, the
+	// Authorization header is dropped once a redirect leaves the original host.
+	FollowRedirects *bool `json:"follow_redirects,omitempty"`
+
+	// Optional. Default: 10.
+	// MaxRedirects caps the number of redirects followed for a single request.
+	MaxRedirects int `json:"max_redirects"`
+
+	// Optional.
+	// CookieJar is shared by all the tools of the kit, so that cookies set by a response,
+	// e.g. a session cookie returned by a login request, are sent with the following requests.
+	CookieJar http.CookieJar `json:"-"`
+
+	// Optional.
+	// AllowedHosts restricts the hosts the tools may call, e.g. "api.example.com" or
+	// "*.example.com" for any subdomain. An empty list allows every host.
+	AllowedHosts []string `json:"allowed_hosts"`
+
+	// Optional.
+	// DeniedCIDRs lists the IP ranges the target host must not resolve to. Once it is set,
+	// loopback, private and link-local ranges, including cloud metadata endpoints, are blocked as well.
+	DeniedCIDRs []string `json:"denied_cidrs"`
+
+	// Optional.
+	// BearerToken is sent as "Authorization: Bearer <token>" with every request.
+	// It takes precedence over BasicAuth and is never serialized or printed.
+	BearerToken BearerToken `json:"-"`
+
+	// Optional.
+	// BasicAuth is sent as HTTP basic authentication with every request
+	// when BearerToken is empty. Its password is never serialized or printed.
+	BasicAuth *BasicAuth `json:"basic_auth,omitempty"`
+
+	// Optional. Default: false.
+	// IncludeStatus makes the POST and PUT tools return a JSON envelope of the form
+	// {"status":200,"body":"..."} instead of the bare response body.
+	IncludeStatus bool `json:"include_status"`
+
+	// Optional. Default: 0, which means no limit.
+	// MaxResponseBytes caps the number of response body bytes read by each tool.
+	MaxResponseBytes int64 `json:"max_response_bytes"`
+
+	// Optional.
+	// ResponseHeaders lists the response headers to return to the model from each tool.
+	// When set, the output is a JSON envelope carrying the status, headers and body.
+	ResponseHeaders []string `json:"response_headers"`
+
+	// Optional. Default: 0, which means no retry.
+	// MaxRetries is the number of times the PUT tool retries a request after a network error,
+	// a 5xx response or a 429 response. The POST tool only retries when AllowPostRetry is true.
+	MaxRetries int `json:"max_retries"`
+
+	// Optional. Default: 500 milliseconds.
+	// RetryBackoff is the wait before the first retry, doubled on every following retry.
+	RetryBackoff time.Duration `json:"retry_backoff"`
+
+	// Optional. Default: false.
+	// AllowPostRetry enables retrying POST requests, which are not idempotent.
+	AllowPostRetry bool `json:"allow_post_retry"`
+
+	// Optional. Default: false.
+	// ParseJSON makes every tool validate and pretty-print JSON response bodies.
+	ParseJSON bool `json:"parse_json"`
+
+	// Optional. Default: "eino-ext-httprequest/1.0".
+	// UserAgent is sent as the User-Agent header by every tool.
+	UserAgent string `json:"user_agent"`
 }
 
 func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
@@ -47,6 +125,17 @@ func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 	if conf != nil {
 		getConf.Headers = conf.Headers
 		getConf.HttpClient = conf.HttpClient
+		getConf.FollowRedirects = conf.FollowRedirects
+		getConf.MaxRedirects = conf.MaxRedirects
+		getConf.CookieJar = conf.CookieJar
+		getConf.AllowedHosts = conf.AllowedHosts
+		getConf.DeniedCIDRs = conf.DeniedCIDRs
+		getConf.BearerToken = conf.BearerToken
+		getConf.BasicAuth = conf.BasicAuth
+		getConf.MaxResponseBytes = conf.MaxResponseBytes
+		getConf.ResponseHeaders = conf.ResponseHeaders
+		getConf.ParseJSON = conf.ParseJSON
+		getConf.UserAgent = conf.UserAgent
 	}
 
 	getTool, err := get.NewTool(ctx, getConf)
@@ -58,6 +147,21 @@ func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 	if conf != nil {
 		postConf.Headers = conf.Headers
 		postConf.HttpClient = conf.HttpClient
+		postConf.FollowRedirects = conf.FollowRedirects
+		postConf.MaxRedirects = conf.MaxRedirects
+		postConf.CookieJar = conf.CookieJar
+		postConf.AllowedHosts = conf.AllowedHosts
+		postConf.DeniedCIDRs = conf.DeniedCIDRs
+		postConf.BearerToken = conf.BearerToken
+		postConf.BasicAuth = conf.BasicAuth
+		postConf.IncludeStatus = conf.IncludeStatus
+		postConf.MaxResponseBytes = conf.MaxResponseBytes
+		postConf.ResponseHeaders = conf.ResponseHeaders
+		postConf.ParseJSON = conf.ParseJSON
+		postConf.UserAgent = conf.UserAgent
+		postConf.MaxRetries = conf.MaxRetries
+		postConf.RetryBackoff = conf.RetryBackoff
+		postConf.AllowRetry = conf.AllowPostRetry
 	}
 	postTool, err := post.NewTool(ctx, postConf)
 	if err != nil {
@@ -68,6 +172,20 @@ func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 	if conf != nil {
 		putConf.Headers = conf.Headers
 		putConf.HttpClient = conf.HttpClient
+		putConf.FollowRedirects = conf.FollowRedirects
+		putConf.MaxRedirects = conf.MaxRedirects
+		putConf.CookieJar = conf.CookieJar
+		putConf.AllowedHosts = conf.AllowedHosts
+		putConf.DeniedCIDRs = conf.DeniedCIDRs
+		putConf.BearerToken = conf.BearerToken
+		putConf.BasicAuth = conf.BasicAuth
+		putConf.IncludeStatus = conf.IncludeStatus
+		putConf.MaxResponseBytes = conf.MaxResponseBytes
+		putConf.ResponseHeaders = conf.ResponseHeaders
+		putConf.ParseJSON = conf.ParseJSON
+		putConf.UserAgent = conf.UserAgent
+		putConf.MaxRetries = conf.MaxRetries
+		putConf.RetryBackoff = conf.RetryBackoff
 	}
 	putTool, err := put.NewTool(ctx, putConf)
 	if err != nil {
@@ -78,6 +196,17 @@ func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 	if conf != nil {
 		deleteConf.Headers = conf.Headers
 		deleteConf.HttpClient = conf.HttpClient
+		deleteConf.FollowRedirects = conf.FollowRedirects
+		deleteConf.MaxRedirects = conf.MaxRedirects
+		deleteConf.CookieJar = conf.CookieJar
+		deleteConf.AllowedHosts = conf.AllowedHosts
+		deleteConf.DeniedCIDRs = conf.DeniedCIDRs
+		deleteConf.BearerToken = conf.BearerToken
+		deleteConf.BasicAuth = conf.BasicAuth
+		deleteConf.MaxResponseBytes = conf.MaxResponseBytes
+		deleteConf.ResponseHeaders = conf.ResponseHeaders
+		deleteConf.ParseJSON = conf.ParseJSON
+		deleteConf.UserAgent = conf.UserAgent
 	}
 	deleteTool, err := delete.NewTool(ctx, deleteConf)
 	if err != nil {
@@ -18,9 +18,13 @@ package httprequest
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/cloudwego/eino/components/tool"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -64,3 +68,49 @@ func TestNewToolKit_NilConfig(t *testing.T) {
 	assert.Contains(t, toolNames, "requests_put")
 	assert.Contains(t, toolNames, "requests_delete")
 }
+
+func TestNewToolKit_CookieJar(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123", Path: "/"})
+			_, _ = io.WriteString(w, "logged in")
+		case "/profile":
+			cookie, err := r.Cookie("session")
+			if err != nil || cookie.Value != "abc123" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = io.WriteString(w, "unauthorized")
+				return
+			}
+			_, _ = io.WriteString(w, "profile")
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	jar, err := cookiejar.New(nil)
+	assert.NoError(t, err)
+
+	tools, err := NewToolKit(ctx, &Config{CookieJar: jar})
+	assert.NoError(t, err)
+
+	statelessTools, err := NewToolKit(ctx, nil)
+	assert.NoError(t, err)
+
+	invoke := func(tools []tool.BaseTool, name, args string) string {
+		for _, bt := range tools {
+			info, _ := bt.Info(ctx)
+			if info.Name == name {
+				out, err := bt.(tool.InvokableTool).InvokableRun(ctx, args)
+				assert.NoError(t, err)
+				return out
+			}
+		}
+		t.Fatalf("tool %s not found", name)
+		return ""
+	}
+
+	assert.Equal(t, "unauthorized", invoke(statelessTools, "request_get", `{"url": "`+server.URL+`/profile"}`))
+	assert.Equal(t, "logged in", invoke(tools, "requests_post", `{"url": "`+server.URL+`/login", "body": ""}`))
+	assert.Equal(t, "profile", invoke(tools, "request_get", `{"url": "`+server.URL+`/profile"}`))
+}
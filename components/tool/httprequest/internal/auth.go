@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// BasicAuth holds the credentials of HTTP basic authentication.
+type BasicAuth struct {
+	User string `json:"user"`
+	Pass string `json:"-"`
+}
+
+// String hides the password so that the credentials can be logged safely.
+func (b BasicAuth) String() string {
+	return fmt.Sprintf("{User:%s Pass:******}", b.User)
+}
+
+// GoString hides the password when the credentials are printed with %#v.
+func (b BasicAuth) GoString() string {
+	return b.String()
+}
+
+// BearerToken is a token of bearer authentication.
+type BearerToken string
+
+// String hides the token so that it can be logged safely.
+func (t BearerToken) String() string {
+	if t == "" {
+		return ""
+	}
+	return "******"
+}
+
+// GoString hides the token when it is printed with %#v.
+func (t BearerToken) GoString() string {
+	return t.String()
+}
+
+// SetAuth sets the Authorization header of req. The bearer token takes
+// precedence over basic authentication; nothing is set when both are empty.
+func SetAuth(req *http.Request, bearerToken BearerToken, basicAuth *BasicAuth) {
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+string(bearerToken))
+		return
+	}
+	if basicAuth != nil {
+		req.SetBasicAuth(basicAuth.User, basicAuth.Pass)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAuth(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	SetAuth(req, "", nil)
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	SetAuth(req, "", &BasicAuth{User: "user", Pass: "pass"})
+	assert.Equal(t, "Basic dXNlcjpwYXNz", req.Header.Get("Authorization"))
+
+	SetAuth(req, "token", &BasicAuth{User: "user", Pass: "pass"})
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+}
+
+func TestBasicAuth_Redacted(t *testing.T) {
+	auth := &BasicAuth{User: "user", Pass: "secret"}
+	for _, s := range []string{
+		fmt.Sprintf("%v", auth),
+		fmt.Sprintf("%+v", *auth),
+		fmt.Sprintf("%#v", auth),
+	} {
+		assert.NotContains(t, s, "secret")
+	}
+
+	out, err := json.Marshal(auth)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(out), "secret")
+}
+
+func TestBearerToken_Redacted(t *testing.T) {
+	config := struct {
+		BearerToken BearerToken `json:"-"`
+	}{BearerToken: "secret"}
+	for _, s := range []string{
+		fmt.Sprintf("%v", config),
+		fmt.Sprintf("%+v", config),
+		fmt.Sprintf("%#v", config),
+		fmt.Sprintf("%s", config.BearerToken),
+	} {
+		assert.NotContains(t, s, "secret")
+	}
+	assert.Empty(t, BearerToken("").String())
+}
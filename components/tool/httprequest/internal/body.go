@@ -0,0 +1,85 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// MultipartFile is a file field of a multipart/form-data body.
+type MultipartFile struct {
+	Field    string
+	FileName string
+	Content  string
+}
+
+// EncodeBody builds the request body. With neither form fields nor files the
+// raw body is sent as is and contentType is empty. Form fields alone are sent
+// as application/x-www-form-urlencoded; as soon as a file is present the form
+// is sent as multipart/form-data.
+func EncodeBody(raw string, form map[string]string, files []MultipartFile) (body string, contentType string, err error) {
+	if len(form) == 0 && len(files) == 0 {
+		return raw, "", nil
+	}
+	if raw != "" {
+		return "", "", errors.New("body cannot be combined with form or files")
+	}
+
+	if len(files) == 0 {
+		values := make(url.Values, len(form))
+		for key, value := range form {
+			values.Set(key, value)
+		}
+		return values.Encode(), "application/x-www-form-urlencoded", nil
+	}
+
+	var sb strings.Builder
+	w := multipart.NewWriter(&sb)
+
+	keys := make([]string, 0, len(form))
+	for key := range form {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err = w.WriteField(key, form[key]); err != nil {
+			return "", "", err
+		}
+	}
+
+	for _, file := range files {
+		if file.Field == "" {
+			return "", "", errors.New("file field name is required")
+		}
+		part, err := w.CreateFormFile(file.Field, file.FileName)
+		if err != nil {
+			return "", "", err
+		}
+		if _, err = part.Write([]byte(file.Content)); err != nil {
+			return "", "", err
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		return "", "", err
+	}
+	return sb.String(), w.FormDataContentType(), nil
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxRedirects is the number of redirects followed when redirects are
+// enabled without an explicit limit, matching the net/http default.
+const DefaultMaxRedirects = 10
+
+// ClientOptions holds the client behaviours that the tools configure on top
+// of the user provided http.Client.
+type ClientOptions struct {
+	// FollowRedirects enables or disables following redirects. nil keeps the
+	// redirect policy of the client.
+	FollowRedirects *bool
+	// MaxRedirects caps the number of redirects followed, DefaultMaxRedirects
+	// when zero.
+	MaxRedirects int
+	// CookieJar replaces the cookie jar of the client when not nil.
+	CookieJar http.CookieJar
+	// HostGuard checks the target of every request sent by the client when
+	// not nil, see NewHostGuard. The denylist is enforced again on the address
+	// each connection is dialed to, see HostGuard.guardTransport. A client
+	// without a transport gets a direct one, ignoring the proxy environment
+	// variables; a configured transport with a proxy only gets the first check.
+	HostGuard *HostGuard
+}
+
+// NewDefaultClient returns the client used by the tools when none is
+// configured: a 30-second timeout and a standard transport.
+func NewDefaultClient() *http.Client {
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{},
+	}
+}
+
+// ConfigureClient applies opts to client. The client is copied before being
+// changed, so that a client shared with the rest of the application is left
+// untouched; it is returned as is when opts changes nothing.
+func ConfigureClient(client *http.Client, opts *ClientOptions) *http.Client {
+	if opts == nil || (opts.FollowRedirects == nil && opts.MaxRedirects == 0 && opts.CookieJar == nil && opts.HostGuard == nil) {
+		return client
+	}
+
+	configured := *client
+	if opts.FollowRedirects != nil || opts.MaxRedirects != 0 {
+		follow := opts.FollowRedirects == nil || *opts.FollowRedirects
+		configured.CheckRedirect = redirectPolicy(follow, opts.MaxRedirects)
+	}
+	if opts.CookieJar != nil {
+		configured.Jar = opts.CookieJar
+	}
+	if opts.HostGuard != nil {
+		next := configured.Transport
+		if next == nil {
+			next = directDefaultTransport()
+		}
+		configured.Transport = &guardedTransport{guard: opts.HostGuard, next: opts.HostGuard.guardTransport(next)}
+	}
+	return &configured
+}
+
+// directDefaultTransport returns a copy of http.DefaultTransport that does
+// not use the proxy from the environment, so that the guard can check the
+// address each connection is dialed to instead of leaving it to the proxy.
+func directDefaultTransport() http.RoundTripper {
+	t, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return http.DefaultTransport
+	}
+	t = t.Clone()
+	t.Proxy = nil
+	return t
+}
+
+// redirectPolicy builds a CheckRedirect function. When following redirects,
+// the Authorization headers are dropped as soon as the redirect leaves the
+// host of the original request, so that credentials never leak cross-origin.
+func redirectPolicy(follow bool, maxRedirects int) func(req *http.Request, via []*http.Request) error {
+	if maxRedirects <= 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+
+	return func(req *http.Request, via []*http.Request) error {
+		if !follow {
+			return http.ErrUseLastResponse
+		}
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		if req.URL.Host != via[0].URL.Host {
+			req.Header.Del("Authorization")
+			req.Header.Del("Proxy-Authorization")
+		}
+		return nil
+	}
+}
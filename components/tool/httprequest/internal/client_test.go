@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigureClient_Unchanged(t *testing.T) {
+	client := &http.Client{}
+	assert.Same(t, client, ConfigureClient(client, nil))
+	assert.Same(t, client, ConfigureClient(client, &ClientOptions{}))
+}
+
+func TestConfigureClient_Redirects(t *testing.T) {
+	var authOnTarget string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authOnTarget = r.Header.Get("Authorization")
+		_, _ = io.WriteString(w, "target")
+	}))
+	defer target.Close()
+
+	hops := 0
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cross":
+			http.Redirect(w, r, target.URL, http.StatusFound)
+		case "/same":
+			http.Redirect(w, r, "/final", http.StatusFound)
+		case "/loop":
+			hops++
+			http.Redirect(w, r, fmt.Sprintf("/loop?n=%d", hops), http.StatusFound)
+		default:
+			_, _ = io.WriteString(w, r.Header.Get("Authorization"))
+		}
+	}))
+	defer origin.Close()
+
+	get := func(client *http.Client, path string) (*http.Response, string, error) {
+		req, _ := http.NewRequest(http.MethodGet, origin.URL+path, nil)
+		req.Header.Set("Authorization", "Bearer token")
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, "", err
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body), nil
+	}
+
+	base := &http.Client{}
+	follow, noFollow := true, false
+
+	resp, _, err := get(ConfigureClient(base, &ClientOptions{FollowRedirects: &noFollow}), "/cross")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.Equal(t, target.URL, resp.Header.Get("Location"))
+	assert.Nil(t, base.CheckRedirect)
+
+	client := ConfigureClient(base, &ClientOptions{FollowRedirects: &follow, MaxRedirects: 3})
+	_, body, err := get(client, "/cross")
+	assert.NoError(t, err)
+	assert.Equal(t, "target", body)
+	assert.Empty(t, authOnTarget)
+
+	_, body, err = get(client, "/same")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token", body)
+
+	_, _, err = get(client, "/loop")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "stopped after 3 redirects")
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// AcceptEncoding lists the content codings the tools can decode.
+const AcceptEncoding = "gzip, deflate"
+
+// SetAcceptEncoding advertises the content codings the tools can decode.
+// It is meant to be called before the configured and per-request headers are
+// applied, so that they can still override it.
+func SetAcceptEncoding(req *http.Request) {
+	req.Header.Set("Accept-Encoding", AcceptEncoding)
+}
+
+// decodeBody wraps the body of resp with the decoder matching its
+// Content-Encoding. Bodies already decoded by the transport, which then drops
+// the header, and identity bodies are returned as is.
+func decodeBody(resp *http.Response) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "", "identity":
+		return resp.Body, nil
+	case "gzip", "x-gzip":
+		r, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode gzip body: %w", err)
+		}
+		return r, nil
+	case "deflate":
+		// "deflate" should be zlib-wrapped, but some servers send raw deflate data.
+		br := bufio.NewReader(resp.Body)
+		header, err := br.Peek(2)
+		if err == nil && isZlibHeader(header) {
+			r, err := zlib.NewReader(br)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode deflate body: %w", err)
+			}
+			return r, nil
+		}
+		return flate.NewReader(br), nil
+	default:
+		return resp.Body, nil
+	}
+}
+
+func isZlibHeader(b []byte) bool {
+	return b[0]&0x0f == 8 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0
+}
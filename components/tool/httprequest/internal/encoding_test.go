@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadBody_Decoding(t *testing.T) {
+	const text = `{"message": "hello, compressed world"}`
+
+	var gz, zl, raw bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, _ = gw.Write([]byte(text))
+	_ = gw.Close()
+	zw := zlib.NewWriter(&zl)
+	_, _ = zw.Write([]byte(text))
+	_ = zw.Close()
+	fw, _ := flate.NewWriter(&raw, flate.DefaultCompression)
+	_, _ = fw.Write([]byte(text))
+	_ = fw.Close()
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{name: "identity", encoding: "", body: []byte(text)},
+		{name: "gzip", encoding: "gzip", body: gz.Bytes()},
+		{name: "zlib deflate", encoding: "deflate", body: zl.Bytes()},
+		{name: "raw deflate", encoding: "Deflate", body: raw.Bytes()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				Header: http.Header{"Content-Encoding": []string{tt.encoding}},
+				Body:   io.NopCloser(bytes.NewReader(tt.body)),
+			}
+			body, truncated, err := ReadBody(resp, 0)
+			assert.NoError(t, err)
+			assert.False(t, truncated)
+			assert.Equal(t, text, string(body))
+		})
+	}
+}
+
+func TestReadBody_LimitAppliesToDecodedBody(t *testing.T) {
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, _ = gw.Write([]byte(strings.Repeat("a", 1<<20)))
+	_ = gw.Close()
+
+	resp := &http.Response{
+		Header: http.Header{"Content-Encoding": []string{"gzip"}},
+		Body:   io.NopCloser(&gz),
+	}
+	body, truncated, err := ReadBody(resp, 16)
+	assert.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Equal(t, strings.Repeat("a", 16), string(body))
+}
+
+func TestReadBody_InvalidGzip(t *testing.T) {
+	resp := &http.Response{
+		Header: http.Header{"Content-Encoding": []string{"gzip"}},
+		Body:   io.NopCloser(strings.NewReader("not gzip")),
+	}
+	_, _, err := ReadBody(resp, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode gzip body")
+}
@@ -0,0 +1,242 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultDeniedCIDRs are the ranges always blocked once a denylist is
+// configured: loopback, private, carrier-grade NAT, link-local (which holds
+// the cloud metadata endpoints such as 169.254.169.254) and unspecified
+// addresses.
+var DefaultDeniedCIDRs = []string{
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+}
+
+// HostGuard checks the target of every request against an allowlist of host
+// names and a denylist of IP ranges, to protect against SSRF.
+type HostGuard struct {
+	allowedHosts []string
+	deniedNets   []*net.IPNet
+	lookupIP     func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// NewHostGuard builds a guard from the configured allowlist and denylist. It
+// returns nil when both are empty, meaning every target is allowed. When
+// deniedCIDRs is not empty, DefaultDeniedCIDRs are blocked as well.
+//
+// An allowed host is either an exact host name, such as "api.example.com", or
+// a wildcard matching any subdomain, such as "*.example.com".
+func NewHostGuard(allowedHosts, deniedCIDRs []string) (*HostGuard, error) {
+	if len(allowedHosts) == 0 && len(deniedCIDRs) == 0 {
+		return nil, nil
+	}
+
+	g := &HostGuard{lookupIP: net.DefaultResolver.LookupIPAddr}
+	for _, host := range allowedHosts {
+		g.allowedHosts = append(g.allowedHosts, strings.ToLower(strings.TrimSpace(host)))
+	}
+	if len(deniedCIDRs) > 0 {
+		for _, cidr := range append(append([]string{}, DefaultDeniedCIDRs...), deniedCIDRs...) {
+			_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+			if err != nil {
+				return nil, fmt.Errorf("invalid denied CIDR %q: %w", cidr, err)
+			}
+			g.deniedNets = append(g.deniedNets, ipNet)
+		}
+	}
+	return g, nil
+}
+
+// Check validates the target host of req, resolving it to check the
+// addresses it points to against the denylist.
+//
+// Check alone cannot stop DNS rebinding, where the name resolves to an
+// allowed address here and to a denied one when the connection is made;
+// guardTransport therefore checks the dialed address again.
+func (g *HostGuard) Check(req *http.Request) error {
+	host := strings.ToLower(req.URL.Hostname())
+	if host == "" {
+		return fmt.Errorf("request has no target host")
+	}
+	if len(g.allowedHosts) > 0 && !g.isAllowed(host) {
+		return fmt.Errorf("host %q is not in the allowed hosts", host)
+	}
+	if len(g.deniedNets) == 0 {
+		return nil
+	}
+
+	_, err := g.resolve(req.Context(), host)
+	return err
+}
+
+// resolve returns the addresses of host, failing if any of them is denied.
+func (g *HostGuard) resolve(ctx context.Context, host string) ([]net.IP, error) {
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := g.lookupIP(ctx, host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve host %q: %w", host, err)
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+
+	for _, ip := range ips {
+		if err := g.checkIP(host, ip); err != nil {
+			return nil, err
+		}
+	}
+	return ips, nil
+}
+
+func (g *HostGuard) checkIP(host string, ip net.IP) error {
+	for _, denied := range g.deniedNets {
+		if denied.Contains(ip) {
+			return fmt.Errorf("host %q resolves to denied address %s", host, ip)
+		}
+	}
+	return nil
+}
+
+// guardTransport makes rt check the denylist against the address it actually
+// connects to. The standard dialer is replaced by one resolving the host with
+// the guard and dialing the checked addresses directly; a custom DialContext
+// is kept, and its connection is closed if the remote address is denied.
+//
+// rt is returned as is when it is not an *http.Transport or when it sends
+// requests through a proxy, since the proxy then resolves the target host
+// and only Check applies.
+func (g *HostGuard) guardTransport(rt http.RoundTripper) http.RoundTripper {
+	t, ok := rt.(*http.Transport)
+	if !ok || t.Proxy != nil || len(g.deniedNets) == 0 {
+		return rt
+	}
+
+	t = t.Clone()
+	switch {
+	case t.DialContext != nil:
+		t.DialContext = g.checkConn(t.DialContext)
+	case t.Dial != nil:
+		dial := t.Dial
+		t.Dial = nil
+		t.DialContext = g.checkConn(func(_ context.Context, network, addr string) (net.Conn, error) {
+			return dial(network, addr)
+		})
+	default:
+		t.DialContext = g.dialContext(&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second})
+	}
+	if t.DialTLSContext != nil {
+		t.DialTLSContext = g.checkConn(t.DialTLSContext)
+	}
+	return t
+}
+
+// dialContext resolves the host of addr, rejects it if any of its addresses
+// is denied, and connects to the first address that accepts the connection.
+func (g *HostGuard) dialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		ips, err := g.resolve(ctx, strings.ToLower(host))
+		if err != nil {
+			return nil, err
+		}
+
+		var lastErr error
+		for _, ip := range ips {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		if lastErr == nil {
+			lastErr = fmt.Errorf("host %q has no addresses", host)
+		}
+		return nil, lastErr
+	}
+}
+
+// checkConn wraps dial so that connections to a denied remote address are
+// closed before anything is sent on them.
+func (g *HostGuard) checkConn(dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		if tcpAddr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+			host, _, _ := net.SplitHostPort(addr)
+			if err := g.checkIP(host, tcpAddr.IP); err != nil {
+				_ = conn.Close()
+				return nil, err
+			}
+		}
+		return conn, nil
+	}
+}
+
+func (g *HostGuard) isAllowed(host string) bool {
+	for _, allowed := range g.allowedHosts {
+		if allowed == host {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok && strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// guardedTransport checks every outgoing request, including the ones issued
+// while following redirects, before handing it to the wrapped transport.
+type guardedTransport struct {
+	guard *HostGuard
+	next  http.RoundTripper
+}
+
+func (t *guardedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.guard.Check(req); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHostGuard(t *testing.T) {
+	g, err := NewHostGuard(nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, g)
+
+	_, err = NewHostGuard(nil, []string{"not-a-cidr"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid denied CIDR "not-a-cidr"`)
+}
+
+func TestHostGuard_Check(t *testing.T) {
+	g, err := NewHostGuard([]string{"api.example.com", "*.example.org", "169.254.169.254", "rebind.example.org"}, []string{"203.0.113.0/24"})
+	assert.NoError(t, err)
+	g.lookupIP = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		switch host {
+		case "api.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+		case "rebind.example.org":
+			return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}, {IP: net.ParseIP("10.0.0.8")}}, nil
+		case "cdn.example.org":
+			return []net.IPAddr{{IP: net.ParseIP("203.0.113.7")}}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	tests := []struct {
+		url string
+		err string
+	}{
+		{url: "https://api.example.com/v1"},
+		{url: "https://evil.example.com/", err: `host "evil.example.com" is not in the allowed hosts`},
+		{url: "http://169.254.169.254/latest/meta-data", err: "resolves to denied address 169.254.169.254"},
+		{url: "https://rebind.example.org/", err: "resolves to denied address 10.0.0.8"},
+		{url: "https://cdn.example.org/", err: "resolves to denied address 203.0.113.7"},
+		{url: "https://www.example.org/", err: `failed to resolve host "www.example.org"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			err := g.Check(req)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestHostGuard_DenylistOnly(t *testing.T) {
+	g, err := NewHostGuard(nil, []string{"198.51.100.0/24"})
+	assert.NoError(t, err)
+
+	for _, target := range []string{"http://127.0.0.1:8080", "http://[::1]/", "http://192.168.1.1", "http://198.51.100.1"} {
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		assert.Error(t, g.Check(req), target)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://93.184.216.34", nil)
+	assert.NoError(t, g.Check(req))
+}
+
+func TestHostGuard_DNSRebinding(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	g, err := NewHostGuard(nil, []string{"203.0.113.0/24"})
+	assert.NoError(t, err)
+	// the first lookup, made by Check, sees a public address; the one made when dialing sees loopback
+	var lookups int
+	g.lookupIP = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		lookups++
+		if lookups == 1 {
+			return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+		}
+		return []net.IPAddr{{IP: net.ParseIP("127.0.0.1")}}, nil
+	}
+
+	client := ConfigureClient(NewDefaultClient(), &ClientOptions{HostGuard: g})
+	_, err = client.Get("http://rebind.example.com:" + port + "/")
+	assert.ErrorContains(t, err, "resolves to denied address 127.0.0.1")
+	assert.Equal(t, 2, lookups)
+	assert.Equal(t, 0, hits)
+}
+
+func TestHostGuard_CustomDialer(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer server.Close()
+
+	g, err := NewHostGuard(nil, []string{"203.0.113.0/24"})
+	assert.NoError(t, err)
+	g.lookupIP = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("93.184.216.34")}}, nil
+	}
+
+	// a custom dialer sending every connection to the loopback server is caught by its remote address
+	transport := &http.Transport{DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}}
+	client := ConfigureClient(&http.Client{Transport: transport}, &ClientOptions{HostGuard: g})
+	_, err = client.Get("http://api.example.com/")
+	assert.ErrorContains(t, err, "resolves to denied address 127.0.0.1")
+	assert.Equal(t, 0, hits)
+
+	// transports using a proxy are left to the proxy, which resolves the target itself
+	proxied := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	assert.Same(t, proxied, g.guardTransport(proxied))
+}
+
+func TestHostGuard_DefaultTransport(t *testing.T) {
+	g, err := NewHostGuard(nil, []string{"203.0.113.0/24"})
+	assert.NoError(t, err)
+
+	// a client without a transport would use http.DefaultTransport, whose proxy from the
+	// environment would bypass the dial-time check, so it gets a direct copy instead
+	client := ConfigureClient(&http.Client{}, &ClientOptions{HostGuard: g})
+	guarded, ok := client.Transport.(*guardedTransport)
+	assert.True(t, ok)
+	next, ok := guarded.next.(*http.Transport)
+	assert.True(t, ok)
+	assert.Nil(t, next.Proxy)
+	assert.NotNil(t, next.DialContext)
+	assert.NotNil(t, http.DefaultTransport.(*http.Transport).Proxy)
+}
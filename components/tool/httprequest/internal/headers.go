@@ -0,0 +1,41 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import "net/http"
+
+const (
+	// DefaultUserAgent is sent when a tool is not configured with a User-Agent,
+	// in place of the Go default that some APIs reject as a bot.
+	DefaultUserAgent = "eino-ext-httprequest/1.0"
+
+	// DefaultAccept asks for JSON responses unless the request says otherwise.
+	DefaultAccept = "application/json"
+)
+
+// SetDefaultHeaders sets the User-Agent, Accept and Accept-Encoding headers sent
+// with every request. Like SetAcceptEncoding, it is meant to be called before
+// the configured and per-request headers are applied, so that they can still
+// override the defaults.
+func SetDefaultHeaders(req *http.Request, userAgent string) {
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", DefaultAccept)
+	SetAcceptEncoding(req)
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// ExtractJSON selects the subtree of the JSON document body addressed by
+// path. The path is a simple dot-path with optional array indexes, with or
+// without a leading "$", e.g. "$.data.items[0].name" or "data.items.0.name".
+// String values are returned unquoted, anything else is returned as JSON.
+// ok is false when body is not JSON or the path does not match.
+func ExtractJSON(body []byte, path string) (extracted string, ok bool) {
+	segments, ok := parsePath(path)
+	if !ok {
+		return "", false
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var node any
+	if err := dec.Decode(&node); err != nil {
+		return "", false
+	}
+
+	for _, seg := range segments {
+		switch v := node.(type) {
+		case map[string]any:
+			child, found := v[seg]
+			if !found {
+				return "", false
+			}
+			node = child
+		case []any:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return "", false
+			}
+			node = v[idx]
+		default:
+			return "", false
+		}
+	}
+
+	if s, isString := node.(string); isString {
+		return s, true
+	}
+	out, err := json.Marshal(node)
+	if err != nil {
+		return "", false
+	}
+	return string(out), true
+}
+
+func parsePath(path string) ([]string, bool) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+	path = strings.ReplaceAll(path, "[", ".")
+	path = strings.ReplaceAll(path, "]", "")
+	path = strings.TrimPrefix(path, ".")
+	if path == "" {
+		return nil, true
+	}
+
+	segments := strings.Split(path, ".")
+	for _, seg := range segments {
+		if seg == "" {
+			return nil, false
+		}
+	}
+	return segments, true
+}
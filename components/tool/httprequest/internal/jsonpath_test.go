@@ -0,0 +1,52 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractJSON(t *testing.T) {
+	body := []byte(`{"data": {"items": [{"name": "a", "id": 12345678901234567890}, {"name": "b", "tags": ["x"]}]}}`)
+
+	tests := []struct {
+		path     string
+		expected string
+		ok       bool
+	}{
+		{path: "data.items[0].name", expected: "a", ok: true},
+		{path: "$.data.items.1.tags", expected: `["x"]`, ok: true},
+		{path: "data.items[0].id", expected: "12345678901234567890", ok: true},
+		{path: "$.data.items[1]", expected: `{"name":"b","tags":["x"]}`, ok: true},
+		{path: "$", expected: `{"data":{"items":[{"id":12345678901234567890,"name":"a"},{"name":"b","tags":["x"]}]}}`, ok: true},
+		{path: "data.missing", ok: false},
+		{path: "data.items[5]", ok: false},
+		{path: "data..items", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			extracted, ok := ExtractJSON(body, tt.path)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, extracted)
+		})
+	}
+
+	_, ok := ExtractJSON([]byte("not json"), "data")
+	assert.False(t, ok)
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Response is the JSON envelope returned to the model when the tool is
+// configured to report the HTTP status code or response headers alongside
+// the body.
+type Response struct {
+	Status    int               `json:"status"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Body      string            `json:"body"`
+	Truncated bool              `json:"truncated,omitempty"`
+	Note      string            `json:"note,omitempty"`
+
+	// plain marks a body replaced by a JSON string value selected by ExtractPath.
+	plain bool
+}
+
+// ExtractPath replaces the body with the JSON subtree addressed by path, see
+// ExtractJSON. When the path does not match, the full body is kept and a note
+// explaining why is attached. An empty path leaves the response untouched.
+func (r *Response) ExtractPath(path string) {
+	if path == "" {
+		return
+	}
+	if extracted, ok := ExtractJSON([]byte(r.Body), path); ok {
+		r.Body = extracted
+		r.plain = !json.Valid([]byte(extracted))
+		return
+	}
+	r.addNote(fmt.Sprintf("response_path %q did not match the response, the full body is returned", path))
+}
+
+// FormatJSON validates the body as JSON and indents it for readability. A body
+// that is not valid JSON is kept as is and a note with the parse error is
+// attached. Empty bodies and plain strings selected by ExtractPath are left
+// untouched.
+func (r *Response) FormatJSON() {
+	if r.plain || strings.TrimSpace(r.Body) == "" {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(r.Body), "", "  "); err != nil {
+		r.addNote(fmt.Sprintf("response is not valid JSON: %v", err))
+		return
+	}
+	r.Body = buf.String()
+}
+
+func (r *Response) addNote(note string) {
+	if r.Note == "" {
+		r.Note = note
+		return
+	}
+	r.Note += "; " + note
+}
+
+// SelectHeaders picks the named headers from h. Multiple values of the same
+// header are joined with ", ", and headers absent from h are left out.
+func SelectHeaders(h http.Header, names []string) map[string]string {
+	if len(names) == 0 {
+		return nil
+	}
+
+	selected := make(map[string]string, len(names))
+	for _, name := range names {
+		if values := h.Values(name); len(values) > 0 {
+			selected[http.CanonicalHeaderKey(name)] = strings.Join(values, ", ")
+		}
+	}
+	return selected
+}
+
+// ReadBody reads the body of resp, decoding it according to its
+// Content-Encoding, and stops after maxBytes of decoded data. A non-positive
+// maxBytes reads the whole body. truncated reports whether the decoded body
+// held more than maxBytes.
+func ReadBody(resp *http.Response, maxBytes int64) (body []byte, truncated bool, err error) {
+	r, err := decodeBody(resp)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if maxBytes <= 0 {
+		body, err = io.ReadAll(r)
+		return body, false, err
+	}
+
+	body, err = io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(len(body)) > maxBytes {
+		return body[:maxBytes], true, nil
+	}
+	return body, false, nil
+}
+
+// FormatOutput renders the tool output. When envelope is false the raw body is
+// returned unchanged, keeping the original plain-text behaviour, except for the
+// notes about path extraction and truncation.
+func FormatOutput(resp *Response, envelope bool) (string, error) {
+	if !envelope {
+		out := resp.Body
+		if resp.Note != "" {
+			out = fmt.Sprintf("[%s]\n", resp.Note) + out
+		}
+		if resp.Truncated {
+			out += fmt.Sprintf("\n[response truncated to %d bytes]", len(resp.Body))
+		}
+		return out, nil
+	}
+
+	out, err := json.Marshal(resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return string(out), nil
+}
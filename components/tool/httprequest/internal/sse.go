@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// SSEDone is the data of the event that OpenAI-style APIs send to mark the end
+// of a Server-Sent Events stream.
+const SSEDone = "[DONE]"
+
+// SetAcceptEventStream asks the server for a Server-Sent Events stream. Like
+// SetAcceptEncoding, it is meant to be called before the configured and
+// per-request headers are applied.
+func SetAcceptEventStream(req *http.Request) {
+	req.Header.Set("Accept", "text/event-stream")
+}
+
+// StreamSSE parses the decoded body of resp as Server-Sent Events and emits
+// the data of each event. The data lines of an event are joined with "\n",
+// comment lines and fields other than data are skipped. The stream ends at an
+// event whose data is SSEDone or at the end of the body, and the body is closed
+// once the stream ends, reading it fails, ctx is done or the reader closes the
+// stream.
+func StreamSSE(ctx context.Context, resp *http.Response) (*schema.StreamReader[string], error) {
+	r, err := decodeBody(resp)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	sr, sw := schema.Pipe[string](1)
+	go func() {
+		defer sw.Close()
+		defer resp.Body.Close()
+
+		br := bufio.NewReader(r)
+		var data []string
+		// dispatch emits the buffered event, reporting whether the stream is over.
+		dispatch := func() bool {
+			if data == nil {
+				return false
+			}
+			event := strings.Join(data, "\n")
+			data = nil
+			if event == "" {
+				// A single empty data line carries no event.
+				return false
+			}
+			if event == SSEDone {
+				return true
+			}
+			return sw.Send(event, nil)
+		}
+
+		for {
+			line, err := br.ReadString('\n')
+			if line != "" {
+				line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+				switch {
+				case line == "":
+					if dispatch() {
+						return
+					}
+				case strings.HasPrefix(line, ":"):
+					// A comment, typically sent as a keep-alive.
+				default:
+					field, value, _ := strings.Cut(line, ":")
+					if field == "data" {
+						data = append(data, strings.TrimPrefix(value, " "))
+					}
+				}
+			}
+
+			if err == io.EOF {
+				dispatch()
+				return
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					err = ctx.Err()
+				}
+				sw.Send("", fmt.Errorf("failed to read event stream: %w", err))
+				return
+			}
+		}
+	}()
+
+	return sr, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collectEvents(t *testing.T, body string) []string {
+	resp := &http.Response{Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+	sr, err := StreamSSE(context.Background(), resp)
+	assert.NoError(t, err)
+	defer sr.Close()
+
+	var events []string
+	for {
+		event, err := sr.Recv()
+		if err == io.EOF {
+			return events
+		}
+		assert.NoError(t, err)
+		events = append(events, event)
+	}
+}
+
+func TestStreamSSE(t *testing.T) {
+	t.Run("multi-line data and comments", func(t *testing.T) {
+		body := ": keep-alive\n" +
+			"event: message\nid: 1\ndata: first\n\n" +
+			"data: second\ndata:  indented\r\n\r\n" +
+			"data\n\n" +
+			"retry: 1000\n\n"
+		assert.Equal(t, []string{"first", "second\n indented"}, collectEvents(t, body))
+	})
+
+	t.Run("ends at done", func(t *testing.T) {
+		body := "data: {\"n\":1}\n\ndata: [DONE]\n\ndata: ignored\n\n"
+		assert.Equal(t, []string{`{"n":1}`}, collectEvents(t, body))
+	})
+
+	t.Run("ends at eof", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b"}, collectEvents(t, "data: a\n\ndata: b"))
+	})
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// DefaultStreamChunkSize is the size of the chunks read from a streamed
+// response body when no chunk size is configured.
+const DefaultStreamChunkSize = 4096
+
+// StreamBody delivers the decoded body of resp as a stream of chunks of at
+// most chunkSize bytes, see ReadBody for maxBytes. Chunks never split a UTF-8
+// encoded character. The body is closed once it is exhausted, reading it fails,
+// ctx is done or the reader closes the stream. A body cut at maxBytes ends with
+// a truncation note, like the buffered output.
+func StreamBody(ctx context.Context, resp *http.Response, chunkSize int, maxBytes int64) (*schema.StreamReader[string], error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultStreamChunkSize
+	}
+
+	r, err := decodeBody(resp)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if maxBytes > 0 {
+		r = io.LimitReader(r, maxBytes+1)
+	}
+
+	sr, sw := schema.Pipe[string](1)
+	go func() {
+		defer sw.Close()
+		defer resp.Body.Close()
+
+		buf := make([]byte, chunkSize+utf8.UTFMax)
+		pending := 0
+		var total int64
+		for {
+			if ctx.Err() != nil {
+				sw.Send("", ctx.Err())
+				return
+			}
+
+			n, err := r.Read(buf[pending : pending+chunkSize])
+			n += pending
+			pending = 0
+
+			truncated := false
+			if maxBytes > 0 && total+int64(n) > maxBytes {
+				n = int(maxBytes - total)
+				truncated = true
+			}
+
+			// Hold back a trailing partial character until the rest of it is read.
+			end := n
+			if err == nil && !truncated {
+				end = completeUTF8(buf[:n])
+			}
+			if end > 0 {
+				if closed := sw.Send(string(buf[:end]), nil); closed {
+					return
+				}
+				total += int64(end)
+			}
+			pending = copy(buf, buf[end:n])
+
+			if truncated {
+				sw.Send(fmt.Sprintf("\n[response truncated to %d bytes]", maxBytes), nil)
+				return
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					err = ctx.Err()
+				}
+				sw.Send("", fmt.Errorf("failed to read response body: %w", err))
+				return
+			}
+		}
+	}()
+
+	return sr, nil
+}
+
+// completeUTF8 returns the length of the longest prefix of b that does not end
+// in the middle of a UTF-8 encoded character.
+func completeUTF8(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if utf8.FullRune(b[i:]) {
+				return len(b)
+			}
+			return i
+		}
+	}
+	return len(b)
+}
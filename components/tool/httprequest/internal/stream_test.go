@@ -0,0 +1,85 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collectStream(t *testing.T, resp *http.Response, chunkSize int, maxBytes int64) []string {
+	sr, err := StreamBody(context.Background(), resp, chunkSize, maxBytes)
+	assert.NoError(t, err)
+	defer sr.Close()
+
+	var chunks []string
+	for {
+		chunk, err := sr.Recv()
+		if err == io.EOF {
+			return chunks
+		}
+		assert.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+}
+
+func TestStreamBody(t *testing.T) {
+	newResp := func(body string) *http.Response {
+		return &http.Response{Header: http.Header{}, Body: io.NopCloser(&oneByteReader{r: strings.NewReader(body)})}
+	}
+
+	t.Run("keeps characters whole", func(t *testing.T) {
+		const text = "héllo, 世界!"
+		chunks := collectStream(t, newResp(text), 2, 0)
+		for _, chunk := range chunks {
+			assert.True(t, utf8.ValidString(chunk), chunk)
+		}
+		assert.Equal(t, text, strings.Join(chunks, ""))
+	})
+
+	t.Run("truncates at max bytes", func(t *testing.T) {
+		chunks := collectStream(t, newResp("0123456789"), 3, 5)
+		assert.Equal(t, "01234\n[response truncated to 5 bytes]", strings.Join(chunks, ""))
+	})
+
+	t.Run("default chunk size", func(t *testing.T) {
+		text := strings.Repeat("a", DefaultStreamChunkSize+1)
+		resp := &http.Response{Header: http.Header{}, Body: io.NopCloser(strings.NewReader(text))}
+		chunks := collectStream(t, resp, 0, 0)
+		assert.Len(t, chunks, 2)
+		assert.Equal(t, text, strings.Join(chunks, ""))
+	})
+}
+
+// oneByteReader returns a single byte per Read, so that multi-byte characters
+// are split across reads.
+type oneByteReader struct {
+	r io.Reader
+}
+
+func (o *oneByteReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return o.r.Read(p[:1])
+}
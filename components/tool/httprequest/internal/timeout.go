@@ -0,0 +1,36 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WithTimeout derives a context that expires after the given number of
+// seconds. A zero value leaves ctx untouched, a negative one is rejected.
+func WithTimeout(ctx context.Context, seconds int) (context.Context, context.CancelFunc, error) {
+	if seconds < 0 {
+		return nil, nil, fmt.Errorf("timeout_seconds must be positive, got %d", seconds)
+	}
+	if seconds == 0 {
+		return ctx, func() {}, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(seconds)*time.Second)
+	return ctx, cancel, nil
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"strings"
 	"testing"
@@ -174,7 +175,7 @@ func TestNewTool_Config(t *testing.T) {
 
 		doc, err := info.ParamsOneOf.ToJSONSchema()
 		assert.Nil(t, err)
-		assert.Equal(t, 2, doc.Properties.Len())
+		assert.Equal(t, 8, doc.Properties.Len())
 		for pair := doc.Properties.Oldest(); pair != nil; pair = pair.Next() {
 			assert.NotEqual(t, "", pair.Value.Description)
 		}
@@ -209,3 +210,398 @@ func TestPost_WithHeaders(t *testing.T) {
 	assert.Equal(t, "Bearer token", receivedHeaders.Get("Authorization"))
 	assert.Equal(t, "test-agent", receivedHeaders.Get("User-Agent"))
 }
+
+func TestPost_IncludeStatus(t *testing.T) {
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       io.NopCloser(strings.NewReader(`{"error": "boom"}`)),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PostRequestTool{
+		config: &Config{
+			Headers:       make(map[string]string),
+			IncludeStatus: true,
+		},
+		client: client,
+	}
+
+	req := &PostRequest{URL: "https://example.com/resource", Body: `{"key":"value"}`}
+	result, err := tool.Post(context.Background(), req)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status": 500, "body": "{\"error\": \"boom\"}"}`, result)
+}
+
+func TestPost_WithParams(t *testing.T) {
+	var receivedURL string
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			receivedURL = req.URL.String()
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PostRequestTool{
+		config: &Config{
+			Headers: make(map[string]string),
+		},
+		client: client,
+	}
+
+	req := &PostRequest{
+		URL:  "https://example.com/resource?keep=1",
+		Body: `{"key":"value"}`,
+		Params: map[string]string{
+			"q":    "hello world&more",
+			"lang": "zh/cn",
+		},
+	}
+	_, err := tool.Post(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/resource?keep=1&lang=zh%2Fcn&q=hello+world%26more", receivedURL)
+}
+
+func TestPost_RequestHeadersOverrideConfig(t *testing.T) {
+	var receivedHeaders http.Header
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			receivedHeaders = req.Header
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PostRequestTool{
+		config: &Config{
+			Headers: map[string]string{
+				"Authorization": "Bearer config-token",
+				"User-Agent":    "test-agent",
+			},
+		},
+		client: client,
+	}
+
+	req := &PostRequest{
+		URL:  "https://example.com/resource",
+		Body: `{"key":"value"}`,
+		Headers: map[string]string{
+			"Authorization": "Bearer request-token",
+			"Content-Type":  "application/json",
+		},
+	}
+	_, err := tool.Post(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer request-token", receivedHeaders.Get("Authorization"))
+	assert.Equal(t, "application/json", receivedHeaders.Get("Content-Type"))
+	assert.Equal(t, "test-agent", receivedHeaders.Get("User-Agent"))
+}
+
+func TestPost_MaxResponseBytes(t *testing.T) {
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(strings.Repeat("a", 100))),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PostRequestTool{
+		config: &Config{
+			Headers:          make(map[string]string),
+			MaxResponseBytes: 10,
+		},
+		client: client,
+	}
+
+	req := &PostRequest{URL: "https://example.com/resource",
+		Body: `{"key":"value"}`,
+	}
+	result, err := tool.Post(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", 10)+"\n[response truncated to 10 bytes]", result)
+
+	tool.config.IncludeStatus = true
+	result, err = tool.Post(context.Background(), req)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status": 200, "body": "aaaaaaaaaa", "truncated": true}`, result)
+}
+
+func TestPost_Retry(t *testing.T) {
+	calls := 0
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != `{"key":"value"}` {
+				return nil, fmt.Errorf("unexpected body")
+			}
+			if calls == 1 {
+				return nil, fmt.Errorf("network error")
+			}
+			if calls == 2 {
+				return &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Header:     http.Header{"Retry-After": []string{"0"}},
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("ok")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	req := &PostRequest{URL: "https://example.com/resource", Body: `{"key":"value"}`}
+
+	tool := &PostRequestTool{
+		config: &Config{
+			Headers:      make(map[string]string),
+			MaxRetries:   2,
+			RetryBackoff: time.Millisecond,
+		},
+		client: client,
+	}
+	_, err := tool.Post(context.Background(), req)
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	tool.config.AllowRetry = true
+	result, err := tool.Post(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, 3, calls)
+}
+
+func TestPost_TimeoutSeconds(t *testing.T) {
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(5 * time.Second):
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			}
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PostRequestTool{
+		config: &Config{
+			Headers: make(map[string]string),
+		},
+		client: client,
+	}
+
+	start := time.Now()
+	req := &PostRequest{URL: "https://example.com/resource", Body: `{"key":"value"}`, TimeoutSeconds: 1}
+	_, err := tool.Post(context.Background(), req)
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 3*time.Second)
+
+	req.TimeoutSeconds = -1
+	_, err = tool.Post(context.Background(), req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "timeout_seconds must be positive")
+}
+
+func TestPost_Form(t *testing.T) {
+	var contentType, body string
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			contentType = req.Header.Get("Content-Type")
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PostRequestTool{
+		config: &Config{
+			Headers: map[string]string{"Content-Type": "application/json"},
+		},
+		client: client,
+	}
+
+	req := &PostRequest{
+		URL:  "https://example.com/resource",
+		Form: map[string]string{"name": "eino ext", "lang": "go&rust"},
+	}
+	_, err := tool.Post(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/x-www-form-urlencoded", contentType)
+	assert.Equal(t, "lang=go%26rust&name=eino+ext", body)
+
+	req.Body = `{"key":"value"}`
+	_, err = tool.Post(context.Background(), req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to encode request body")
+}
+
+func TestPost_Multipart(t *testing.T) {
+	var form *multipart.Form
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if err := req.ParseMultipartForm(1 << 20); err != nil {
+				return nil, err
+			}
+			form = req.MultipartForm
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PostRequestTool{
+		config: &Config{
+			Headers: make(map[string]string),
+		},
+		client: client,
+	}
+
+	req := &PostRequest{
+		URL:  "https://example.com/resource",
+		Form: map[string]string{"description": "report"},
+		Files: []*FormFile{
+			{Field: "file", FileName: "report.txt", Content: "hello"},
+		},
+	}
+	_, err := tool.Post(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"report"}, form.Value["description"])
+	assert.Len(t, form.File["file"], 1)
+	assert.Equal(t, "report.txt", form.File["file"][0].Filename)
+
+	f, err := form.File["file"][0].Open()
+	assert.NoError(t, err)
+	content, _ := io.ReadAll(f)
+	assert.Equal(t, "hello", string(content))
+}
+
+func TestPost_ResponseHeaders(t *testing.T) {
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Header: http.Header{
+					"Location":     []string{"https://example.com/resource/1"},
+					"Link":         []string{"<https://example.com/resource?page=2>; rel=\"next\""},
+					"Content-Type": []string{"application/json"},
+				},
+				Body: io.NopCloser(strings.NewReader(`{"id":1}`)),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PostRequestTool{
+		config: &Config{
+			Headers:         make(map[string]string),
+			ResponseHeaders: []string{"location", "Link", "X-Missing"},
+		},
+		client: client,
+	}
+
+	req := &PostRequest{URL: "https://example.com/resource", Body: `{"key":"value"}`}
+	result, err := tool.Post(context.Background(), req)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"status": 201,
+		"headers": {
+			"Location": "https://example.com/resource/1",
+			"Link": "<https://example.com/resource?page=2>; rel=\"next\""
+		},
+		"body": "{\"id\":1}"
+	}`, result)
+}
+
+func TestPost_Auth(t *testing.T) {
+	var authorization string
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			authorization = req.Header.Get("Authorization")
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PostRequestTool{
+		config: &Config{
+			Headers:   make(map[string]string),
+			BasicAuth: &BasicAuth{User: "user", Pass: "pass"},
+		},
+		client: client,
+	}
+
+	req := &PostRequest{URL: "https://example.com/resource", Body: `{"key":"value"}`}
+	_, err := tool.Post(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", authorization)
+
+	tool.config.BearerToken = "token"
+	_, err = tool.Post(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token", authorization)
+}
+
+func TestPost_StreamSSE(t *testing.T) {
+	var accept, body string
+	client := &http.Client{
+		Transport: &mockTransport{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				accept = req.Header.Get("Accept")
+				b, _ := io.ReadAll(req.Body)
+				body = string(b)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+					Body:       io.NopCloser(strings.NewReader("data: {\"delta\":\"Hi\"}\n\n: comment\ndata: {\"delta\":\"!\"}\n\ndata: [DONE]\n\n")),
+				}, nil
+			},
+		},
+	}
+
+	tool, err := newRequestTool(&Config{HttpClient: client, SSE: true})
+	assert.NoError(t, err)
+
+	sr, err := tool.PostStream(context.Background(), &PostStreamRequest{URL: "http://example.com/chat", Body: `{"stream":true}`})
+	assert.NoError(t, err)
+	defer sr.Close()
+
+	var events []string
+	for {
+		event, err := sr.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		events = append(events, event)
+	}
+	assert.Equal(t, "text/event-stream", accept)
+	assert.Equal(t, `{"stream":true}`, body)
+	assert.Equal(t, []string{`{"delta":"Hi"}`, `{"delta":"!"}`}, events)
+
+	st, err := NewStreamTool(context.Background(), &Config{})
+	assert.NoError(t, err)
+	info, err := st.Info(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "requests_post_stream", info.Name)
+}
@@ -19,37 +19,98 @@ package put
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/internal"
 )
 
 type PutRequest struct {
-	URL  string `json:"url" jsonschema_description:"The URL to make the PUT request"`
-	Body string `json:"body" jsonschema_description:"The body to send in the PUT request"`
+	URL            string            `json:"url" jsonschema_description:"The URL to make the PUT request"`
+	Body           string            `json:"body" jsonschema_description:"The body to send in the PUT request"`
+	Params         map[string]string `json:"params,omitempty" jsonschema_description:"The query parameters to append to the URL, they will be URL-encoded"`
+	Headers        map[string]string `json:"headers,omitempty" jsonschema_description:"The HTTP headers to send with this request, they override the configured headers with the same name"`
+	TimeoutSeconds int               `json:"timeout_seconds,omitempty" jsonschema_description:"The maximum number of seconds to wait for this request, it must be positive when set"`
+	Form           map[string]string `json:"form,omitempty" jsonschema_description:"The form fields to send instead of body, they are URL-encoded unless files are also given"`
+	Files          []*FormFile       `json:"files,omitempty" jsonschema_description:"The files to upload as multipart/form-data instead of body, together with the form fields"`
+	ResponsePath   string            `json:"response_path,omitempty" jsonschema_description:"A dot-path such as data.items[0].name selecting the part of a JSON response to return, the full body is returned when it does not match"`
+}
+
+type FormFile struct {
+	Field    string `json:"field" jsonschema_description:"The name of the form field holding the file"`
+	FileName string `json:"file_name" jsonschema_description:"The name of the uploaded file"`
+	Content  string `json:"content" jsonschema_description:"The text content of the uploaded file"`
 }
 
 func (r *PutRequestTool) Put(ctx context.Context, req *PutRequest) (string, error) {
+	ctx, cancel, err := internal.WithTimeout(ctx, req.TimeoutSeconds)
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+
+	reqURL, err := internal.BuildURL(req.URL, req.Params)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request url: %w", err)
+	}
+
+	files := make([]internal.MultipartFile, 0, len(req.Files))
+	for _, file := range req.Files {
+		if file == nil {
+			continue
+		}
+		files = append(files, internal.MultipartFile{
+			Field:    file.Field,
+			FileName: file.FileName,
+			Content:  file.Content,
+		})
+	}
+	reqBody, contentType, err := internal.EncodeBody(req.Body, req.Form, files)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request body: %w", err)
+	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, req.URL, strings.NewReader(req.Body))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, reqURL, strings.NewReader(reqBody))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
+	internal.SetDefaultHeaders(httpReq, r.config.UserAgent)
 	for key, value := range r.config.Headers {
 		httpReq.Header.Set(key, value)
 	}
+	internal.SetAuth(httpReq, r.config.BearerToken, r.config.BasicAuth)
+	for key, value := range req.Headers {
+		httpReq.Header.Set(key, value)
+	}
+	if contentType != "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
 
-	resp, err := r.client.Do(httpReq)
+	resp, err := internal.Do(r.client, httpReq, internal.RetryPolicy{
+		MaxRetries: r.config.MaxRetries,
+		Backoff:    r.config.RetryBackoff,
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, truncated, err := internal.ReadBody(resp, r.config.MaxResponseBytes)
 	if err != nil {
 		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return string(body), nil
+	output := &internal.Response{
+		Status:    resp.StatusCode,
+		Headers:   internal.SelectHeaders(resp.Header, r.config.ResponseHeaders),
+		Body:      string(body),
+		Truncated: truncated,
+	}
+	output.ExtractPath(req.ResponsePath)
+	if r.config.ParseJSON {
+		output.FormatJSON()
+	}
+
+	return internal.FormatOutput(output, r.config.IncludeStatus || len(r.config.ResponseHeaders) > 0)
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"strings"
 	"testing"
@@ -175,7 +176,7 @@ func TestNewTool_Config(t *testing.T) {
 
 		doc, err := info.ParamsOneOf.ToJSONSchema()
 		assert.Nil(t, err)
-		assert.Equal(t, 2, doc.Properties.Len())
+		assert.Equal(t, 8, doc.Properties.Len())
 		for pair := doc.Properties.Oldest(); pair != nil; pair = pair.Next() {
 			assert.NotEqual(t, "", pair.Value.Description)
 		}
@@ -210,3 +211,250 @@ func TestPut_WithHeaders(t *testing.T) {
 	assert.Equal(t, "Bearer token", receivedHeaders.Get("Authorization"))
 	assert.Equal(t, "test-agent", receivedHeaders.Get("User-Agent"))
 }
+
+func TestPut_IncludeStatus(t *testing.T) {
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       io.NopCloser(strings.NewReader(`{"error": "boom"}`)),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PutRequestTool{
+		config: &Config{
+			Headers:       make(map[string]string),
+			IncludeStatus: true,
+		},
+		client: client,
+	}
+
+	req := &PutRequest{URL: "https://example.com/resource", Body: `{"key":"value"}`}
+	result, err := tool.Put(context.Background(), req)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status": 500, "body": "{\"error\": \"boom\"}"}`, result)
+}
+
+func TestPut_WithParams(t *testing.T) {
+	var receivedURL string
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			receivedURL = req.URL.String()
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PutRequestTool{
+		config: &Config{
+			Headers: make(map[string]string),
+		},
+		client: client,
+	}
+
+	req := &PutRequest{
+		URL:  "https://example.com/resource?keep=1",
+		Body: `{"key":"value"}`,
+		Params: map[string]string{
+			"q":    "hello world&more",
+			"lang": "zh/cn",
+		},
+	}
+	_, err := tool.Put(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/resource?keep=1&lang=zh%2Fcn&q=hello+world%26more", receivedURL)
+}
+
+func TestPut_RequestHeadersOverrideConfig(t *testing.T) {
+	var receivedHeaders http.Header
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			receivedHeaders = req.Header
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PutRequestTool{
+		config: &Config{
+			Headers: map[string]string{
+				"Authorization": "Bearer config-token",
+				"User-Agent":    "test-agent",
+			},
+		},
+		client: client,
+	}
+
+	req := &PutRequest{
+		URL:  "https://example.com/resource",
+		Body: `{"key":"value"}`,
+		Headers: map[string]string{
+			"Authorization": "Bearer request-token",
+			"Content-Type":  "application/json",
+		},
+	}
+	_, err := tool.Put(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer request-token", receivedHeaders.Get("Authorization"))
+	assert.Equal(t, "application/json", receivedHeaders.Get("Content-Type"))
+	assert.Equal(t, "test-agent", receivedHeaders.Get("User-Agent"))
+}
+
+func TestPut_Retry(t *testing.T) {
+	calls := 0
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			body, _ := io.ReadAll(req.Body)
+			if string(body) != `{"key":"value"}` {
+				return nil, fmt.Errorf("unexpected body")
+			}
+			if calls == 1 {
+				return nil, fmt.Errorf("network error")
+			}
+			if calls == 2 {
+				return &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Header:     http.Header{"Retry-After": []string{"0"}},
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("ok")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PutRequestTool{
+		config: &Config{
+			Headers:      make(map[string]string),
+			MaxRetries:   2,
+			RetryBackoff: time.Millisecond,
+		},
+		client: client,
+	}
+
+	req := &PutRequest{URL: "https://example.com/resource", Body: `{"key":"value"}`}
+	result, err := tool.Put(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, 3, calls)
+}
+
+func TestPut_TimeoutSeconds(t *testing.T) {
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(5 * time.Second):
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			}
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PutRequestTool{
+		config: &Config{
+			Headers: make(map[string]string),
+		},
+		client: client,
+	}
+
+	start := time.Now()
+	req := &PutRequest{URL: "https://example.com/resource", Body: `{"key":"value"}`, TimeoutSeconds: 1}
+	_, err := tool.Put(context.Background(), req)
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 3*time.Second)
+
+	req.TimeoutSeconds = -1
+	_, err = tool.Put(context.Background(), req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "timeout_seconds must be positive")
+}
+
+func TestPut_Form(t *testing.T) {
+	var contentType, body string
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			contentType = req.Header.Get("Content-Type")
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PutRequestTool{
+		config: &Config{
+			Headers: map[string]string{"Content-Type": "application/json"},
+		},
+		client: client,
+	}
+
+	req := &PutRequest{
+		URL:  "https://example.com/resource",
+		Form: map[string]string{"name": "eino ext", "lang": "go&rust"},
+	}
+	_, err := tool.Put(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/x-www-form-urlencoded", contentType)
+	assert.Equal(t, "lang=go%26rust&name=eino+ext", body)
+
+	req.Body = `{"key":"value"}`
+	_, err = tool.Put(context.Background(), req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to encode request body")
+}
+
+func TestPut_Multipart(t *testing.T) {
+	var form *multipart.Form
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if err := req.ParseMultipartForm(1 << 20); err != nil {
+				return nil, err
+			}
+			form = req.MultipartForm
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		},
+	}
+	client := &http.Client{Transport: mockTransport}
+	tool := &PutRequestTool{
+		config: &Config{
+			Headers: make(map[string]string),
+		},
+		client: client,
+	}
+
+	req := &PutRequest{
+		URL:  "https://example.com/resource",
+		Form: map[string]string{"description": "report"},
+		Files: []*FormFile{
+			{Field: "file", FileName: "report.txt", Content: "hello"},
+		},
+	}
+	_, err := tool.Put(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"report"}, form.Value["description"])
+	assert.Len(t, form.File["file"], 1)
+	assert.Equal(t, "report.txt", form.File["file"][0].Filename)
+
+	f, err := form.File["file"][0].Open()
+	assert.NoError(t, err)
+	content, _ := io.ReadAll(f)
+	assert.Equal(t, "hello", string(content))
+}
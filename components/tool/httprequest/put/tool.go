@@ -23,10 +23,18 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/internal"
+
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/components/tool/utils"
 )
 
+// BasicAuth holds the credentials of HTTP basic authentication.
+type BasicAuth = internal.BasicAuth
+
+// BearerToken is a token of bearer authentication, hidden when printed.
+type BearerToken = internal.BearerToken
+
 type Config struct {
 	// Inspired by the "Requests" tool from the LangChain project, specifically the RequestsPutTool.
 	// For more details, visit: https://python.langchain.com/docs/integrations/tools/requests/
@@ -51,6 +59,87 @@ type Config struct {
 	// If not provided, a default client with a 30-second timeout and a standard transport
 	// will be initialized and used.
 	HttpClient *http.Client
+
+	// Optional. Default: nil, which keeps the redirect policy of HttpClient.
+	// FollowRedirects controls whether redirects are followed. When false, the
+	// redirect response itself is returned. When following redirects, the
+	// Authorization header is dropped once a redirect leaves the original host.
+	FollowRedirects *bool `json:"follow_redirects,omitempty"`
+
+	// Optional. Default: 10.
+	// MaxRedirects caps the number of redirects followed for a single request.
+	MaxRedirects int `json:"max_redirects"`
+
+	// Optional.
+	// CookieJar stores the cookies set by responses and sends them with later requests,
+	// so that a session established by one tool call is reused by the following ones.
+	// Share the same jar between tools, e.g. one created by net/http/cookiejar.New,
+	// to keep a session across them.
+	CookieJar http.CookieJar `json:"-"`
+
+	// Optional.
+	// AllowedHosts restricts the hosts the tool may call, e.g. "api.example.com" or
+	// "*.example.com" for any subdomain. An empty list allows every host.
+	AllowedHosts []string `json:"allowed_hosts"`
+
+	// Optional.
+	// DeniedCIDRs lists the IP ranges, e.g. "203.0.113.0/24", that the target host must not
+	// resolve to. Once it is set, loopback, private and link-local ranges, including cloud
+	// metadata endpoints such as 169.254.169.254, are blocked as well.
+	// Targets are checked before every request is sent, redirects included, and the address
+	// each connection is made to is checked again, unless the transport of HttpClient goes through
+	// a proxy. Without HttpClient or a transport, requests are then sent directly, ignoring the
+	// proxy environment variables.
+	DeniedCIDRs []string `json:"denied_cidrs"`
+
+	// Optional.
+	// BearerToken is sent as "Authorization: Bearer <token>" with every request.
+	// It takes precedence over BasicAuth and is never serialized or printed.
+	BearerToken BearerToken `json:"-"`
+
+	// Optional.
+	// BasicAuth is sent as HTTP basic authentication with every request
+	// when BearerToken is empty. Its password is never serialized or printed.
+	BasicAuth *BasicAuth `json:"basic_auth,omitempty"`
+
+	// Optional. Default: false.
+	// IncludeStatus makes the tool return a JSON envelope of the form
+	// {"status":200,"body":"..."} instead of the bare response body, so that
+	// the model can tell a successful response from an error response.
+	IncludeStatus bool `json:"include_status"`
+
+	// Optional. Default: 0, which means no limit.
+	// MaxResponseBytes caps the number of response body bytes read by the tool.
+	// Longer bodies are cut off and marked as truncated in the output.
+	MaxResponseBytes int64 `json:"max_response_bytes"`
+
+	// Optional.
+	// ResponseHeaders lists the response headers, e.g. "Location" or "Link", to return
+	// to the model. When set, the output is a JSON envelope of the form
+	// {"status":200,"headers":{"Location":"..."},"body":"..."}.
+	ResponseHeaders []string `json:"response_headers"`
+
+	// Optional. Default: 0, which means no retry.
+	// MaxRetries is the number of times a request is retried after a network error,
+	// a 5xx response or a 429 response.
+	MaxRetries int `json:"max_retries"`
+
+	// Optional. Default: 500 milliseconds.
+	// RetryBackoff is the wait before the first retry, doubled on every following retry.
+	// A Retry-After header in the response takes precedence over it.
+	RetryBackoff time.Duration `json:"retry_backoff"`
+
+	// Optional. Default: false.
+	// ParseJSON validates the response body as JSON and returns it pretty-printed,
+	// which makes structured responses easier for the model to read. When the body
+	// is not valid JSON it is returned unchanged together with a note on the error.
+	ParseJSON bool `json:"parse_json"`
+
+	// Optional. Default: "eino-ext-httprequest/1.0".
+	// UserAgent is sent as the User-Agent header of every request. The tool also
+	// asks for JSON with "Accept: application/json". Both can be overridden
+	// through Headers.
+	UserAgent string `json:"user_agent"`
 }
 
 func (c *Config) validate() error {
@@ -68,11 +157,14 @@ func (c *Config) validate() error {
 	if c.Headers == nil {
 		c.Headers = make(map[string]string)
 	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = 500 * time.Millisecond
+	}
+	if c.UserAgent == "" {
+		c.UserAgent = internal.DefaultUserAgent
+	}
 	if c.HttpClient == nil {
-		c.HttpClient = &http.Client{
-			Timeout:   30 * time.Second,
-			Transport: &http.Transport{},
-		}
+		c.HttpClient = internal.NewDefaultClient()
 	}
 	return nil
 }
@@ -104,8 +196,18 @@ func newRequestTool(config *Config) (*PutRequestTool, error) {
 		return nil, err
 	}
 
+	guard, err := internal.NewHostGuard(config.AllowedHosts, config.DeniedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
 	return &PutRequestTool{
 		config: config,
-		client: config.HttpClient,
+		client: internal.ConfigureClient(config.HttpClient, &internal.ClientOptions{
+			FollowRedirects: config.FollowRedirects,
+			MaxRedirects:    config.MaxRedirects,
+			CookieJar:       config.CookieJar,
+			HostGuard:       guard,
+		}),
 	}, nil
 }
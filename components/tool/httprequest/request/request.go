@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/internal"
+)
+
+type Request struct {
+	Method         string            `json:"method" jsonschema_description:"The HTTP method of the request, e.g. GET or POST"`
+	URL            string            `json:"url" jsonschema_description:"The URL to send the request to"`
+	Body           string            `json:"body,omitempty" jsonschema_description:"The body to send in the request"`
+	Params         map[string]string `json:"params,omitempty" jsonschema_description:"The query parameters to append to the URL, they will be URL-encoded"`
+	Headers        map[string]string `json:"headers,omitempty" jsonschema_description:"The HTTP headers to send with this request, they override the configured headers with the same name"`
+	TimeoutSeconds int               `json:"timeout_seconds,omitempty" jsonschema_description:"The maximum number of seconds to wait for this request, it must be positive when set"`
+	ResponsePath   string            `json:"response_path,omitempty" jsonschema_description:"A dot-path such as data.items[0].name selecting the part of a JSON response to return, the full body is returned when it does not match"`
+}
+
+func (r *RequestTool) Request(ctx context.Context, req *Request) (string, error) {
+	method := strings.ToUpper(req.Method)
+	if !r.isAllowed(method) {
+		return "", fmt.Errorf("method %q is not allowed, allowed methods: %s", req.Method, strings.Join(r.config.AllowedMethods, ", "))
+	}
+
+	ctx, cancel, err := internal.WithTimeout(ctx, req.TimeoutSeconds)
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+
+	reqURL, err := internal.BuildURL(req.URL, req.Params)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request url: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, reqURL, strings.NewReader(req.Body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	internal.SetDefaultHeaders(httpReq, r.config.UserAgent)
+	for key, value := range r.config.Headers {
+		httpReq.Header.Set(key, value)
+	}
+	internal.SetAuth(httpReq, r.config.BearerToken, r.config.BasicAuth)
+	for key, value := range req.Headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	policy := internal.RetryPolicy{Backoff: r.config.RetryBackoff}
+	if isIdempotent(method) || r.config.RetryNonIdempotent {
+		policy.MaxRetries = r.config.MaxRetries
+	}
+
+	resp, err := internal.Do(r.client, httpReq, policy)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, truncated, err := internal.ReadBody(resp, r.config.MaxResponseBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	output := &internal.Response{
+		Status:    resp.StatusCode,
+		Headers:   internal.SelectHeaders(resp.Header, r.config.ResponseHeaders),
+		Body:      string(body),
+		Truncated: truncated,
+	}
+	output.ExtractPath(req.ResponsePath)
+	if r.config.ParseJSON {
+		output.FormatJSON()
+	}
+
+	return internal.FormatOutput(output, r.config.IncludeStatus || len(r.config.ResponseHeaders) > 0)
+}
+
+func (r *RequestTool) isAllowed(method string) bool {
+	for _, allowed := range r.config.AllowedMethods {
+		if strings.ToUpper(allowed) == method {
+			return true
+		}
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
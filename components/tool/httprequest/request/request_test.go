@@ -0,0 +1,173 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package request
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTransport struct {
+	RoundTripFunc func(*http.Request) (*http.Response, error)
+}
+
+func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return m.RoundTripFunc(req)
+}
+
+func newTestTool(config *Config, roundTrip func(*http.Request) (*http.Response, error)) *RequestTool {
+	_ = config.validate()
+	return &RequestTool{
+		config: config,
+		client: &http.Client{Transport: &mockTransport{RoundTripFunc: roundTrip}},
+	}
+}
+
+func TestRequest_Get(t *testing.T) {
+	tool := newTestTool(&Config{
+		Headers: map[string]string{"User-Agent": "test-agent"},
+	}, func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet || req.URL.String() != "https://example.com/resource?q=a+b" {
+			return nil, fmt.Errorf("unexpected method or URL: %s %s", req.Method, req.URL)
+		}
+		if req.Header.Get("User-Agent") != "test-agent" {
+			return nil, fmt.Errorf("missing config header")
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Hello, World!"}`)),
+		}, nil
+	})
+
+	result, err := tool.Request(context.Background(), &Request{
+		Method: "get",
+		URL:    "https://example.com/resource",
+		Params: map[string]string{"q": "a b"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"message": "Hello, World!"}`, result)
+}
+
+func TestRequest_Post(t *testing.T) {
+	tool := newTestTool(&Config{
+		IncludeStatus: true,
+	}, func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		if req.Method != http.MethodPost || string(body) != `{"key":"value"}` {
+			return nil, fmt.Errorf("unexpected method or body")
+		}
+		if req.Header.Get("Content-Type") != "application/json" {
+			return nil, fmt.Errorf("missing request header")
+		}
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       io.NopCloser(strings.NewReader(`{"id":1}`)),
+		}, nil
+	})
+
+	result, err := tool.Request(context.Background(), &Request{
+		Method:  http.MethodPost,
+		URL:     "https://example.com/resource",
+		Body:    `{"key":"value"}`,
+		Headers: map[string]string{"Content-Type": "application/json"},
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status": 201, "body": "{\"id\":1}"}`, result)
+}
+
+func TestRequest_MethodNotAllowed(t *testing.T) {
+	called := false
+	tool := newTestTool(&Config{
+		AllowedMethods: []string{"get"},
+	}, func(req *http.Request) (*http.Response, error) {
+		called = true
+		return nil, fmt.Errorf("should not be called")
+	})
+
+	_, err := tool.Request(context.Background(), &Request{
+		Method: http.MethodDelete,
+		URL:    "https://example.com/resource",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `method "DELETE" is not allowed`)
+	assert.False(t, called)
+}
+
+func TestRequest_RetryOnlyIdempotent(t *testing.T) {
+	calls := 0
+	tool := newTestTool(&Config{
+		AllowedMethods: []string{http.MethodGet, http.MethodPatch},
+		MaxRetries:     2,
+		RetryBackoff:   time.Millisecond,
+	}, func(req *http.Request) (*http.Response, error) {
+		calls++
+		return nil, fmt.Errorf("network error")
+	})
+
+	_, err := tool.Request(context.Background(), &Request{Method: http.MethodGet, URL: "https://example.com"})
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	_, err = tool.Request(context.Background(), &Request{Method: http.MethodPatch, URL: "https://example.com"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to execute request")
+	assert.Equal(t, 1, calls)
+}
+
+func TestConfig_Validate_Defaults(t *testing.T) {
+	config := &Config{}
+	err := config.validate()
+	assert.NoError(t, err)
+	assert.Equal(t, "requests", config.ToolName)
+	assert.Equal(t, []string{"GET", "POST", "PUT", "DELETE"}, config.AllowedMethods)
+	assert.Contains(t, config.ToolDesc, "GET, POST, PUT, DELETE")
+	assert.NotNil(t, config.Headers)
+	assert.NotNil(t, config.HttpClient)
+	assert.Equal(t, 30*time.Second, config.HttpClient.Timeout)
+}
+
+func TestNewTool_Config(t *testing.T) {
+	mockey.PatchConvey("NilConfig", t, func() {
+		_, err := NewTool(context.Background(), nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "request tool configuration is required")
+	})
+
+	mockey.PatchConvey("WithConfig", t, func() {
+		tool, err := NewTool(context.Background(), &Config{})
+		assert.NoError(t, err)
+
+		info, err := tool.Info(context.Background())
+		assert.Nil(t, err)
+
+		doc, err := info.ParamsOneOf.ToJSONSchema()
+		assert.Nil(t, err)
+		assert.Equal(t, 7, doc.Properties.Len())
+		for pair := doc.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			assert.NotEqual(t, "", pair.Value.Description)
+		}
+	})
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/internal"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+// BasicAuth holds the credentials of HTTP basic authentication.
+type BasicAuth = internal.BasicAuth
+
+// BearerToken is a token of bearer authentication, hidden when printed.
+type BearerToken = internal.BearerToken
+
+type Config struct {
+	// Optional. Default: "requests".
+	ToolName string `json:"tool_name"`
+
+	// Optional. Default: A portal to the internet.
+	// Use this when you need to send an HTTP request to a website.
+	// Input should be a JSON string with the keys "method", "url" and optionally
+	// "body", "params" and "headers". The allowed methods are appended to the description.
+	// The output will be the text response of the request.
+	ToolDesc string `json:"tool_desc"`
+
+	// Optional. Default: GET, POST, PUT and DELETE.
+	// AllowedMethods lists the HTTP methods the model is allowed to use, any other
+	// method is rejected before the request is sent.
+	AllowedMethods []string `json:"allowed_methods"`
+
+	// Optional.
+	// Headers is a map of HTTP header names to their corresponding values.
+	// These headers will be included in every request made by the tool.
+	Headers map[string]string `json:"headers"`
+
+	// Optional.
+	// HttpClient is the HTTP client used to perform the requests.
+	// If not provided, a default client with a 30-second timeout and a standard transport
+	// will be initialized and used.
+	HttpClient *http.Client
+
+	// Optional. Default: nil, which keeps the redirect policy of HttpClient.
+	// FollowRedirects controls whether redirects are followed. When false, the
+	// redirect response itself is returned. When following redirects, the
+	// Authorization header is dropped once a redirect leaves the original host.
+	FollowRedirects *bool `json:"follow_redirects,omitempty"`
+
+	// Optional. Default: 10.
+	// MaxRedirects caps the number of redirects followed for a single request.
+	MaxRedirects int `json:"max_redirects"`
+
+	// Optional.
+	// CookieJar stores the cookies set by responses and sends them with later requests,
+	// so that a session established by one tool call is reused by the following ones.
+	// Share the same jar between tools, e.g. one created by net/http/cookiejar.New,
+	// to keep a session across them.
+	CookieJar http.CookieJar `json:"-"`
+
+	// Optional.
+	// AllowedHosts restricts the hosts the tool may call, e.g. "api.example.com" or
+	// "*.example.com" for any subdomain. An empty list allows every host.
+	AllowedHosts []string `json:"allowed_hosts"`
+
+	// Optional.
+	// DeniedCIDRs lists the IP ranges, e.g. "203.0.113.0/24", that the target host must not
+	// resolve to. Once it is set, loopback, private and link-local ranges, including cloud
+	// metadata endpoints such as 169.254.169.254, are blocked as well.
+	// Targets are checked before every request is sent, redirects included, and the address
+	// each connection is made to is checked again, unless the transport of HttpClient goes through
+	// a proxy. Without HttpClient or a transport, requests are then sent directly, ignoring the
+	// proxy environment variables.
+	DeniedCIDRs []string `json:"denied_cidrs"`
+
+	// Optional.
+	// BearerToken is sent as "Authorization: Bearer <token>" with every request.
+	// It takes precedence over BasicAuth and is never serialized or printed.
+	BearerToken BearerToken `json:"-"`
+
+	// Optional.
+	// BasicAuth is sent as HTTP basic authentication with every request
+	// when BearerToken is empty. Its password is never serialized or printed.
+	BasicAuth *BasicAuth `json:"basic_auth,omitempty"`
+
+	// Optional. Default: false.
+	// IncludeStatus makes the tool return a JSON envelope of the form
+	// {"status":200,"body":"..."} instead of the bare response body.
+	IncludeStatus bool `json:"include_status"`
+
+	// Optional. Default: 0, which means no limit.
+	// MaxResponseBytes caps the number of response body bytes read by the tool.
+	// Longer bodies are cut off and marked as truncated in the output.
+	MaxResponseBytes int64 `json:"max_response_bytes"`
+
+	// Optional.
+	// ResponseHeaders lists the response headers, e.g. "Location" or "Link", to return
+	// to the model. When set, the output is a JSON envelope carrying the status,
+	// headers and body.
+	ResponseHeaders []string `json:"response_headers"`
+
+	// Optional. Default: 0, which means no retry.
+	// MaxRetries is the number of times a request is retried after a network error,
+	// a 5xx response or a 429 response. Requests with a non-idempotent method,
+	// such as POST or PATCH, are only retried when RetryNonIdempotent is true.
+	MaxRetries int `json:"max_retries"`
+
+	// Optional. Default: 500 milliseconds.
+	// RetryBackoff is the wait before the first retry, doubled on every following retry.
+	// A Retry-After header in the response takes precedence over it.
+	RetryBackoff time.Duration `json:"retry_backoff"`
+
+	// Optional. Default: false.
+	// RetryNonIdempotent enables retrying requests with a non-idempotent method.
+	RetryNonIdempotent bool `json:"retry_non_idempotent"`
+
+	// Optional. Default: false.
+	// ParseJSON validates the response body as JSON and returns it pretty-printed,
+	// which makes structured responses easier for the model to read. When the body
+	// is not valid JSON it is returned unchanged together with a note on the error.
+	ParseJSON bool `json:"parse_json"`
+
+	// Optional. Default: "eino-ext-httprequest/1.0".
+	// UserAgent is sent as the User-Agent header of every request. The tool also
+	// asks for JSON with "Accept: application/json". Both can be overridden
+	// through Headers.
+	UserAgent string `json:"user_agent"`
+}
+
+func (c *Config) validate() error {
+	if c.ToolName == "" {
+		c.ToolName = "requests"
+	}
+	if len(c.AllowedMethods) == 0 {
+		c.AllowedMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}
+	}
+	methods := make([]string, 0, len(c.AllowedMethods))
+	for _, method := range c.AllowedMethods {
+		methods = append(methods, strings.ToUpper(method))
+	}
+	c.AllowedMethods = methods
+	if c.ToolDesc == "" {
+		c.ToolDesc = fmt.Sprintf(`A portal to the internet.
+		Use this when you need to send an HTTP request to a website.
+		Input should be a JSON string with the keys "method", "url" and optionally "body", "params" and "headers".
+		The value of "method" must be one of %s.
+		The output will be the text response of the request.`, strings.Join(c.AllowedMethods, ", "))
+	}
+	if c.Headers == nil {
+		c.Headers = make(map[string]string)
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = 500 * time.Millisecond
+	}
+	if c.UserAgent == "" {
+		c.UserAgent = internal.DefaultUserAgent
+	}
+	if c.HttpClient == nil {
+		c.HttpClient = internal.NewDefaultClient()
+	}
+	return nil
+}
+
+func NewTool(ctx context.Context, config *Config) (tool.InvokableTool, error) {
+	reqTool, err := newRequestTool(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request tool: %w", err)
+	}
+
+	invokableTool, err := utils.InferTool(config.ToolName, config.ToolDesc, reqTool.Request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer the tool: %w", err)
+	}
+
+	return invokableTool, nil
+}
+
+type RequestTool struct {
+	config *Config
+	client *http.Client
+}
+
+func newRequestTool(config *Config) (*RequestTool, error) {
+	if config == nil {
+		return nil, errors.New("request tool configuration is required")
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	guard, err := internal.NewHostGuard(config.AllowedHosts, config.DeniedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RequestTool{
+		config: config,
+		client: internal.ConfigureClient(config.HttpClient, &internal.ClientOptions{
+			FollowRedirects: config.FollowRedirects,
+			MaxRedirects:    config.MaxRedirects,
+			CookieJar:       config.CookieJar,
+			HostGuard:       guard,
+		}),
+	}, nil
+}
@@ -0,0 +1,519 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package structured validates structured (JSON) model outputs against the schema
+// that was requested from the model, so that chat models with a structured-output
+// mode (e.g. Ark response_format, Gemini ResponseJSONSchema) can surface a
+// non-conforming response as an error instead of returning it silently.
+package structured
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eino-contrib/jsonschema"
+)
+
+// ErrSchemaMismatch is returned when a model response does not conform to the requested schema.
+type ErrSchemaMismatch struct {
+	// Path locates the offending value in the response, e.g. "$.items[2].name".
+	Path string
+	// Reason describes why the value does not match.
+	Reason string
+}
+
+func (e *ErrSchemaMismatch) Error() string {
+	return fmt.Sprintf("response does not match the requested schema at %s: %s", e.Path, e.Reason)
+}
+
+// Validate checks that data is a JSON document conforming to s.
+// It returns an *ErrSchemaMismatch describing the first violation found, or nil if data matches.
+// A nil schema accepts any valid JSON.
+func Validate(s *jsonschema.Schema, data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return &ErrSchemaMismatch{Path: rootPath, Reason: fmt.Sprintf("invalid JSON: %v", err)}
+	}
+	if dec.More() {
+		return &ErrSchemaMismatch{Path: rootPath, Reason: "invalid JSON: unexpected data after the top-level value"}
+	}
+
+	return (&validator{root: s}).validate(s, v, rootPath)
+}
+
+// ValidateString is like Validate for a message content.
+func ValidateString(s *jsonschema.Schema, content string) error {
+	return Validate(s, []byte(content))
+}
+
+// SchemaFrom converts a schema given in another representation, e.g. a map or the raw JSON
+// used by a provider SDK, into a *jsonschema.Schema usable with Validate.
+func SchemaFrom(v any) (*jsonschema.Schema, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case *jsonschema.Schema:
+		return t, nil
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	switch t := v.(type) {
+	case []byte:
+		b = t
+	case json.RawMessage:
+		b = t
+	case string:
+		b = []byte(t)
+	default:
+		if b, err = json.Marshal(v); err != nil {
+			return nil, fmt.Errorf("marshal schema failed: %w", err)
+		}
+	}
+
+	s := &jsonschema.Schema{}
+	if err = json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("unmarshal schema failed: %w", err)
+	}
+
+	return s, nil
+}
+
+const rootPath = "$"
+
+type validator struct {
+	root *jsonschema.Schema
+}
+
+func (vd *validator) validate(s *jsonschema.Schema, v any, path string) error {
+	if s == nil {
+		return nil
+	}
+	if b, ok := booleanSchema(s); ok {
+		if !b {
+			return mismatch(path, "no value is allowed here")
+		}
+		return nil
+	}
+
+	if s.Ref != "" {
+		ref, err := vd.resolve(s.Ref)
+		if err != nil {
+			return mismatch(path, err.Error())
+		}
+		if err = vd.validate(ref, v, path); err != nil {
+			return err
+		}
+	}
+
+	if err := checkType(s, v, path); err != nil {
+		return err
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if equalJSON(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return mismatch(path, fmt.Sprintf("value %s is not one of %s", render(v), render(s.Enum)))
+		}
+	}
+	if s.Const != nil && !equalJSON(s.Const, v) {
+		return mismatch(path, fmt.Sprintf("value %s does not equal %s", render(v), render(s.Const)))
+	}
+
+	var err error
+	switch t := v.(type) {
+	case string:
+		err = checkString(s, t, path)
+	case json.Number:
+		err = checkNumber(s, t, path)
+	case []any:
+		err = vd.checkArray(s, t, path)
+	case map[string]any:
+		err = vd.checkObject(s, t, path)
+	}
+	if err != nil {
+		return err
+	}
+
+	return vd.checkCombinators(s, v, path)
+}
+
+func (vd *validator) checkCombinators(s *jsonschema.Schema, v any, path string) error {
+	for _, sub := range s.AllOf {
+		if err := vd.validate(sub, v, path); err != nil {
+			return err
+		}
+	}
+
+	if len(s.AnyOf) > 0 {
+		var firstErr error
+		for _, sub := range s.AnyOf {
+			err := vd.validate(sub, v, path)
+			if err == nil {
+				firstErr = nil
+				break
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		if firstErr != nil {
+			return mismatch(path, fmt.Sprintf("value matches none of anyOf: %v", firstErr))
+		}
+	}
+
+	if len(s.OneOf) > 0 {
+		matched := 0
+		for _, sub := range s.OneOf {
+			if vd.validate(sub, v, path) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return mismatch(path, fmt.Sprintf("value matches %d of oneOf, expected exactly 1", matched))
+		}
+	}
+
+	if s.Not != nil && vd.validate(s.Not, v, path) == nil {
+		return mismatch(path, "value must not match the \"not\" schema")
+	}
+
+	return nil
+}
+
+func (vd *validator) checkArray(s *jsonschema.Schema, arr []any, path string) error {
+	if s.MinItems != nil && uint64(len(arr)) < *s.MinItems {
+		return mismatch(path, fmt.Sprintf("array has %d items, expected at least %d", len(arr), *s.MinItems))
+	}
+	if s.MaxItems != nil && uint64(len(arr)) > *s.MaxItems {
+		return mismatch(path, fmt.Sprintf("array has %d items, expected at most %d", len(arr), *s.MaxItems))
+	}
+
+	for i, item := range arr {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		var itemSchema *jsonschema.Schema
+		if i < len(s.PrefixItems) {
+			itemSchema = s.PrefixItems[i]
+		} else {
+			itemSchema = s.Items
+		}
+		if err := vd.validate(itemSchema, item, itemPath); err != nil {
+			return err
+		}
+	}
+
+	if s.UniqueItems {
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if equalJSON(arr[i], arr[j]) {
+					return mismatch(fmt.Sprintf("%s[%d]", path, j), fmt.Sprintf("duplicate of item %d", i))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (vd *validator) checkObject(s *jsonschema.Schema, obj map[string]any, path string) error {
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			return mismatch(propertyPath(path, name), "required property is missing")
+		}
+	}
+
+	if s.MinProperties != nil && uint64(len(obj)) < *s.MinProperties {
+		return mismatch(path, fmt.Sprintf("object has %d properties, expected at least %d", len(obj), *s.MinProperties))
+	}
+	if s.MaxProperties != nil && uint64(len(obj)) > *s.MaxProperties {
+		return mismatch(path, fmt.Sprintf("object has %d properties, expected at most %d", len(obj), *s.MaxProperties))
+	}
+
+	// iterate in the schema order first, so that the reported violation is deterministic
+	declared := make(map[string]bool)
+	if s.Properties != nil {
+		for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			declared[pair.Key] = true
+			val, ok := obj[pair.Key]
+			if !ok {
+				continue
+			}
+			if err := vd.validate(pair.Value, val, propertyPath(path, pair.Key)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(obj) {
+		if declared[name] {
+			continue
+		}
+
+		matchedPattern := false
+		for pattern, sub := range s.PatternProperties {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return mismatch(path, fmt.Sprintf("invalid patternProperties pattern %q: %v", pattern, err))
+			}
+			if re.MatchString(name) {
+				matchedPattern = true
+				if err = vd.validate(sub, obj[name], propertyPath(path, name)); err != nil {
+					return err
+				}
+			}
+		}
+		if matchedPattern || s.AdditionalProperties == nil {
+			continue
+		}
+
+		if b, ok := booleanSchema(s.AdditionalProperties); ok && !b {
+			return mismatch(propertyPath(path, name), "additional property is not allowed")
+		}
+		if err := vd.validate(s.AdditionalProperties, obj[name], propertyPath(path, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (vd *validator) resolve(ref string) (*jsonschema.Schema, error) {
+	if ref == "#" {
+		return vd.root, nil
+	}
+
+	for _, prefix := range []string{"#/$defs/", "#/definitions/"} {
+		if name := strings.TrimPrefix(ref, prefix); name != ref && vd.root != nil {
+			if def, ok := vd.root.Definitions[name]; ok {
+				return def, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unresolvable $ref %q", ref)
+}
+
+func checkType(s *jsonschema.Schema, v any, path string) error {
+	types := s.TypeEnhanced
+	if s.Type != "" {
+		types = []string{s.Type}
+	}
+	if len(types) == 0 {
+		return nil
+	}
+
+	actual := typeOf(v)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return nil
+		}
+	}
+
+	return mismatch(path, fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), actual))
+}
+
+func checkString(s *jsonschema.Schema, str string, path string) error {
+	length := uint64(utf8.RuneCountInString(str))
+	if s.MinLength != nil && length < *s.MinLength {
+		return mismatch(path, fmt.Sprintf("string has %d characters, expected at least %d", length, *s.MinLength))
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		return mismatch(path, fmt.Sprintf("string has %d characters, expected at most %d", length, *s.MaxLength))
+	}
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return mismatch(path, fmt.Sprintf("invalid pattern %q: %v", s.Pattern, err))
+		}
+		if !re.MatchString(str) {
+			return mismatch(path, fmt.Sprintf("string %q does not match pattern %q", str, s.Pattern))
+		}
+	}
+
+	return nil
+}
+
+func checkNumber(s *jsonschema.Schema, n json.Number, path string) error {
+	val, ok := new(big.Rat).SetString(n.String())
+	if !ok {
+		return mismatch(path, fmt.Sprintf("invalid number %s", n))
+	}
+
+	bounds := []struct {
+		limit json.Number
+		ok    func(cmp int) bool
+		desc  string
+	}{
+		{s.Minimum, func(cmp int) bool { return cmp >= 0 }, ">="},
+		{s.Maximum, func(cmp int) bool { return cmp <= 0 }, "<="},
+		{s.ExclusiveMinimum, func(cmp int) bool { return cmp > 0 }, ">"},
+		{s.ExclusiveMaximum, func(cmp int) bool { return cmp < 0 }, "<"},
+	}
+	for _, b := range bounds {
+		if b.limit == "" {
+			continue
+		}
+		limit, ok := new(big.Rat).SetString(b.limit.String())
+		if !ok {
+			continue
+		}
+		if !b.ok(val.Cmp(limit)) {
+			return mismatch(path, fmt.Sprintf("%s is not %s %s", n, b.desc, b.limit))
+		}
+	}
+
+	if s.MultipleOf != "" {
+		div, ok := new(big.Rat).SetString(s.MultipleOf.String())
+		if ok && div.Sign() != 0 && !new(big.Rat).Quo(val, div).IsInt() {
+			return mismatch(path, fmt.Sprintf("%s is not a multiple of %s", n, s.MultipleOf))
+		}
+	}
+
+	return nil
+}
+
+func typeOf(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(t.String()); ok && r.IsInt() {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// booleanSchema reports whether s is the boolean schema true or false, which jsonschema keeps unexported.
+func booleanSchema(s *jsonschema.Schema) (value bool, ok bool) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return false, false
+	}
+
+	switch string(b) {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// equalJSON compares two JSON values, treating numbers by value.
+func equalJSON(a, b any) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func normalize(v any) any {
+	switch t := v.(type) {
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(t.String()); ok {
+			return r.RatString()
+		}
+		return t.String()
+	case float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		if r, ok := new(big.Rat).SetString(fmt.Sprint(t)); ok {
+			return r.RatString()
+		}
+		return fmt.Sprint(t)
+	case []any:
+		out := make([]any, len(t))
+		for i := range t {
+			out[i] = normalize(t[i])
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, val := range t {
+			out[k] = normalize(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func render(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func propertyPath(path, name string) string {
+	if isIdentifier(name) {
+		return path + "." + name
+	}
+	return fmt.Sprintf("%s[%q]", path, name)
+}
+
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func mismatch(path, reason string) error {
+	return &ErrSchemaMismatch{Path: path, Reason: reason}
+}
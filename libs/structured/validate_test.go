@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package structured
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eino-contrib/jsonschema"
+	"github.com/stretchr/testify/assert"
+)
+
+const personSchema = `{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "minLength": 1},
+    "age": {"type": "integer", "minimum": 0},
+    "role": {"enum": ["admin", "user"]},
+    "tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2},
+    "address": {"$ref": "#/$defs/address"}
+  },
+  "required": ["name", "age"],
+  "additionalProperties": false,
+  "$defs": {
+    "address": {
+      "type": "object",
+      "properties": {"city": {"type": "string"}},
+      "required": ["city"]
+    }
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	s, err := SchemaFrom(personSchema)
+	assert.NoError(t, err)
+
+	t.Run("conforming", func(t *testing.T) {
+		err := ValidateString(s, `{"name": "bob", "age": 3, "role": "user", "tags": ["a"], "address": {"city": "x"}}`)
+		assert.NoError(t, err)
+	})
+
+	cases := []struct {
+		name    string
+		content string
+		path    string
+		reason  string
+	}{
+		{"invalid_json", `{"name": "bob",`, "$", "invalid JSON"},
+		{"trailing_data", `{"name": "bob", "age": 1} {}`, "$", "unexpected data"},
+		{"root_type", `[1, 2]`, "$", "expected object, got array"},
+		{"missing_required", `{"name": "bob"}`, "$.age", "required property is missing"},
+		{"wrong_type", `{"name": "bob", "age": "3"}`, "$.age", "expected integer, got string"},
+		{"not_integer", `{"name": "bob", "age": 3.5}`, "$.age", "expected integer, got number"},
+		{"minimum", `{"name": "bob", "age": -1}`, "$.age", "-1 is not >= 0"},
+		{"min_length", `{"name": "", "age": 1}`, "$.name", "expected at least 1"},
+		{"enum", `{"name": "bob", "age": 1, "role": "root"}`, "$.role", `value "root" is not one of ["admin","user"]`},
+		{"array_item", `{"name": "bob", "age": 1, "tags": ["a", 2]}`, "$.tags[1]", "expected string, got integer"},
+		{"max_items", `{"name": "bob", "age": 1, "tags": ["a", "b", "c"]}`, "$.tags", "expected at most 2"},
+		{"additional_property", `{"name": "bob", "age": 1, "extra-field": true}`, `$["extra-field"]`, "additional property is not allowed"},
+		{"ref", `{"name": "bob", "age": 1, "address": {}}`, "$.address.city", "required property is missing"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateString(s, c.content)
+
+			var mismatchErr *ErrSchemaMismatch
+			assert.True(t, errors.As(err, &mismatchErr), "expected ErrSchemaMismatch, got %v", err)
+			assert.Equal(t, c.path, mismatchErr.Path)
+			assert.Contains(t, mismatchErr.Reason, c.reason)
+			assert.Contains(t, err.Error(), c.path)
+		})
+	}
+}
+
+func TestValidateCombinators(t *testing.T) {
+	s, err := SchemaFrom(map[string]any{
+		"anyOf": []any{
+			map[string]any{"type": "string"},
+			map[string]any{"type": "number", "multipleOf": 0.5},
+		},
+		"not": map[string]any{"const": "forbidden"},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, ValidateString(s, `"ok"`))
+	assert.NoError(t, ValidateString(s, `1.5`))
+	assert.Error(t, ValidateString(s, `1.2`))
+	assert.Error(t, ValidateString(s, `true`))
+	assert.Error(t, ValidateString(s, `"forbidden"`))
+
+	oneOf, err := SchemaFrom(`{"oneOf": [{"type": "integer"}, {"type": "number"}]}`)
+	assert.NoError(t, err)
+	assert.NoError(t, ValidateString(oneOf, `1.5`))
+	err = ValidateString(oneOf, `1`)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "matches 2 of oneOf")
+}
+
+func TestSchemaFrom(t *testing.T) {
+	s, err := SchemaFrom(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+	assert.NoError(t, ValidateString(s, `{"anything": [1, 2]}`))
+
+	orig := &jsonschema.Schema{Type: "string"}
+	s, err = SchemaFrom(orig)
+	assert.NoError(t, err)
+	assert.Same(t, orig, s)
+
+	_, err = SchemaFrom(`{"type": `)
+	assert.Error(t, err)
+}